@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Maintenance commands for operators",
+	Long:  "Operator tooling that works across posts rather than on one post, such as photo storage cleanup.",
+}
+
+var adminPhotosCmd = &cobra.Command{
+	Use:   "photos",
+	Short: "Photo storage maintenance",
+}
+
+func init() {
+	rootCmd.AddCommand(adminCmd)
+	adminCmd.AddCommand(adminPhotosCmd)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var adminPhotosBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Backfill public.photo rows from legacy post photo columns",
+	Long: `Derive public.photo rows from image_source1..4 / photo1..4_file_name in
+post-id batches (the Go port of supabase/scripts/backfill_photo_incremental.sql).
+Progress is checkpointed after every batch, so an interrupted run resumes where it
+stopped. Posts that already have photo rows are skipped. By default every derived
+object is checked in photo storage first, and missing objects are reported, not inserted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		flags := cmd.Flags()
+		job, err := flags.GetString("job")
+		if err != nil {
+			return fmt.Errorf("reading job flag: %w", err)
+		}
+		batchSize, err := flags.GetInt("batch-size")
+		if err != nil {
+			return fmt.Errorf("reading batch-size flag: %w", err)
+		}
+		if batchSize < 1 {
+			return fmt.Errorf("--batch-size must be positive")
+		}
+		maxBatches, err := flags.GetInt("max-batches")
+		if err != nil {
+			return fmt.Errorf("reading max-batches flag: %w", err)
+		}
+		dryRun, err := flags.GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("reading dry-run flag: %w", err)
+		}
+		verify, err := flags.GetBool("verify")
+		if err != nil {
+			return fmt.Errorf("reading verify flag: %w", err)
+		}
+		reset, err := flags.GetBool("reset")
+		if err != nil {
+			return fmt.Errorf("reading reset flag: %w", err)
+		}
+		checkpointDir, err := flags.GetString("checkpoint-dir")
+		if err != nil {
+			return fmt.Errorf("reading checkpoint-dir flag: %w", err)
+		}
+
+		checkpoints := adapters.NewFilePhotoBackfillCheckpoints(checkpointDir)
+		if reset {
+			if err := checkpoints.ResetPhotoBackfillCheckpoint(job); err != nil {
+				return fmt.Errorf("resetting checkpoint: %w", err)
+			}
+		}
+
+		var (
+			repo      service.PhotoBackfillRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		svc := service.NewPhotoBackfillService(repo, checkpoints).
+			WithProgress(func(batch domain.PhotoBackfillBatch) {
+				_ = adapters.RenderPhotoBackfillBatch(cmd.ErrOrStderr(), batch)
+			})
+		if verify {
+			checker, err := newPhotoObjectChecker(cmd.Context(), cfg)
+			if err != nil {
+				return err
+			}
+			svc = svc.WithObjectChecker(checker)
+		}
+
+		report, err := svc.Run(cmd.Context(), domain.PhotoBackfillOptions{
+			Job:        job,
+			BatchSize:  batchSize,
+			MaxBatches: maxBatches,
+			DryRun:     dryRun,
+			Verify:     verify,
+		})
+		if err != nil {
+			return fmt.Errorf("photo backfill: %w", err)
+		}
+		return renderPhotoBackfillOutput(cmd, cfg.Format, report, checkpoints.Path(report.Job))
+	},
+}
+
+func init() {
+	adminPhotosCmd.AddCommand(adminPhotosBackfillCmd)
+	adminPhotosBackfillCmd.Flags().Int("batch-size", 1000, "posts per batch (checkpointed after each)")
+	adminPhotosBackfillCmd.Flags().Int("max-batches", 0, "stop after this many batches (0 = until done)")
+	adminPhotosBackfillCmd.Flags().String("job", "photo_backfill_v1", "checkpoint name; a new name starts from the first post")
+	adminPhotosBackfillCmd.Flags().String("checkpoint-dir", "", "checkpoint directory (default: <user cache dir>/supost-cli/backfill)")
+	adminPhotosBackfillCmd.Flags().Bool("reset", false, "discard the job's checkpoint and start over")
+	adminPhotosBackfillCmd.Flags().Bool("verify", true, "skip photos whose post or ticker object is missing from photo storage")
+	adminPhotosBackfillCmd.Flags().Bool("dry-run", false, "report what would be inserted without writing rows or the checkpoint")
+}
+
+func renderPhotoBackfillOutput(cmd *cobra.Command, format string, report domain.PhotoBackfillReport, checkpointPath string) error {
+	if !cmd.Flags().Changed("format") && (format == "" || format == "json") {
+		return adapters.RenderPhotoBackfillReport(cmd.OutOrStdout(), report, checkpointPath)
+	}
+	if format == "text" || format == "table" {
+		return adapters.RenderPhotoBackfillReport(cmd.OutOrStdout(), report, checkpointPath)
+	}
+	return adapters.Render(format, report)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestAdminPhotosBackfill_ResumesFromLocalCheckpoint(t *testing.T) {
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	t.Cleanup(func() {
+		for name, def := range map[string]string{"checkpoint-dir": "", "verify": "true"} {
+			_ = adminPhotosBackfillCmd.Flags().Set(name, def)
+			adminPhotosBackfillCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+	if err := adminPhotosBackfillCmd.Flags().Set("checkpoint-dir", t.TempDir()); err != nil {
+		t.Fatalf("setting checkpoint-dir: %v", err)
+	}
+	if err := adminPhotosBackfillCmd.Flags().Set("verify", "false"); err != nil {
+		t.Fatalf("setting verify: %v", err)
+	}
+
+	run := func() (string, string) {
+		t.Helper()
+		var out, progress bytes.Buffer
+		adminPhotosBackfillCmd.SetOut(&out)
+		adminPhotosBackfillCmd.SetErr(&progress)
+		adminPhotosBackfillCmd.SetContext(t.Context())
+		if err := adminPhotosBackfillCmd.RunE(adminPhotosBackfillCmd, nil); err != nil {
+			t.Fatalf("unexpected error running admin photos backfill: %v", err)
+		}
+		return out.String(), progress.String()
+	}
+
+	report, progress := run()
+	for _, want := range []string{"inserted_photos: 3", "checkpoint: last_post_id=130031900 processed_posts=2", "status: done"} {
+		if !strings.Contains(report, want) {
+			t.Fatalf("expected %q in report:\n%s", want, report)
+		}
+	}
+	if !strings.Contains(progress, "batch 1: posts 130031899..130031900 selected=2") {
+		t.Fatalf("expected a progress line on stderr, got %q", progress)
+	}
+
+	// The in-memory repository is fresh, but the checkpoint says the job is done.
+	report, progress = run()
+	if !strings.Contains(report, "batches: 0") || !strings.Contains(report, "inserted_photos: 0") || progress != "" {
+		t.Fatalf("expected the second run to resume past every post:\n%s%s", report, progress)
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var adminPhotosGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Find and delete photo objects no post references",
+	Long: `List every object under the photo prefix (S3_PHOTO_PREFIX) page by page, join
+each page against public.photo s3_key and ticker_s3_key, and delete the
+unreferenced objects older than the grace period. Use --dry-run to only
+report them. Works with both the s3 and local photo_storage backends.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return fmt.Errorf("reading dry-run flag: %w", err)
+		}
+		gracePeriod, err := cmd.Flags().GetDuration("grace-period")
+		if err != nil {
+			return fmt.Errorf("reading grace-period flag: %w", err)
+		}
+		if gracePeriod <= 0 {
+			return fmt.Errorf("--grace-period must be positive")
+		}
+		pageSize, err := cmd.Flags().GetInt("page-size")
+		if err != nil {
+			return fmt.Errorf("reading page-size flag: %w", err)
+		}
+		if pageSize < 1 || pageSize > 1000 {
+			return fmt.Errorf("--page-size must be between 1 and 1000")
+		}
+		// The in-memory repository knows nothing about a real bucket's rows.
+		if cfg.DatabaseURL == "" && !dryRun && photoStorage(cfg) != photoStorageLocal {
+			return fmt.Errorf("refusing to delete from s3 without DATABASE_URL; set it or pass --dry-run")
+		}
+
+		var (
+			repo      service.PhotoReferenceRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		store, err := newPhotoObjectStore(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+
+		report, err := service.NewPhotoGCService(repo, store).Run(cmd.Context(), domain.PhotoGCOptions{
+			DryRun:      dryRun,
+			GracePeriod: gracePeriod,
+			PageSize:    pageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("photo gc: %w", err)
+		}
+		if err := renderPhotoGCOutput(cmd, cfg.Format, report); err != nil {
+			return err
+		}
+		if len(report.DeleteErrors) > 0 {
+			return fmt.Errorf("photo gc: %d of %d orphaned objects could not be deleted", len(report.DeleteErrors), len(report.Orphans))
+		}
+		return nil
+	},
+}
+
+func init() {
+	adminPhotosCmd.AddCommand(adminPhotosGCCmd)
+	adminPhotosGCCmd.Flags().Bool("dry-run", false, "report orphaned objects without deleting them")
+	adminPhotosGCCmd.Flags().Duration("grace-period", 24*time.Hour, "skip objects modified more recently than this (uploads precede their photo rows)")
+	adminPhotosGCCmd.Flags().Int("page-size", 1000, "objects listed per storage page (1-1000)")
+}
+
+func renderPhotoGCOutput(cmd *cobra.Command, format string, report domain.PhotoGCReport) error {
+	if !cmd.Flags().Changed("format") && (format == "" || format == "json") {
+		return adapters.RenderPhotoGCReport(cmd.OutOrStdout(), report)
+	}
+	if format == "text" || format == "table" {
+		return adapters.RenderPhotoGCReport(cmd.OutOrStdout(), report)
+	}
+	return adapters.Render(format, report)
+}
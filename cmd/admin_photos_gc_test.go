@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestAdminPhotosGC_LocalStorageDeletesOldOrphansOnly(t *testing.T) {
+	photoDir := t.TempDir()
+	old := time.Now().Add(-48 * time.Hour)
+	files := map[string]time.Time{
+		"v2/posts/1/orphan.jpg":    old,
+		"v2/posts/2/uploading.jpg": time.Now(),
+	}
+	for key, modified := range files {
+		target := filepath.Join(photoDir, filepath.FromSlash(key))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(target, []byte("jpeg"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if err := os.Chtimes(target, modified, modified); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	viper.Set("photo_storage", "local")
+	viper.Set("photo_local_dir", photoDir)
+	t.Cleanup(func() {
+		viper.Set("photo_storage", "")
+		viper.Set("photo_local_dir", "")
+		_ = adminPhotosGCCmd.Flags().Set("dry-run", "false")
+		adminPhotosGCCmd.Flags().Lookup("dry-run").Changed = false
+	})
+
+	run := func() string {
+		t.Helper()
+		var out bytes.Buffer
+		adminPhotosGCCmd.SetOut(&out)
+		adminPhotosGCCmd.SetContext(t.Context())
+		if err := adminPhotosGCCmd.RunE(adminPhotosGCCmd, nil); err != nil {
+			t.Fatalf("unexpected error running admin photos gc: %v", err)
+		}
+		return out.String()
+	}
+
+	if err := adminPhotosGCCmd.Flags().Set("dry-run", "true"); err != nil {
+		t.Fatalf("setting dry-run: %v", err)
+	}
+	report := run()
+	if !strings.Contains(report, "[dry-run] photo gc") || !strings.Contains(report, "too_recent: 1") || !strings.Contains(report, "  v2/posts/1/orphan.jpg  4 bytes") {
+		t.Fatalf("unexpected dry-run report:\n%s", report)
+	}
+	if _, err := os.Stat(filepath.Join(photoDir, "v2", "posts", "1", "orphan.jpg")); err != nil {
+		t.Fatalf("expected dry run to keep the orphan: %v", err)
+	}
+
+	if err := adminPhotosGCCmd.Flags().Set("dry-run", "false"); err != nil {
+		t.Fatalf("clearing dry-run: %v", err)
+	}
+	report = run()
+	if !strings.Contains(report, "deleted: 1") {
+		t.Fatalf("expected one deletion:\n%s", report)
+	}
+	if _, err := os.Stat(filepath.Join(photoDir, "v2", "posts", "1", "orphan.jpg")); !os.IsNotExist(err) {
+		t.Fatalf("expected the old orphan removed, stat err %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(photoDir, "v2", "posts", "2", "uploading.jpg")); err != nil {
+		t.Fatalf("expected the recent upload kept: %v", err)
+	}
+}
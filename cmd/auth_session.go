@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// supabaseAPIKey prefers the publishable key over the legacy anon key.
+func supabaseAPIKey(cfg *config.Config) string {
+	if key := strings.TrimSpace(cfg.SupabasePublishableKey); key != "" {
+		return key
+	}
+	return strings.TrimSpace(cfg.SupabaseAnonKey)
+}
+
+// newAuthSessionService wires Supabase Auth and the session file for
+// login, logout, whoami, and commands that act as the logged-in user.
+func newAuthSessionService(cfg *config.Config) (*service.AuthSessionService, *adapters.FileSessionStore, error) {
+	provider, err := adapters.NewSupabaseAuthSessionClient(cfg.SupabaseURL, supabaseAPIKey(cfg))
+	if err != nil {
+		return nil, nil, fmt.Errorf("configuring supabase auth: %w", err)
+	}
+	checker, err := emailDomainChecker(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := adapters.NewFileSessionStore(cfg.AuthSessionFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return service.NewAuthSessionService(provider, store).WithEmailDomainPolicy(checker), store, nil
+}
+
+// useTextAuthOutput follows the other commands: text unless --format asks
+// for something else.
+func useTextAuthOutput(cmd *cobra.Command, format string) bool {
+	if !cmd.Flags().Changed("format") && (format == "" || format == "json") {
+		return true
+	}
+	return format == "text" || format == "table"
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/Capmus-Team/supost-cli/internal/adapters"
 	"github.com/Capmus-Team/supost-cli/internal/config"
-	"github.com/Capmus-Team/supost-cli/internal/repository"
 	"github.com/Capmus-Team/supost-cli/internal/service"
 	"github.com/spf13/cobra"
 )
@@ -20,19 +19,9 @@ var categoriesCmd = &cobra.Command{
 			return fmt.Errorf("loading config: %w", err)
 		}
 
-		var (
-			repo      service.CategoryRepository
-			closeRepo func() error
-		)
-		if cfg.DatabaseURL != "" {
-			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
-			if err != nil {
-				return fmt.Errorf("connecting to postgres: %w", err)
-			}
-			repo = pgRepo
-			closeRepo = pgRepo.Close
-		} else {
-			repo = repository.NewInMemory()
+		repo, closeRepo, err := openDataStore(cmd.Context(), cfg)
+		if err != nil {
+			return err
 		}
 		if closeRepo != nil {
 			defer func() {
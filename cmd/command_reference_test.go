@@ -10,7 +10,7 @@ import (
 )
 
 func TestCommandReference_TopLevelCommandsExist(t *testing.T) {
-	for _, name := range []string{"home", "search", "post", "categories", "signup", "serve", "version"} {
+	for _, name := range []string{"home", "search", "post", "categories", "signup", "policy", "login", "logout", "whoami", "me", "mail", "messages", "admin", "serve", "version"} {
 		if mustCommandByName(t, rootCmd, name) == nil {
 			t.Fatalf("expected top-level command %q", name)
 		}
@@ -112,6 +112,23 @@ func TestCommandReference_PostRespondFlags(t *testing.T) {
 	}
 }
 
+func TestCommandReference_PostPhotosArgsAndFlags(t *testing.T) {
+	post := mustCommandByName(t, rootCmd, "post")
+	photos := mustCommandByName(t, post, "photos")
+
+	for _, flagName := range []string{"photo", "keep-metadata"} {
+		if photos.Flags().Lookup(flagName) == nil {
+			t.Fatalf("expected post photos flag %q", flagName)
+		}
+	}
+	if err := photos.Args(photos, []string{}); err == nil {
+		t.Fatalf("expected post photos to require <access_token>")
+	}
+	if err := photos.Args(photos, []string{"token", "reorder", "2,0,1,3"}); err != nil {
+		t.Fatalf("expected post photos to accept <access_token> <action> <arg>: %v", err)
+	}
+}
+
 func TestCommandReference_SignupFlags(t *testing.T) {
 	signup := mustCommandByName(t, rootCmd, "signup")
 	for _, flagName := range []string{"display-name", "email", "phone", "password"} {
@@ -142,6 +159,43 @@ func TestCommandReference_PostAndRespondArgs(t *testing.T) {
 	}
 }
 
+func TestCommandReference_AdminPhotosGCFlags(t *testing.T) {
+	gc := mustCommandByName(t, mustCommandByName(t, mustCommandByName(t, rootCmd, "admin"), "photos"), "gc")
+	for name, def := range map[string]string{"dry-run": "false", "grace-period": "24h0m0s", "page-size": "1000"} {
+		flag := gc.Flags().Lookup(name)
+		if flag == nil || flag.DefValue != def {
+			t.Fatalf("expected admin photos gc --%s with default %s", name, def)
+		}
+	}
+}
+
+func TestCommandReference_AdminPhotosBackfillFlags(t *testing.T) {
+	backfill := mustCommandByName(t, mustCommandByName(t, mustCommandByName(t, rootCmd, "admin"), "photos"), "backfill")
+	for name, def := range map[string]string{
+		"batch-size":     "1000",
+		"max-batches":    "0",
+		"job":            "photo_backfill_v1",
+		"checkpoint-dir": "",
+		"reset":          "false",
+		"verify":         "true",
+		"dry-run":        "false",
+	} {
+		flag := backfill.Flags().Lookup(name)
+		if flag == nil || flag.DefValue != def {
+			t.Fatalf("expected admin photos backfill --%s with default %q", name, def)
+		}
+	}
+}
+
+func TestCommandReference_LoginFlags(t *testing.T) {
+	login := mustCommandByName(t, rootCmd, "login")
+	for _, name := range []string{"email", "password", "magic-link", "otp", "phone", "no-callback", "callback-addr", "timeout"} {
+		if login.Flags().Lookup(name) == nil {
+			t.Fatalf("expected login --%s flag", name)
+		}
+	}
+}
+
 func TestCommandReference_ServePortDefault(t *testing.T) {
 	serve := mustCommandByName(t, rootCmd, "serve")
 	port := serve.Flags().Lookup("port")
@@ -167,9 +221,29 @@ func TestProjectStructure_ReadmeListedPathsExist(t *testing.T) {
 		"cmd/search.go",
 		"cmd/post.go",
 		"cmd/post_create.go",
+		"cmd/post_photos.go",
 		"cmd/post_respond.go",
 		"cmd/signup.go",
+		"cmd/auth_session.go",
+		"cmd/postgres.go",
+		"cmd/data_backend.go",
+		"cmd/login.go",
+		"cmd/logout.go",
+		"cmd/whoami.go",
+		"cmd/me.go",
+		"cmd/me_posts.go",
+		"cmd/me_messages.go",
+		"cmd/me_profile.go",
+		"cmd/policy.go",
+		"cmd/mail_sender.go",
+		"cmd/photo_storage.go",
+		"cmd/mail.go",
+		"cmd/mail_simulate.go",
+		"cmd/messages.go",
 		"cmd/categories.go",
+		"cmd/admin.go",
+		"cmd/admin_photos_gc.go",
+		"cmd/admin_photos_backfill.go",
 		"cmd/command_reference_test.go",
 		"cmd/serve.go",
 		"internal/config/config.go",
@@ -183,34 +257,108 @@ func TestProjectStructure_ReadmeListedPathsExist(t *testing.T) {
 		"internal/domain/post_respond.go",
 		"internal/domain/search_result.go",
 		"internal/domain/user_signup.go",
+		"internal/domain/auth_session.go",
+		"internal/domain/my_posts.go",
+		"internal/domain/my_messages.go",
+		"internal/domain/email_policy.go",
+		"internal/domain/password_policy.go",
 		"internal/domain/user.go",
 		"internal/domain/errors.go",
+		"internal/domain/captured_email.go",
+		"internal/domain/email_template.go",
+		"internal/domain/mail_relay.go",
+		"internal/domain/mail_event.go",
+		"internal/domain/photo_validation.go",
+		"internal/domain/post_photos.go",
+		"internal/domain/photo_gc.go",
+		"internal/domain/photo_backfill.go",
 		"internal/service/categories.go",
+		"internal/service/email_templates.go",
+		"internal/service/email_preview.go",
+		"internal/service/mail_relay.go",
+		"internal/service/message_status.go",
 		"internal/service/home.go",
 		"internal/service/post.go",
 		"internal/service/post_create.go",
 		"internal/service/post_create_submit.go",
+		"internal/service/photo_format.go",
+		"internal/service/photo_validation.go",
+		"internal/service/post_create_photos.go",
+		"internal/service/post_photos.go",
+		"internal/service/photo_gc.go",
+		"internal/service/photo_backfill.go",
 		"internal/service/post_respond.go",
 		"internal/service/search.go",
 		"internal/service/user_signup.go",
+		"internal/service/auth_session.go",
+		"internal/service/my_posts.go",
+		"internal/service/my_messages.go",
+		"internal/service/profile.go",
+		"internal/service/email_policy.go",
+		"internal/service/password_policy.go",
+		"internal/service/password_strength.go",
+		"internal/service/passwords/common.txt",
 		"internal/repository/interfaces.go",
 		"internal/repository/inmemory.go",
 		"internal/repository/inmemory_post_create.go",
+		"internal/repository/inmemory_post_photos.go",
+		"internal/repository/inmemory_photo_gc.go",
+		"internal/repository/inmemory_photo_backfill.go",
 		"internal/repository/inmemory_post_respond.go",
 		"internal/repository/inmemory_search.go",
+		"internal/repository/inmemory_my_posts.go",
+		"internal/repository/inmemory_profile.go",
 		"internal/repository/postgres.go",
+		"internal/repository/postgres_rls.go",
 		"internal/repository/postgres_post_create.go",
+		"internal/repository/postgres_post_photos.go",
+		"internal/repository/postgres_photo_gc.go",
+		"internal/repository/postgres_photo_backfill.go",
 		"internal/repository/postgres_post_respond.go",
 		"internal/repository/postgres_search.go",
+		"internal/repository/postgres_my_posts.go",
+		"internal/repository/postgres_profile.go",
+		"internal/repository/supabase_rest.go",
+		"internal/repository/supabase_rest_post_create.go",
+		"internal/repository/supabase_rest_post_respond.go",
+		"internal/repository/supabase_rest_search.go",
+		"internal/repository/conformance_test.go",
+		"internal/repository/postgres_migrations_test.go",
 		"internal/adapters/output.go",
 		"internal/adapters/mailgun.go",
+		"internal/adapters/mailgun_webhook.go",
+		"internal/adapters/mailgun_events.go",
+		"internal/adapters/smtp.go",
+		"internal/adapters/s3_photo_uploader.go",
+		"internal/adapters/image_orientation.go",
+		"internal/adapters/image_metadata.go",
+		"internal/adapters/local_photo_storage.go",
+		"internal/adapters/photo_object_store.go",
+		"internal/adapters/photo_backfill_checkpoint.go",
+		"internal/adapters/email_message.go",
+		"internal/adapters/file_mail.go",
+		"internal/adapters/mail_output.go",
 		"internal/adapters/home_output.go",
 		"internal/adapters/search_output.go",
 		"internal/adapters/post_output.go",
 		"internal/adapters/post_create_output.go",
 		"internal/adapters/post_create_submit_output.go",
+		"internal/adapters/post_photos_output.go",
+		"internal/adapters/photo_gc_output.go",
+		"internal/adapters/photo_backfill_output.go",
 		"internal/adapters/post_respond_output.go",
+		"internal/adapters/message_output.go",
 		"internal/adapters/supabase_auth_signup.go",
+		"internal/adapters/supabase_auth_session.go",
+		"internal/adapters/session_file.go",
+		"internal/adapters/session_file_unix.go",
+		"internal/adapters/session_file_other.go",
+		"internal/adapters/auth_output.go",
+		"internal/adapters/auth_callback.go",
+		"internal/adapters/my_posts_output.go",
+		"internal/adapters/my_messages_output.go",
+		"internal/adapters/profile_output.go",
+		"internal/adapters/email_policy_output.go",
 		"internal/adapters/page_header.go",
 		"internal/adapters/page_footer.go",
 		"internal/adapters/home_cache.go",
@@ -222,7 +370,7 @@ func TestProjectStructure_ReadmeListedPathsExist(t *testing.T) {
 		assertFileExists(t, filepath.Join(root, rel))
 	}
 
-	expectedDirs := []string{"supabase/migrations", "testdata/seed", "docs"}
+	expectedDirs := []string{"supabase/migrations", "testdata/seed", "docs", "internal/service/templates/email"}
 	for _, rel := range expectedDirs {
 		assertDirExists(t, filepath.Join(root, rel))
 	}
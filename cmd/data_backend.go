@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+)
+
+const (
+	dataBackendMemory       = "memory"
+	dataBackendPostgres     = "postgres"
+	dataBackendSupabaseREST = "supabase_rest"
+)
+
+// dataStore is what every data_backend serves: the home, post, category,
+// search, post create, and post respond repositories.
+type dataStore interface {
+	service.HomeRepository
+	service.PostRepository
+	service.CategoryRepository
+	service.SearchRepository
+	service.PostCreateRepository
+	service.PostRespondRepository
+}
+
+// dataBackend resolves data_backend. Blank keeps the old behavior:
+// postgres when DATABASE_URL is set, memory otherwise.
+func dataBackend(cfg *config.Config) (string, error) {
+	switch cfg.DataBackend {
+	case "":
+		if cfg.DatabaseURL != "" {
+			return dataBackendPostgres, nil
+		}
+		return dataBackendMemory, nil
+	case dataBackendMemory, dataBackendSupabaseREST:
+		return cfg.DataBackend, nil
+	case dataBackendPostgres:
+		if cfg.DatabaseURL == "" {
+			return "", fmt.Errorf("data_backend postgres needs database_url")
+		}
+		return dataBackendPostgres, nil
+	default:
+		return "", fmt.Errorf("unknown data_backend %q (expected memory, postgres, or supabase_rest)", cfg.DataBackend)
+	}
+}
+
+// openDataStore opens the configured backend. The close func is nil for
+// the in-memory store.
+func openDataStore(ctx context.Context, cfg *config.Config) (dataStore, func() error, error) {
+	backend, err := dataBackend(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch backend {
+	case dataBackendPostgres:
+		pgRepo, err := openPostgres(ctx, cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("connecting to postgres: %w", err)
+		}
+		return pgRepo, pgRepo.Close, nil
+	case dataBackendSupabaseREST:
+		restRepo, err := openSupabaseREST(ctx, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return restRepo, restRepo.Close, nil
+	default:
+		return repository.NewInMemory(), nil, nil
+	}
+}
+
+// openSupabaseREST uses the logged-in user's access token when there is
+// one, so RLS sees the authenticated role; otherwise the publishable key
+// (anon).
+func openSupabaseREST(ctx context.Context, cfg *config.Config) (*repository.SupabaseREST, error) {
+	restRepo, err := repository.NewSupabaseREST(cfg.SupabaseURL, supabaseAPIKey(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("configuring supabase rest: %w", err)
+	}
+	sessions, _, err := newAuthSessionService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	session, _, err := sessions.Session(ctx)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		return restRepo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return restRepo.WithAccessToken(session.AccessToken), nil
+}
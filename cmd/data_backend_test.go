@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/config"
+)
+
+func TestDataBackend_ResolvesConfiguredBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		backend     string
+		databaseURL string
+		want        string
+		wantErr     bool
+	}{
+		{name: "blank without database", want: dataBackendMemory},
+		{name: "blank with database", databaseURL: "postgres://db", want: dataBackendPostgres},
+		{name: "memory ignores database", backend: "memory", databaseURL: "postgres://db", want: dataBackendMemory},
+		{name: "supabase rest", backend: "supabase_rest", databaseURL: "postgres://db", want: dataBackendSupabaseREST},
+		{name: "postgres without database", backend: "postgres", wantErr: true},
+		{name: "unknown", backend: "mysql", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dataBackend(&config.Config{DataBackend: tt.backend, DatabaseURL: tt.databaseURL})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("expected %q, got %q (%v)", tt.want, got, err)
+			}
+		})
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/Capmus-Team/supost-cli/internal/adapters"
 	"github.com/Capmus-Team/supost-cli/internal/config"
 	"github.com/Capmus-Team/supost-cli/internal/domain"
-	"github.com/Capmus-Team/supost-cli/internal/repository"
 	"github.com/Capmus-Team/supost-cli/internal/service"
 	"github.com/spf13/cobra"
 )
@@ -46,29 +45,24 @@ var homeCmd = &cobra.Command{
 			sectionsOK   bool
 			sectionsErr  error
 		)
-		if cfg.DatabaseURL != "" {
+		backend, err := dataBackend(cfg)
+		if err != nil {
+			return err
+		}
+		liveBackend := backend != dataBackendMemory
+		if liveBackend {
 			var ok bool
-			posts, ok, cacheLoadErr = getCachedHomePosts(cfg.DatabaseURL, refresh, cacheTTL, limit)
+			posts, ok, cacheLoadErr = getCachedHomePosts(liveBackend, refresh, cacheTTL, limit)
 			usedCache = cacheLoadErr == nil && ok
-			sections, sectionsOK, sectionsErr = getCachedHomeCategorySections(cfg.DatabaseURL, refresh, cacheTTL)
+			sections, sectionsOK, sectionsErr = getCachedHomeCategorySections(liveBackend, refresh, cacheTTL)
 		}
 
-		var (
-			repo      service.HomeRepository
-			closeRepo func() error
-		)
-		if cfg.DatabaseURL != "" {
-			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
-			if err != nil {
-				if usedCache {
-					return renderHomeOutput(cmd, cfg.Format, posts, nil, sections)
-				}
-				return fmt.Errorf("connecting to postgres: %w", err)
+		repo, closeRepo, err := openDataStore(cmd.Context(), cfg)
+		if err != nil {
+			if usedCache {
+				return renderHomeOutput(cmd, cfg.Format, photoBaseURL(cfg), posts, nil, sections)
 			}
-			repo = pgRepo
-			closeRepo = pgRepo.Close
-		} else {
-			repo = repository.NewInMemory()
+			return err
 		}
 		if closeRepo != nil {
 			defer func() {
@@ -84,7 +78,7 @@ var homeCmd = &cobra.Command{
 				return fmt.Errorf("fetching recent active posts: %w", err)
 			}
 
-			if cfg.DatabaseURL != "" && cacheTTL > 0 {
+			if liveBackend && cacheTTL > 0 {
 				_ = adapters.SaveHomePostsCache(posts)
 			}
 		} else if cacheLoadErr != nil && cfg.Verbose {
@@ -98,7 +92,7 @@ var homeCmd = &cobra.Command{
 					fmt.Fprintf(cmd.ErrOrStderr(), "warning: loading category sections: %v\n", err)
 				}
 				sections = nil
-			} else if cfg.DatabaseURL != "" && cacheTTL > 0 && len(sections) > 0 {
+			} else if liveBackend && cacheTTL > 0 && len(sections) > 0 {
 				_ = adapters.SaveHomeCategorySectionsCache(sections)
 			}
 		} else if sectionsErr != nil && cfg.Verbose {
@@ -116,7 +110,7 @@ var homeCmd = &cobra.Command{
 			}
 		}
 
-		return renderHomeOutput(cmd, cfg.Format, posts, featuredJobs, sections)
+		return renderHomeOutput(cmd, cfg.Format, photoBaseURL(cfg), posts, featuredJobs, sections)
 	},
 }
 
@@ -127,8 +121,8 @@ func init() {
 	homeCmd.Flags().Bool("refresh", false, "bypass cache and fetch fresh data from database")
 }
 
-func getCachedHomePosts(databaseURL string, refresh bool, ttl time.Duration, limit int) ([]domain.Post, bool, error) {
-	if databaseURL == "" || refresh || ttl <= 0 {
+func getCachedHomePosts(liveBackend bool, refresh bool, ttl time.Duration, limit int) ([]domain.Post, bool, error) {
+	if !liveBackend || refresh || ttl <= 0 {
 		return nil, false, nil
 	}
 	posts, ok, err := adapters.LoadHomePostsCache(ttl, limit)
@@ -138,8 +132,8 @@ func getCachedHomePosts(databaseURL string, refresh bool, ttl time.Duration, lim
 	return posts, ok, nil
 }
 
-func getCachedHomeCategorySections(databaseURL string, refresh bool, ttl time.Duration) ([]domain.HomeCategorySection, bool, error) {
-	if databaseURL == "" || refresh || ttl <= 0 {
+func getCachedHomeCategorySections(liveBackend bool, refresh bool, ttl time.Duration) ([]domain.HomeCategorySection, bool, error) {
+	if !liveBackend || refresh || ttl <= 0 {
 		return nil, false, nil
 	}
 	sections, ok, err := adapters.LoadHomeCategorySectionsCache(ttl)
@@ -149,12 +143,12 @@ func getCachedHomeCategorySections(databaseURL string, refresh bool, ttl time.Du
 	return sections, ok, nil
 }
 
-func renderHomeOutput(cmd *cobra.Command, format string, posts []domain.Post, featuredJobs []domain.Post, sections []domain.HomeCategorySection) error {
+func renderHomeOutput(cmd *cobra.Command, format string, photoBaseURL string, posts []domain.Post, featuredJobs []domain.Post, sections []domain.HomeCategorySection) error {
 	if !cmd.Flags().Changed("format") && (format == "" || format == "json") {
-		return adapters.RenderHomePosts(cmd.OutOrStdout(), posts, featuredJobs, sections)
+		return adapters.RenderHomePosts(cmd.OutOrStdout(), posts, featuredJobs, sections, photoBaseURL)
 	}
 	if format == "text" || format == "table" {
-		return adapters.RenderHomePosts(cmd.OutOrStdout(), posts, featuredJobs, sections)
+		return adapters.RenderHomePosts(cmd.OutOrStdout(), posts, featuredJobs, sections, photoBaseURL)
 	}
 	return adapters.Render(format, posts)
 }
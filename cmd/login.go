@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in with Supabase Auth and store the session",
+	Long: `Log in with Supabase Auth and store the session.
+
+  --email [--password]      password grant; the password is read from stdin when omitted
+  --email --magic-link      email a login link and wait for it on a local callback listener
+                            (--no-callback: paste the link or code from the email instead)
+  --email|--phone --otp     email or text a one-time code and prompt for it
+
+The access and refresh tokens are stored in auth_session_file (default
+<user config dir>/supost-cli/session.json) with 0600 permissions, and are
+refreshed automatically when they expire.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		email, err := cmd.Flags().GetString("email")
+		if err != nil {
+			return fmt.Errorf("reading email flag: %w", err)
+		}
+		phone, err := cmd.Flags().GetString("phone")
+		if err != nil {
+			return fmt.Errorf("reading phone flag: %w", err)
+		}
+		magicLink, err := cmd.Flags().GetBool("magic-link")
+		if err != nil {
+			return fmt.Errorf("reading magic-link flag: %w", err)
+		}
+		otp, err := cmd.Flags().GetBool("otp")
+		if err != nil {
+			return fmt.Errorf("reading otp flag: %w", err)
+		}
+		if magicLink && otp {
+			return fmt.Errorf("use only one of --magic-link and --otp")
+		}
+		if phone != "" && !otp {
+			return fmt.Errorf("--phone requires --otp")
+		}
+		if phone != "" && email != "" {
+			return fmt.Errorf("use only one of --email and --phone")
+		}
+		if email == "" && phone == "" {
+			return fmt.Errorf("--email is required (or --phone with --otp)")
+		}
+
+		svc, store, err := newAuthSessionService(cfg)
+		if err != nil {
+			return err
+		}
+
+		var session domain.AuthSession
+		switch {
+		case magicLink:
+			session, err = loginWithMagicLink(cmd, svc, email)
+		case otp:
+			session, err = loginWithOTP(cmd, svc, email, phone)
+		default:
+			session, err = loginWithPassword(cmd, svc, email)
+		}
+		if err != nil {
+			return fmt.Errorf("logging in: %w", err)
+		}
+
+		result := domain.AuthLoginResult{
+			UserID:      session.UserID,
+			Email:       session.Email,
+			Phone:       session.Phone,
+			ExpiresAt:   session.ExpiresAt,
+			SessionFile: store.Path(),
+		}
+		created, err := ensureLoginProfile(cmd, cfg, session)
+		if err != nil {
+			// The session is already saved; a missing profile is created
+			// again by `supost me profile`.
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %v\n", err)
+		}
+		result.ProfileCreated = created
+		if useTextAuthOutput(cmd, cfg.Format) {
+			return adapters.RenderAuthLogin(cmd.OutOrStdout(), result)
+		}
+		return adapters.Render(cfg.Format, result)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	loginCmd.Flags().String("email", "", "account email")
+	loginCmd.Flags().String("password", "", "account password (read from stdin when omitted)")
+	loginCmd.Flags().Bool("magic-link", false, "email a login link instead of using a password")
+	loginCmd.Flags().Bool("otp", false, "email or text a one-time code instead of using a password")
+	loginCmd.Flags().String("phone", "", "phone number for an SMS code (with --otp)")
+	loginCmd.Flags().Bool("no-callback", false, "with --magic-link, paste the link or code instead of running a local callback listener")
+	loginCmd.Flags().String("callback-addr", "127.0.0.1:0", "local address for the magic-link callback listener")
+	loginCmd.Flags().Duration("timeout", 10*time.Minute, "how long to wait for the magic-link callback")
+	loginCmd.MarkFlagsMutuallyExclusive("password", "magic-link", "otp")
+}
+
+func loginWithPassword(cmd *cobra.Command, svc *service.AuthSessionService, email string) (domain.AuthSession, error) {
+	password, err := cmd.Flags().GetString("password")
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("reading password flag: %w", err)
+	}
+	if password == "" {
+		password, err = promptLine(cmd, "Password: ")
+		if err != nil {
+			return domain.AuthSession{}, fmt.Errorf("reading password: %w", err)
+		}
+	}
+	return svc.Login(cmd.Context(), email, password)
+}
+
+// loginWithMagicLink emails a link that redirects to a local listener, or
+// with --no-callback prompts for the link or code from the email.
+func loginWithMagicLink(cmd *cobra.Command, svc *service.AuthSessionService, email string) (domain.AuthSession, error) {
+	noCallback, err := cmd.Flags().GetBool("no-callback")
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("reading no-callback flag: %w", err)
+	}
+	if noCallback {
+		email, err = svc.SendEmailOTP(cmd.Context(), email, "")
+		if err != nil {
+			return domain.AuthSession{}, err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Sent a login link to %s.\n", email)
+		input, err := promptLine(cmd, "Paste the link or code from the email: ")
+		if err != nil {
+			return domain.AuthSession{}, fmt.Errorf("reading magic link: %w", err)
+		}
+		return svc.CompleteMagicLink(cmd.Context(), email, input)
+	}
+
+	callbackAddr, err := cmd.Flags().GetString("callback-addr")
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("reading callback-addr flag: %w", err)
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("reading timeout flag: %w", err)
+	}
+	listener, err := adapters.NewAuthCallbackListener(callbackAddr)
+	if err != nil {
+		return domain.AuthSession{}, err
+	}
+	defer listener.Close()
+
+	email, err = svc.SendEmailOTP(cmd.Context(), email, listener.URL())
+	if err != nil {
+		return domain.AuthSession{}, err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Sent a login link to %s. Open it on this machine; waiting on %s\n", email, listener.URL())
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+	callbackURL, err := listener.Wait(ctx)
+	if err != nil {
+		return domain.AuthSession{}, err
+	}
+	return svc.CompleteMagicLink(cmd.Context(), email, callbackURL)
+}
+
+// loginWithOTP sends a one-time code by email or SMS and prompts for it.
+func loginWithOTP(cmd *cobra.Command, svc *service.AuthSessionService, email string, phone string) (domain.AuthSession, error) {
+	var verification domain.AuthOTPVerification
+	if phone != "" {
+		normalized, err := svc.SendPhoneOTP(cmd.Context(), phone)
+		if err != nil {
+			return domain.AuthSession{}, err
+		}
+		verification = domain.AuthOTPVerification{Type: domain.AuthOTPTypeSMS, Phone: normalized}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Texted a login code to %s.\n", normalized)
+	} else {
+		normalized, err := svc.SendEmailOTP(cmd.Context(), email, "")
+		if err != nil {
+			return domain.AuthSession{}, err
+		}
+		verification = domain.AuthOTPVerification{Type: domain.AuthOTPTypeEmail, Email: normalized}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Emailed a login code to %s.\n", normalized)
+	}
+
+	code, err := promptLine(cmd, "Code: ")
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("reading code: %w", err)
+	}
+	verification.Token = code
+	return svc.VerifyOTP(cmd.Context(), verification)
+}
+
+// promptLine writes prompt to stderr and reads one line from stdin.
+func promptLine(cmd *cobra.Command, prompt string) (string, error) {
+	fmt.Fprint(cmd.ErrOrStderr(), prompt)
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoginWhoAmILogout_StoresAndClearsSession(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1","email":"user@stanford.edu","role":"authenticated","exp":4102444800}`))
+	accessToken := "eyJhbGciOiJIUzI1NiJ9." + claims + ".signature"
+	var loggedOut string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/v1/token":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"` + accessToken + `","token_type":"bearer","expires_at":4102444800,"refresh_token":"refresh-1","user":{"id":"user-1","email":"user@stanford.edu"}}`))
+		case "/auth/v1/logout":
+			loggedOut = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	sessionFile := filepath.Join(t.TempDir(), "session.json")
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	viper.Set("supabase_url", server.URL)
+	viper.Set("supabase_publishable_key", "sb_publishable_test")
+	viper.Set("auth_session_file", sessionFile)
+	t.Cleanup(func() {
+		viper.Set("supabase_url", "")
+		viper.Set("supabase_publishable_key", "")
+		viper.Set("auth_session_file", "")
+		_ = loginCmd.Flags().Set("email", "")
+		loginCmd.Flags().Lookup("email").Changed = false
+		loginCmd.SetIn(nil)
+	})
+
+	var out bytes.Buffer
+	if err := loginCmd.Flags().Set("email", "user@stanford.edu"); err != nil {
+		t.Fatalf("setting email: %v", err)
+	}
+	loginCmd.SetIn(strings.NewReader("supost123!\n"))
+	loginCmd.SetOut(&out)
+	loginCmd.SetErr(&bytes.Buffer{})
+	loginCmd.SetContext(t.Context())
+	if err := loginCmd.RunE(loginCmd, nil); err != nil {
+		t.Fatalf("unexpected login error: %v", err)
+	}
+	if !strings.Contains(out.String(), "logged in as user@stanford.edu") || !strings.Contains(out.String(), "session_file: "+sessionFile) || !strings.Contains(out.String(), "profile: created") {
+		t.Fatalf("unexpected login output:\n%s", out.String())
+	}
+	info, err := os.Stat(sessionFile)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected a 0600 session file, got %v, %v", info, err)
+	}
+
+	out.Reset()
+	whoamiCmd.SetOut(&out)
+	whoamiCmd.SetContext(t.Context())
+	if err := whoamiCmd.RunE(whoamiCmd, nil); err != nil {
+		t.Fatalf("unexpected whoami error: %v", err)
+	}
+	if !strings.Contains(out.String(), "user_id: user-1") || !strings.Contains(out.String(), "role: authenticated") {
+		t.Fatalf("unexpected whoami output:\n%s", out.String())
+	}
+
+	out.Reset()
+	logoutCmd.SetOut(&out)
+	logoutCmd.SetContext(t.Context())
+	if err := logoutCmd.RunE(logoutCmd, nil); err != nil {
+		t.Fatalf("unexpected logout error: %v", err)
+	}
+	if loggedOut != "Bearer "+accessToken || !strings.Contains(out.String(), "revoked: true") {
+		t.Fatalf("expected the session revoked, got auth %q output:\n%s", loggedOut, out.String())
+	}
+	if _, err := os.Stat(sessionFile); !os.IsNotExist(err) {
+		t.Fatalf("expected the session file removed, stat err %v", err)
+	}
+}
+
+func TestLogin_OTPAndPastedMagicLink(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1","email":"user@stanford.edu","exp":4102444800}`))
+	accessToken := "eyJhbGciOiJIUzI1NiJ9." + claims + ".signature"
+	var otpBodies, verifyBodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/auth/v1/otp":
+			otpBodies = append(otpBodies, string(body))
+			_, _ = w.Write([]byte(`{}`))
+		case "/auth/v1/verify":
+			verifyBodies = append(verifyBodies, string(body))
+			_, _ = w.Write([]byte(`{"access_token":"` + accessToken + `","expires_at":4102444800,"refresh_token":"refresh-1","user":{"id":"user-1","email":"user@stanford.edu"}}`))
+		case "/auth/v1/user":
+			if r.Header.Get("Authorization") != "Bearer "+accessToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"user-1","email":"user@stanford.edu"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	sessionFile := filepath.Join(t.TempDir(), "session.json")
+	viper.Set("format", "json")
+	viper.Set("supabase_url", server.URL)
+	viper.Set("supabase_publishable_key", "sb_publishable_test")
+	viper.Set("auth_session_file", sessionFile)
+	t.Cleanup(func() {
+		viper.Set("supabase_url", "")
+		viper.Set("supabase_publishable_key", "")
+		viper.Set("auth_session_file", "")
+		for name, def := range map[string]string{"email": "", "phone": "", "otp": "false", "magic-link": "false", "no-callback": "false"} {
+			_ = loginCmd.Flags().Set(name, def)
+			loginCmd.Flags().Lookup(name).Changed = false
+		}
+		loginCmd.SetIn(nil)
+	})
+
+	run := func(flags map[string]string, stdin string) string {
+		t.Helper()
+		for name, value := range flags {
+			if err := loginCmd.Flags().Set(name, value); err != nil {
+				t.Fatalf("setting --%s: %v", name, err)
+			}
+		}
+		var out, errOut bytes.Buffer
+		loginCmd.SetIn(strings.NewReader(stdin))
+		loginCmd.SetOut(&out)
+		loginCmd.SetErr(&errOut)
+		loginCmd.SetContext(t.Context())
+		if err := loginCmd.RunE(loginCmd, nil); err != nil {
+			t.Fatalf("unexpected login error: %v\n%s", err, errOut.String())
+		}
+		return errOut.String() + out.String()
+	}
+
+	output := run(map[string]string{"phone": "+16505551234", "otp": "true"}, "123456\n")
+	if !strings.Contains(output, "Texted a login code to +16505551234") || !strings.Contains(output, "logged in as user@stanford.edu") {
+		t.Fatalf("unexpected sms otp output:\n%s", output)
+	}
+	if !strings.Contains(otpBodies[0], `"channel":"sms"`) || !strings.Contains(verifyBodies[0], `"type":"sms"`) || !strings.Contains(verifyBodies[0], `"token":"123456"`) {
+		t.Fatalf("unexpected sms otp requests %v %v", otpBodies, verifyBodies)
+	}
+
+	_ = loginCmd.Flags().Set("phone", "")
+	_ = loginCmd.Flags().Set("otp", "false")
+	if err := os.Remove(sessionFile); err != nil {
+		t.Fatalf("removing session: %v", err)
+	}
+	redirect := "http://localhost:3000/#access_token=" + accessToken + "&refresh_token=refresh-2&expires_in=3600&token_type=bearer&type=magiclink"
+	output = run(map[string]string{"email": "user@stanford.edu", "magic-link": "true", "no-callback": "true"}, redirect+"\n")
+	if !strings.Contains(output, "Sent a login link to user@stanford.edu") || !strings.Contains(output, "logged in as user@stanford.edu") {
+		t.Fatalf("unexpected magic link output:\n%s", output)
+	}
+	if len(otpBodies) != 2 || !strings.Contains(otpBodies[1], `"email":"user@stanford.edu"`) || len(verifyBodies) != 1 {
+		t.Fatalf("expected the pasted redirect stored without a verify call, got %v %v", otpBodies, verifyBodies)
+	}
+	saved, err := os.ReadFile(sessionFile)
+	if err != nil || !strings.Contains(string(saved), "refresh-2") {
+		t.Fatalf("expected the pasted session saved, got %s, %v", saved, err)
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Revoke and delete the stored Supabase session",
+	Long:  "Revoke the stored session's refresh tokens with Supabase Auth and delete the session file. The file is deleted even if Supabase cannot be reached.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		svc, _, err := newAuthSessionService(cfg)
+		if err != nil {
+			return err
+		}
+		result, err := svc.Logout(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("logging out: %w", err)
+		}
+
+		if useTextAuthOutput(cmd, cfg.Format) {
+			return adapters.RenderAuthLogout(cmd.OutOrStdout(), result)
+		}
+		return adapters.Render(cfg.Format, result)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logoutCmd)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var mailCmd = &cobra.Command{
+	Use:   "mail",
+	Short: "Inspect captured emails and preview email templates",
+	Long:  "Browse .eml files written when mail_provider is set to file (see mail_file_dir), or preview templated publish/response emails.",
+}
+
+var mailListCmd = &cobra.Command{
+	Use:     "ls",
+	Short:   "List captured emails",
+	Aliases: []string{"list"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		sink, err := adapters.NewFileMailSender(cfg.MailFileDir, "")
+		if err != nil {
+			return fmt.Errorf("opening mail sink: %w", err)
+		}
+		emails, err := sink.List()
+		if err != nil {
+			return fmt.Errorf("listing captured emails: %w", err)
+		}
+
+		if useTextMailOutput(cmd, cfg.Format) {
+			return adapters.RenderCapturedEmailList(cmd.OutOrStdout(), sink.Dir(), emails)
+		}
+		return adapters.Render(cfg.Format, emails)
+	},
+}
+
+var mailShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show one captured email",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		sink, err := adapters.NewFileMailSender(cfg.MailFileDir, "")
+		if err != nil {
+			return fmt.Errorf("opening mail sink: %w", err)
+		}
+		email, err := sink.Get(args[0])
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("captured email %q not found in %s", args[0], sink.Dir())
+			}
+			return fmt.Errorf("reading captured email: %w", err)
+		}
+
+		if useTextMailOutput(cmd, cfg.Format) {
+			return adapters.RenderCapturedEmail(cmd.OutOrStdout(), email)
+		}
+		return adapters.Render(cfg.Format, email)
+	},
+}
+
+var mailPreviewCmd = &cobra.Command{
+	Use:       "preview publish|response",
+	Short:     "Render a publish or response email for an existing post",
+	Long:      "Render the templated subject, text, and HTML bodies for a post without sending or persisting anything.",
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{service.EmailPreviewPublish, service.EmailPreviewResponse},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		postID, err := cmd.Flags().GetInt64("post")
+		if err != nil {
+			return fmt.Errorf("reading post flag: %w", err)
+		}
+		replyTo, err := cmd.Flags().GetString("reply-to")
+		if err != nil {
+			return fmt.Errorf("reading reply-to flag: %w", err)
+		}
+		message, err := cmd.Flags().GetString("message")
+		if err != nil {
+			return fmt.Errorf("reading message flag: %w", err)
+		}
+		part, err := cmd.Flags().GetString("part")
+		if err != nil {
+			return fmt.Errorf("reading part flag: %w", err)
+		}
+		if !adapters.ValidEmailPreviewPart(part) {
+			return fmt.Errorf("invalid --part %q (expected text, html, or both)", part)
+		}
+
+		var (
+			repo      service.EmailPreviewRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		templates, err := loadEmailTemplates(cfg)
+		if err != nil {
+			return err
+		}
+		svc := service.NewEmailPreviewService(repo).WithEmailTemplates(templates)
+
+		var preview domain.EmailPreview
+		switch args[0] {
+		case service.EmailPreviewPublish:
+			preview, err = svc.PreviewPublish(cmd.Context(), postID, cfg.SupostBaseURL)
+		case service.EmailPreviewResponse:
+			preview, err = svc.PreviewResponse(cmd.Context(), postID, replyTo, message, cfg.SupostBaseURL)
+		}
+		if err != nil {
+			return fmt.Errorf("previewing %s email for post %d: %w", args[0], postID, err)
+		}
+
+		if useTextMailOutput(cmd, cfg.Format) {
+			return adapters.RenderEmailPreview(cmd.OutOrStdout(), preview, part)
+		}
+		return adapters.Render(cfg.Format, preview)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mailCmd)
+	mailCmd.AddCommand(mailListCmd)
+	mailCmd.AddCommand(mailShowCmd)
+	mailCmd.AddCommand(mailPreviewCmd)
+	mailPreviewCmd.Flags().Int64("post", 0, "post id to render the email for")
+	mailPreviewCmd.Flags().String("reply-to", "preview@example.com", "reply-to address for response previews")
+	mailPreviewCmd.Flags().String("message", "Hi! Is this still available?", "message body for response previews")
+	mailPreviewCmd.Flags().String("part", "both", "body to print: text, html, or both")
+	_ = mailPreviewCmd.MarkFlagRequired("post")
+}
+
+func useTextMailOutput(cmd *cobra.Command, format string) bool {
+	if !cmd.Flags().Changed("format") && (format == "" || format == "json") {
+		return true
+	}
+	return format == "text" || format == "table"
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+)
+
+const (
+	mailProviderMailgun = "mailgun"
+	mailProviderSMTP    = "smtp"
+	mailProviderFile    = "file"
+)
+
+// emailSender covers every outgoing-email side effect used by commands.
+type emailSender interface {
+	service.PostCreateEmailSender
+	service.PostRespondEmailSender
+}
+
+// newEmailSender builds the transport selected by mail_provider.
+func newEmailSender(cfg *config.Config) (emailSender, error) {
+	switch mailProvider(cfg) {
+	case mailProviderMailgun:
+		return adapters.NewMailgunSender(
+			cfg.MailgunAPIBase,
+			cfg.MailgunDomain,
+			cfg.MailgunAPIKey,
+			cfg.MailgunFromEmail,
+			cfg.MailgunSendTimeout,
+		)
+	case mailProviderSMTP:
+		return adapters.NewSMTPSender(
+			cfg.SMTPHost,
+			cfg.SMTPPort,
+			cfg.SMTPUsername,
+			cfg.SMTPPassword,
+			cfg.SMTPSecurity,
+			cfg.SMTPAuth,
+			mailFromEmail(cfg),
+			cfg.SMTPTimeout,
+		)
+	case mailProviderFile:
+		return adapters.NewFileMailSender(cfg.MailFileDir, mailFromEmail(cfg))
+	default:
+		return nil, fmt.Errorf("unsupported mail_provider %q (expected mailgun, smtp, or file)", cfg.MailProvider)
+	}
+}
+
+// loadEmailTemplates returns the embedded email templates with any overrides
+// from email_template_dir (an OS path list; later directories win).
+func loadEmailTemplates(cfg *config.Config) (*service.EmailTemplates, error) {
+	templates, err := service.LoadEmailTemplates(filepath.SplitList(cfg.EmailTemplateDir)...)
+	if err != nil {
+		return nil, fmt.Errorf("loading email templates: %w", err)
+	}
+	return templates, nil
+}
+
+// mailFromEmail returns the From address for the selected provider.
+func mailFromEmail(cfg *config.Config) string {
+	if mailProvider(cfg) == mailProviderSMTP {
+		if from := strings.TrimSpace(cfg.SMTPFromEmail); from != "" {
+			return from
+		}
+	}
+	return cfg.MailgunFromEmail
+}
+
+func mailProvider(cfg *config.Config) string {
+	provider := strings.ToLower(strings.TrimSpace(cfg.MailProvider))
+	if provider == "" {
+		return mailProviderMailgun
+	}
+	return provider
+}
+
+// newMailRelay builds the reply-relay address codec. Domain and secret fall
+// back to the Mailgun sending domain and API key.
+func newMailRelay(cfg *config.Config) (*service.MailRelay, error) {
+	relayDomain := strings.TrimSpace(cfg.MailRelayDomain)
+	if relayDomain == "" {
+		relayDomain = cfg.MailgunDomain
+	}
+	secret := strings.TrimSpace(cfg.MailRelaySecret)
+	if secret == "" {
+		secret = cfg.MailgunAPIKey
+	}
+	relay, err := service.NewMailRelay(relayDomain, secret)
+	if err != nil {
+		return nil, fmt.Errorf("configuring mail relay: %w", err)
+	}
+	return relay, nil
+}
+
+// mailgunWebhookSigningKey returns the key used to verify webhook signatures.
+func mailgunWebhookSigningKey(cfg *config.Config) string {
+	if key := strings.TrimSpace(cfg.MailgunWebhookSigningKey); key != "" {
+		return key
+	}
+	return strings.TrimSpace(cfg.MailgunAPIKey)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+)
+
+func TestNewEmailSender_DefaultsToMailgun(t *testing.T) {
+	sender, err := newEmailSender(&config.Config{MailgunDomain: "mg.supost.com", MailgunAPIKey: "key"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := sender.(*adapters.MailgunSender); !ok {
+		t.Fatalf("expected mailgun sender, got %T", sender)
+	}
+}
+
+func TestNewEmailSender_SMTP(t *testing.T) {
+	cfg := &config.Config{
+		MailProvider:     "SMTP",
+		SMTPHost:         "localhost",
+		SMTPPort:         1025,
+		SMTPFromEmail:    "dev@supost.local",
+		MailgunFromEmail: "response@mg.supost.com",
+	}
+	sender, err := newEmailSender(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := sender.(*adapters.SMTPSender); !ok {
+		t.Fatalf("expected smtp sender, got %T", sender)
+	}
+	if got := mailFromEmail(cfg); got != "dev@supost.local" {
+		t.Fatalf("expected smtp from email, got %q", got)
+	}
+}
+
+func TestNewEmailSender_File(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := newEmailSender(&config.Config{MailProvider: "file", MailFileDir: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fileSender, ok := sender.(*adapters.FileMailSender)
+	if !ok {
+		t.Fatalf("expected file mail sender, got %T", sender)
+	}
+	if fileSender.Dir() != dir {
+		t.Fatalf("expected sink dir %q, got %q", dir, fileSender.Dir())
+	}
+}
+
+func TestNewEmailSender_RejectsUnknownProvider(t *testing.T) {
+	_, err := newEmailSender(&config.Config{MailProvider: "carrier-pigeon"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported mail_provider") {
+		t.Fatalf("expected unsupported provider error, got %v", err)
+	}
+}
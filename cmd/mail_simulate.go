@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var mailSimulateInboundCmd = &cobra.Command{
+	Use:   "simulate-inbound",
+	Short: "POST a signed fake Mailgun inbound payload to a running serve instance",
+	Long: `Build a Mailgun inbound-route payload signed with the configured webhook
+signing key and POST it to supost serve. Target a relay address directly with
+--to, or derive one with --message-id and --to-party poster|responder. A
+derived address is the one the relay would have issued to --from, so it is
+accepted only when --from is the other party on that message.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		endpoint, err := cmd.Flags().GetString("url")
+		if err != nil {
+			return fmt.Errorf("reading url flag: %w", err)
+		}
+		recipient, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return fmt.Errorf("reading to flag: %w", err)
+		}
+		messageID, err := cmd.Flags().GetInt64("message-id")
+		if err != nil {
+			return fmt.Errorf("reading message-id flag: %w", err)
+		}
+		toParty, err := cmd.Flags().GetString("to-party")
+		if err != nil {
+			return fmt.Errorf("reading to-party flag: %w", err)
+		}
+		from, err := cmd.Flags().GetString("from")
+		if err != nil {
+			return fmt.Errorf("reading from flag: %w", err)
+		}
+		subject, err := cmd.Flags().GetString("subject")
+		if err != nil {
+			return fmt.Errorf("reading subject flag: %w", err)
+		}
+		text, err := cmd.Flags().GetString("text")
+		if err != nil {
+			return fmt.Errorf("reading text flag: %w", err)
+		}
+
+		if strings.TrimSpace(recipient) == "" {
+			if messageID <= 0 {
+				return fmt.Errorf("either --to or --message-id is required")
+			}
+			direction, err := parseRelayParty(toParty)
+			if err != nil {
+				return err
+			}
+			relay, err := newMailRelay(cfg)
+			if err != nil {
+				return err
+			}
+			participant := strings.TrimSpace(from)
+			if parsed, err := mail.ParseAddress(participant); err == nil {
+				participant = parsed.Address
+			}
+			recipient = relay.Address(messageID, direction, participant)
+		}
+
+		signingKey := mailgunWebhookSigningKey(cfg)
+		if signingKey == "" {
+			return fmt.Errorf("mailgun_webhook_signing_key (or mailgun_api_key) is required to sign payloads")
+		}
+		req, err := adapters.NewMailgunInboundRequest(cmd.Context(), endpoint, signingKey, domain.InboundEmail{
+			Recipient: recipient,
+			Sender:    strings.TrimSpace(from),
+			From:      strings.TrimSpace(from),
+			Subject:   strings.TrimSpace(subject),
+			Text:      strings.TrimSpace(text),
+		}, time.Now())
+		if err != nil {
+			return err
+		}
+
+		resp, err := (&http.Client{Timeout: 10 * time.Second}).Do(req)
+		if err != nil {
+			return fmt.Errorf("posting inbound payload: %w", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "recipient: %s\n", recipient)
+		fmt.Fprintf(out, "status: %d\n", resp.StatusCode)
+		fmt.Fprintln(out, strings.TrimSpace(string(body)))
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("inbound webhook returned status %d", resp.StatusCode)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mailCmd.AddCommand(mailSimulateInboundCmd)
+	mailSimulateInboundCmd.Flags().String("url", "http://localhost:8080/webhooks/mailgun/inbound", "inbound webhook endpoint")
+	mailSimulateInboundCmd.Flags().String("to", "", "relay address to deliver to")
+	mailSimulateInboundCmd.Flags().Int64("message-id", 0, "message id used to derive the relay address when --to is empty")
+	mailSimulateInboundCmd.Flags().String("to-party", "poster", "party the derived relay address delivers to: poster or responder")
+	mailSimulateInboundCmd.Flags().String("from", "", "sender email; a derived --to is issued to this address")
+	mailSimulateInboundCmd.Flags().String("subject", "Re: your SUpost response", "inbound subject")
+	mailSimulateInboundCmd.Flags().String("text", "", "inbound plain-text body")
+	_ = mailSimulateInboundCmd.MarkFlagRequired("from")
+	_ = mailSimulateInboundCmd.MarkFlagRequired("text")
+}
+
+func parseRelayParty(raw string) (domain.RelayDirection, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "poster", "owner":
+		return domain.RelayToPoster, nil
+	case "responder":
+		return domain.RelayToResponder, nil
+	default:
+		return "", fmt.Errorf("invalid --to-party %q (expected poster or responder)", raw)
+	}
+}
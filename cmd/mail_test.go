@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMailCommands_FileProviderCapturesPostRespondEmail(t *testing.T) {
+	dir := t.TempDir()
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	viper.Set("mail_provider", "file")
+	viper.Set("mail_file_dir", dir)
+	t.Cleanup(func() {
+		viper.Set("mail_provider", "")
+		viper.Set("mail_file_dir", "")
+		_ = postRespondCmd.Flags().Set("message", "")
+		_ = postRespondCmd.Flags().Set("reply-to", "")
+	})
+
+	if err := postRespondCmd.Flags().Set("message", "Is the bike still available?"); err != nil {
+		t.Fatalf("setting message flag: %v", err)
+	}
+	if err := postRespondCmd.Flags().Set("reply-to", "buyer@gmail.com"); err != nil {
+		t.Fatalf("setting reply-to flag: %v", err)
+	}
+	var respondOut bytes.Buffer
+	postRespondCmd.SetOut(&respondOut)
+	if err := postRespondCmd.RunE(postRespondCmd, []string{"130031783"}); err != nil {
+		t.Fatalf("unexpected error running post respond: %v", err)
+	}
+	if !strings.Contains(respondOut.String(), "email_sent: true") {
+		t.Fatalf("expected email_sent: true; output was %q", respondOut.String())
+	}
+
+	var listOut bytes.Buffer
+	mailListCmd.SetOut(&listOut)
+	if err := mailListCmd.RunE(mailListCmd, nil); err != nil {
+		t.Fatalf("unexpected error running mail ls: %v", err)
+	}
+	fields := strings.Fields(listOut.String())
+	if len(fields) == 0 || !strings.Contains(listOut.String(), "wientjes@alumni.stanford.edu") {
+		t.Fatalf("expected captured email in listing; output was %q", listOut.String())
+	}
+
+	var showOut bytes.Buffer
+	mailShowCmd.SetOut(&showOut)
+	if err := mailShowCmd.RunE(mailShowCmd, []string{fields[0]}); err != nil {
+		t.Fatalf("unexpected error running mail show: %v", err)
+	}
+	for _, needle := range []string{"reply_to: buyer@gmail.com", "Is the bike still available?"} {
+		if !strings.Contains(showOut.String(), needle) {
+			t.Fatalf("expected mail show output to contain %q; output was %q", needle, showOut.String())
+		}
+	}
+}
+
+func TestMailPreviewCommand_RendersPublishTextAndHTML(t *testing.T) {
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	t.Cleanup(func() {
+		_ = mailPreviewCmd.Flags().Set("post", "0")
+	})
+
+	if err := mailPreviewCmd.Flags().Set("post", "130031783"); err != nil {
+		t.Fatalf("setting post flag: %v", err)
+	}
+	if err := mailPreviewCmd.Args(mailPreviewCmd, []string{"digest"}); err == nil {
+		t.Fatalf("expected preview to reject unknown email kind")
+	}
+
+	var out bytes.Buffer
+	mailPreviewCmd.SetOut(&out)
+	if err := mailPreviewCmd.RunE(mailPreviewCmd, []string{"publish"}); err != nil {
+		t.Fatalf("unexpected error running mail preview: %v", err)
+	}
+	for _, needle := range []string{
+		"[PREVIEW] publish email",
+		"subject: SUpost - Publish your post! Looking for a buddy to go to the movies",
+		"--- text/plain ---",
+		"--- text/html ---",
+		`<a href="https://supost.com/post/publish/`,
+	} {
+		if !strings.Contains(out.String(), needle) {
+			t.Fatalf("expected preview output to contain %q; output was %q", needle, out.String())
+		}
+	}
+}
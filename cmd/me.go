@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var meCmd = &cobra.Command{
+	Use:   "me",
+	Short: "Commands for the logged-in user",
+	Long:  "Act as the user logged in with `supost login`. Ownership comes from the session's email claim, so no per-post access token is needed.",
+}
+
+func init() {
+	rootCmd.AddCommand(meCmd)
+}
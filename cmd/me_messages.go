@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var meMessagesCmd = &cobra.Command{
+	Use:   "messages",
+	Short: "Read responses to your posts",
+	Long: `List the responses sent to posts whose email matches your login, grouped into
+one thread per post with the newest activity first. Examples:
+
+  supost me messages
+  supost me messages --post 130031896`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		postID, err := cmd.Flags().GetInt64("post")
+		if err != nil {
+			return fmt.Errorf("reading post flag: %w", err)
+		}
+		if cmd.Flags().Changed("post") && postID <= 0 {
+			return fmt.Errorf("invalid post id %d", postID)
+		}
+
+		sessions, _, err := newAuthSessionService(cfg)
+		if err != nil {
+			return err
+		}
+
+		var (
+			repo      service.MyPostsRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := openPostgres(cmd.Context(), cfg)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		svc := service.NewMyPostsService(repo, sessions)
+		result, err := svc.Messages(cmd.Context(), postID)
+		if err != nil {
+			return fmt.Errorf("me messages: %w", err)
+		}
+		if useTextAuthOutput(cmd, cfg.Format) {
+			return adapters.RenderMyMessages(cmd.OutOrStdout(), result)
+		}
+		return adapters.Render(cfg.Format, result)
+	},
+}
+
+func init() {
+	meCmd.AddCommand(meMessagesCmd)
+	meMessagesCmd.Flags().Int64("post", 0, "only show responses to this post")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMeMessages_ShowsInboxAndRefusesOtherAccountsPost(t *testing.T) {
+	loginForTest(t, "pat@stanford.edu")
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	t.Cleanup(func() {
+		_ = meMessagesCmd.Flags().Set("post", "0")
+		meMessagesCmd.Flags().Lookup("post").Changed = false
+	})
+
+	var out bytes.Buffer
+	meMessagesCmd.SetOut(&out)
+	meMessagesCmd.SetContext(t.Context())
+	if err := meMessagesCmd.RunE(meMessagesCmd, nil); err != nil {
+		t.Fatalf("unexpected me messages error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Inbox: pat@stanford.edu  0 responses on 0 posts") || !strings.Contains(out.String(), "No responses yet.") {
+		t.Fatalf("unexpected me messages output:\n%s", out.String())
+	}
+
+	if err := meMessagesCmd.Flags().Set("post", "130031901"); err != nil {
+		t.Fatalf("setting post flag: %v", err)
+	}
+	if err := meMessagesCmd.RunE(meMessagesCmd, nil); err == nil || !strings.Contains(err.Error(), "belongs to another account") {
+		t.Fatalf("expected another account's post refused, got %v", err)
+	}
+}
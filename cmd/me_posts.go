@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var mePostsCmd = &cobra.Command{
+	Use:   "posts [list|publish <post_id>|edit <post_id>|delete <post_id>]",
+	Short: "List and manage your posts",
+	Long: `List the pending, active, and expired posts whose email matches your login, and
+publish, edit, or delete them without the post's access token. Examples:
+
+  supost me posts
+  supost me posts publish 130031896
+  supost me posts edit 130031896 --name "Desk + chair" --price 40
+  supost me posts delete 130031896`,
+	Args: cobra.RangeArgs(0, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		action := "list"
+		if len(args) > 0 {
+			action = strings.ToLower(strings.TrimSpace(args[0]))
+		}
+		var postID int64
+		switch action {
+		case "list":
+			if len(args) > 1 {
+				return fmt.Errorf("me posts list takes no argument")
+			}
+		case "publish", "edit", "delete":
+			if len(args) < 2 {
+				return fmt.Errorf("me posts %s requires a post id", action)
+			}
+			postID, err = strconv.ParseInt(strings.TrimSpace(args[1]), 10, 64)
+			if err != nil || postID <= 0 {
+				return fmt.Errorf("invalid post id %q", args[1])
+			}
+		default:
+			return fmt.Errorf("unknown me posts action %q (expected list, publish, edit, or delete)", action)
+		}
+
+		edit, err := postEditFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if action != "edit" && (edit.Name != "" || edit.Body != "" || edit.PriceProvided) {
+			return fmt.Errorf("--name, --body, and --price are only used with edit")
+		}
+
+		sessions, _, err := newAuthSessionService(cfg)
+		if err != nil {
+			return err
+		}
+
+		var (
+			repo      service.MyPostsRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := openPostgres(cmd.Context(), cfg)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		svc := service.NewMyPostsService(repo, sessions)
+		if action == "list" {
+			result, err := svc.List(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("me posts: %w", err)
+			}
+			if useTextAuthOutput(cmd, cfg.Format) {
+				return adapters.RenderMyPosts(cmd.OutOrStdout(), result)
+			}
+			return adapters.Render(cfg.Format, result)
+		}
+
+		var result domain.MyPostActionResult
+		switch action {
+		case "publish":
+			result, err = svc.Publish(cmd.Context(), postID)
+		case "edit":
+			result, err = svc.Edit(cmd.Context(), postID, edit)
+		case "delete":
+			result, err = svc.Delete(cmd.Context(), postID)
+		}
+		if err != nil {
+			return fmt.Errorf("me posts %s: %w", action, err)
+		}
+		if useTextAuthOutput(cmd, cfg.Format) {
+			return adapters.RenderMyPostAction(cmd.OutOrStdout(), result)
+		}
+		return adapters.Render(cfg.Format, result)
+	},
+}
+
+func init() {
+	meCmd.AddCommand(mePostsCmd)
+	mePostsCmd.Flags().String("name", "", "new post title (edit)")
+	mePostsCmd.Flags().String("body", "", "new post body (edit)")
+	mePostsCmd.Flags().Float64("price", 0, "new price (edit; housing and for-sale only)")
+}
+
+func postEditFromFlags(cmd *cobra.Command) (domain.PostEdit, error) {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return domain.PostEdit{}, fmt.Errorf("reading name flag: %w", err)
+	}
+	body, err := cmd.Flags().GetString("body")
+	if err != nil {
+		return domain.PostEdit{}, fmt.Errorf("reading body flag: %w", err)
+	}
+	price, err := cmd.Flags().GetFloat64("price")
+	if err != nil {
+		return domain.PostEdit{}, fmt.Errorf("reading price flag: %w", err)
+	}
+	return domain.PostEdit{
+		Name:          name,
+		Body:          body,
+		Price:         price,
+		PriceProvided: cmd.Flags().Changed("price"),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/spf13/viper"
+)
+
+// loginForTest stores a session for email so commands run as that user,
+// with a stand-in Supabase that vouches for the token on /auth/v1/user.
+func loginForTest(t *testing.T, email string) {
+	t.Helper()
+	expiresAt := time.Now().Add(time.Hour)
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1","email":"` + email + `","exp":` + strconv.FormatInt(expiresAt.Unix(), 10) + `}`))
+	accessToken := "eyJhbGciOiJIUzI1NiJ9." + claims + ".signature"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/v1/user" || r.Header.Get("Authorization") != "Bearer "+accessToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"msg":"invalid JWT"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"user-1","email":"` + email + `","email_confirmed_at":"2026-10-19T12:00:00Z"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	sessionFile := filepath.Join(t.TempDir(), "session.json")
+	store, err := adapters.NewFileSessionStore(sessionFile)
+	if err != nil {
+		t.Fatalf("opening test session store: %v", err)
+	}
+	err = store.SaveSession(domain.AuthSession{
+		AccessToken:  accessToken,
+		RefreshToken: "refresh-1",
+		ExpiresAt:    expiresAt,
+		Email:        email,
+	})
+	if err != nil {
+		t.Fatalf("saving test session: %v", err)
+	}
+	viper.Set("supabase_url", server.URL)
+	viper.Set("supabase_publishable_key", "sb_publishable_test")
+	viper.Set("auth_session_file", sessionFile)
+	t.Cleanup(func() {
+		viper.Set("supabase_url", "")
+		viper.Set("supabase_publishable_key", "")
+		viper.Set("auth_session_file", "")
+	})
+}
+
+func TestMePosts_ListsAndPublishesOwnPendingPost(t *testing.T) {
+	loginForTest(t, "pat@stanford.edu")
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		var out bytes.Buffer
+		mePostsCmd.SetOut(&out)
+		mePostsCmd.SetContext(t.Context())
+		err := mePostsCmd.RunE(mePostsCmd, args)
+		return out.String(), err
+	}
+
+	output, err := run()
+	if err != nil {
+		t.Fatalf("unexpected me posts error: %v", err)
+	}
+	if !strings.Contains(output, "my posts: pat@stanford.edu") || !strings.Contains(output, "  130031896  pending") {
+		t.Fatalf("unexpected me posts output:\n%s", output)
+	}
+
+	output, err = run("publish", "130031896")
+	if err != nil {
+		t.Fatalf("unexpected publish error: %v", err)
+	}
+	if !strings.Contains(output, "[publish] post 130031896") || !strings.Contains(output, "status: active") {
+		t.Fatalf("unexpected publish output:\n%s", output)
+	}
+
+	if _, err := run("delete", "130031901"); err == nil || !strings.Contains(err.Error(), "belongs to another account") {
+		t.Fatalf("expected another account's post refused, got %v", err)
+	}
+}
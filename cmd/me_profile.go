@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var meProfileCmd = &cobra.Command{
+	Use:   "profile [show|update]",
+	Short: "Show or update your profile",
+	Long: `Show or update the profile row for your login. The profile is created on first
+login from the display name and phone given at signup. Examples:
+
+  supost me profile
+  supost me profile update --display-name "Pat Lee"
+  supost me profile update --phone +16505551234
+  supost me profile update --phone ""   # clear the phone`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		action := "show"
+		if len(args) > 0 {
+			action = strings.ToLower(strings.TrimSpace(args[0]))
+		}
+		if action != "show" && action != "update" {
+			return fmt.Errorf("unknown me profile action %q (expected show or update)", action)
+		}
+
+		update, err := profileUpdateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if action == "show" && (update.DisplayNameProvided || update.PhoneProvided) {
+			return fmt.Errorf("--display-name and --phone are only used with update")
+		}
+
+		sessions, _, err := newAuthSessionService(cfg)
+		if err != nil {
+			return err
+		}
+		repo, closeRepo, err := newProfileRepository(cmd.Context(), cfg)
+		if err != nil {
+			return err
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		svc := service.NewProfileService(repo, sessions)
+		var profile domain.User
+		if action == "update" {
+			profile, err = svc.Update(cmd.Context(), update)
+		} else {
+			profile, err = svc.Show(cmd.Context())
+		}
+		if err != nil {
+			return fmt.Errorf("me profile %s: %w", action, err)
+		}
+		if useTextAuthOutput(cmd, cfg.Format) {
+			return adapters.RenderProfile(cmd.OutOrStdout(), profile)
+		}
+		return adapters.Render(cfg.Format, profile)
+	},
+}
+
+func init() {
+	meCmd.AddCommand(meProfileCmd)
+	meProfileCmd.Flags().String("display-name", "", "new display name (update)")
+	meProfileCmd.Flags().String("phone", "", "new phone number, or empty to clear it (update)")
+}
+
+func profileUpdateFromFlags(cmd *cobra.Command) (domain.ProfileUpdate, error) {
+	displayName, err := cmd.Flags().GetString("display-name")
+	if err != nil {
+		return domain.ProfileUpdate{}, fmt.Errorf("reading display-name flag: %w", err)
+	}
+	phone, err := cmd.Flags().GetString("phone")
+	if err != nil {
+		return domain.ProfileUpdate{}, fmt.Errorf("reading phone flag: %w", err)
+	}
+	return domain.ProfileUpdate{
+		DisplayName:         displayName,
+		DisplayNameProvided: cmd.Flags().Changed("display-name"),
+		Phone:               phone,
+		PhoneProvided:       cmd.Flags().Changed("phone"),
+	}, nil
+}
+
+// newProfileRepository opens the profile store for me profile and login.
+// The close func is nil for the in-memory store.
+func newProfileRepository(ctx context.Context, cfg *config.Config) (service.ProfileRepository, func() error, error) {
+	if cfg.DatabaseURL == "" {
+		return repository.NewInMemory(), nil, nil
+	}
+	pgRepo, err := openPostgres(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+	return pgRepo, pgRepo.Close, nil
+}
+
+// ensureLoginProfile creates the profile row on a user's first login.
+func ensureLoginProfile(cmd *cobra.Command, cfg *config.Config, session domain.AuthSession) (bool, error) {
+	repo, closeRepo, err := newProfileRepository(cmd.Context(), cfg)
+	if err != nil {
+		return false, fmt.Errorf("creating profile: %w", err)
+	}
+	if closeRepo != nil {
+		defer func() {
+			_ = closeRepo()
+		}()
+	}
+	_, created, err := service.NewProfileService(repo, nil).EnsureProfile(cmd.Context(), session)
+	return created, err
+}
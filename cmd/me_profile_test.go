@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestMeProfile_ShowsAndUpdatesProfile(t *testing.T) {
+	loginForTest(t, "pat@stanford.edu")
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	t.Cleanup(func() {
+		for _, name := range []string{"display-name", "phone"} {
+			_ = meProfileCmd.Flags().Set(name, "")
+			meProfileCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		var out bytes.Buffer
+		meProfileCmd.SetOut(&out)
+		meProfileCmd.SetContext(t.Context())
+		err := meProfileCmd.RunE(meProfileCmd, args)
+		return out.String(), err
+	}
+
+	output, err := run()
+	if err != nil {
+		t.Fatalf("unexpected me profile error: %v", err)
+	}
+	if !strings.Contains(output, "user_id: user-1") || !strings.Contains(output, "email: pat@stanford.edu") {
+		t.Fatalf("unexpected me profile output:\n%s", output)
+	}
+
+	if err := meProfileCmd.Flags().Set("display-name", "Pat Lee"); err != nil {
+		t.Fatalf("setting display-name flag: %v", err)
+	}
+	if _, err := run("show"); err == nil || !strings.Contains(err.Error(), "only used with update") {
+		t.Fatalf("expected flags refused with show, got %v", err)
+	}
+	output, err = run("update")
+	if err != nil {
+		t.Fatalf("unexpected me profile update error: %v", err)
+	}
+	if !strings.Contains(output, "display_name: Pat Lee") {
+		t.Fatalf("unexpected me profile update output:\n%s", output)
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var messagesCmd = &cobra.Command{
+	Use:   "messages",
+	Short: "Inspect response messages",
+	Long:  "Inspect app_private.message rows written by post respond, including Mailgun delivery status.",
+}
+
+var messagesStatusCmd = &cobra.Command{
+	Use:   "status <message_id>",
+	Short: "Show the delivery status of a response message",
+	Long:  "Show queued/delivered/failed/complained/unsubscribed status as recorded from Mailgun event webhooks.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		messageID, err := parseMessageIDArg(args[0])
+		if err != nil {
+			return err
+		}
+
+		var (
+			repo      service.MessageStatusRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		svc := service.NewMessageStatusService(repo)
+		message, err := svc.GetStatus(cmd.Context(), messageID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("message %d not found", messageID)
+			}
+			return fmt.Errorf("fetching message %d: %w", messageID, err)
+		}
+
+		if useTextMailOutput(cmd, cfg.Format) {
+			return adapters.RenderMessageStatus(cmd.OutOrStdout(), message)
+		}
+		return adapters.Render(cfg.Format, message)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(messagesCmd)
+	messagesCmd.AddCommand(messagesStatusCmd)
+}
+
+func parseMessageIDArg(raw string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid message id %q", raw)
+	}
+	return id, nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+)
+
+const (
+	photoStorageS3    = "s3"
+	photoStorageLocal = "local"
+)
+
+// requireMetadataAdmin refuses --keep-metadata unless the logged-in user is
+// an admin according to Supabase. Kept metadata can include GPS coordinates,
+// so a plain session or a forged token is not enough.
+func requireMetadataAdmin(ctx context.Context, cfg *config.Config) error {
+	sessions, _, err := newAuthSessionService(cfg)
+	if err != nil {
+		return err
+	}
+	identity, err := sessions.VerifiedIdentity(ctx)
+	if err != nil {
+		return fmt.Errorf("--keep-metadata is admin only: %w", err)
+	}
+	if !identity.Admin {
+		return fmt.Errorf("%w: --keep-metadata is admin only (%s is not an admin)", domain.ErrUnauthorized, cmp.Or(identity.Email, identity.UserID))
+	}
+	return nil
+}
+
+// newPhotoUploader builds the photo backend selected by photo_storage.
+// keepMetadata disables EXIF/XMP/ICC stripping on uploaded variants.
+func newPhotoUploader(ctx context.Context, cfg *config.Config, keepMetadata bool) (service.PostCreatePhotoUploader, error) {
+	switch photoStorage(cfg) {
+	case photoStorageS3:
+		uploader, err := adapters.NewS3PostPhotoUploader(
+			ctx,
+			cfg.S3PhotoRegion,
+			cfg.S3PhotoBucket,
+			cfg.S3PhotoPrefix,
+			cfg.S3PhotoAWSProfile,
+			cfg.S3PhotoEndpoint,
+			cfg.S3PhotoUsePathStyle,
+			cfg.S3PhotoAccessKeyID,
+			cfg.S3PhotoSecretAccessKey,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("configuring s3 photo uploader: %w", err)
+		}
+		return uploader.WithKeepMetadata(keepMetadata), nil
+	case photoStorageLocal:
+		uploader, err := adapters.NewLocalPostPhotoUploader(cfg.PhotoLocalDir, cfg.S3PhotoPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("configuring local photo storage: %w", err)
+		}
+		return uploader.WithKeepMetadata(keepMetadata), nil
+	default:
+		return nil, fmt.Errorf("unsupported photo_storage %q (expected s3 or local)", cfg.PhotoStorage)
+	}
+}
+
+// photoBaseURL returns the public base for rendered image URLs: the explicit
+// photo_public_base_url, else the local serve address for local storage, else
+// the S3-compatible endpoint + bucket. Blank means the production host.
+func photoBaseURL(cfg *config.Config) string {
+	if base := strings.TrimRight(strings.TrimSpace(cfg.PhotoPublicBaseURL), "/"); base != "" {
+		return base
+	}
+	if photoStorage(cfg) == photoStorageLocal {
+		port := cfg.Port
+		if port == 0 {
+			port = 8080
+		}
+		return fmt.Sprintf("http://localhost:%d", port)
+	}
+
+	endpoint := strings.TrimRight(strings.TrimSpace(cfg.S3PhotoEndpoint), "/")
+	bucket := strings.TrimSpace(cfg.S3PhotoBucket)
+	if endpoint == "" || bucket == "" {
+		return ""
+	}
+	if cfg.S3PhotoUsePathStyle {
+		return endpoint + "/" + bucket
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	parsed.Host = bucket + "." + parsed.Host
+	return strings.TrimRight(parsed.String(), "/")
+}
+
+// photoLimits maps the photo_* validation settings onto the service limits.
+func photoLimits(cfg *config.Config) domain.PhotoLimits {
+	return domain.PhotoLimits{
+		MaxBytes:        cfg.PhotoMaxBytes,
+		MinWidth:        cfg.PhotoMinWidth,
+		MinHeight:       cfg.PhotoMinHeight,
+		MaxWidth:        cfg.PhotoMaxWidth,
+		MaxHeight:       cfg.PhotoMaxHeight,
+		MaxPixels:       cfg.PhotoMaxPixels,
+		AllowedFormats:  cfg.PhotoAllowedFormats,
+		DuplicateWindow: cfg.PhotoDuplicateWindow,
+	}
+}
+
+func photoStorage(cfg *config.Config) string {
+	storage := strings.ToLower(strings.TrimSpace(cfg.PhotoStorage))
+	if storage == "" {
+		return photoStorageS3
+	}
+	return storage
+}
+
+// localPhotoRoutes returns the storage root and the serve path prefixes that
+// photo URLs resolve to when photo_storage is local: the key prefix
+// (formatPostPhotoS3KeyURL) and the legacy posts/ ticker path
+// (formatTickerImageURL).
+func localPhotoRoutes(cfg *config.Config) (string, []string, error) {
+	uploader, err := adapters.NewLocalPostPhotoUploader(cfg.PhotoLocalDir, cfg.S3PhotoPrefix)
+	if err != nil {
+		return "", nil, fmt.Errorf("configuring local photo storage: %w", err)
+	}
+	routes := []string{"/" + uploader.Prefix() + "/"}
+	if routes[0] != "/posts/" {
+		routes = append(routes, "/posts/")
+	}
+	return uploader.Root(), routes, nil
+}
+
+// newPhotoObjectStore builds the photo backend for listing and deleting raw
+// objects under the photo prefix, as used by `admin photos gc`.
+func newPhotoObjectStore(ctx context.Context, cfg *config.Config) (service.PhotoObjectStore, error) {
+	uploader, err := newPhotoUploader(ctx, cfg, false)
+	if err != nil {
+		return nil, err
+	}
+	store, ok := uploader.(service.PhotoObjectStore)
+	if !ok {
+		return nil, fmt.Errorf("photo_storage %q cannot list objects", cfg.PhotoStorage)
+	}
+	return store, nil
+}
+
+// newPhotoObjectChecker builds the photo backend used to confirm objects
+// exist, as used by `admin photos backfill --verify`.
+func newPhotoObjectChecker(ctx context.Context, cfg *config.Config) (service.PhotoObjectChecker, error) {
+	uploader, err := newPhotoUploader(ctx, cfg, false)
+	if err != nil {
+		return nil, err
+	}
+	checker, ok := uploader.(service.PhotoObjectChecker)
+	if !ok {
+		return nil, fmt.Errorf("photo_storage %q cannot check objects", cfg.PhotoStorage)
+	}
+	return checker, nil
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/spf13/viper"
+)
+
+func TestNewPhotoUploader_SelectsBackend(t *testing.T) {
+	uploader, err := newPhotoUploader(t.Context(), &config.Config{PhotoStorage: "LOCAL", PhotoLocalDir: t.TempDir()}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := uploader.(*adapters.LocalPostPhotoUploader); !ok {
+		t.Fatalf("expected local uploader, got %T", uploader)
+	}
+
+	_, err = newPhotoUploader(t.Context(), &config.Config{PhotoStorage: "gcs"}, false)
+	if err == nil || !strings.Contains(err.Error(), "unsupported photo_storage") {
+		t.Fatalf("expected unsupported storage error, got %v", err)
+	}
+}
+
+func TestPhotoBaseURL(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  config.Config
+		want string
+	}{
+		{name: "default", cfg: config.Config{S3PhotoBucket: "supost-prod"}, want: ""},
+		{name: "explicit", cfg: config.Config{PhotoPublicBaseURL: "https://cdn.supost.com/", S3PhotoEndpoint: "http://localhost:9000"}, want: "https://cdn.supost.com"},
+		{name: "path style", cfg: config.Config{S3PhotoEndpoint: "http://localhost:9000/", S3PhotoBucket: "supost-dev", S3PhotoUsePathStyle: true}, want: "http://localhost:9000/supost-dev"},
+		{name: "virtual hosted", cfg: config.Config{S3PhotoEndpoint: "http://s3.local:4566", S3PhotoBucket: "supost-dev"}, want: "http://supost-dev.s3.local:4566"},
+		{name: "local storage", cfg: config.Config{PhotoStorage: "local", Port: 3000}, want: "http://localhost:3000"},
+	}
+	for _, tc := range cases {
+		if got := photoBaseURL(&tc.cfg); got != tc.want {
+			t.Fatalf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPostCreate_LocalPhotoStorageServesUploadedPhoto(t *testing.T) {
+	photoDir := t.TempDir()
+	photoPath := filepath.Join(t.TempDir(), "lamp.png")
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 400, 200))); err != nil {
+		t.Fatalf("encoding photo: %v", err)
+	}
+	if err := os.WriteFile(photoPath, buf.Bytes(), 0o600); err != nil {
+		t.Fatalf("writing photo: %v", err)
+	}
+
+	viper.Set("database_url", "")
+	viper.Set("format", "json")
+	viper.Set("mail_provider", "file")
+	viper.Set("mail_file_dir", t.TempDir())
+	viper.Set("photo_storage", "local")
+	viper.Set("photo_local_dir", photoDir)
+	t.Cleanup(func() {
+		viper.Set("mail_provider", "")
+		viper.Set("mail_file_dir", "")
+		viper.Set("photo_storage", "")
+		viper.Set("photo_local_dir", "")
+		for _, name := range []string{"category", "subcategory", "name", "body", "email", "photo"} {
+			postCreateCmd.Flags().Lookup(name).Changed = false
+		}
+		_ = postCreateCmd.Flags().Set("category", "0")
+		_ = postCreateCmd.Flags().Set("subcategory", "0")
+		_ = postCreateCmd.Flags().Lookup("photo").Value.(interface{ Replace([]string) error }).Replace(nil)
+	})
+
+	for name, value := range map[string]string{
+		"category":    "9",
+		"subcategory": "90",
+		"name":        "Desk lamp",
+		"body":        "Works great",
+		"email":       "seller@stanford.edu",
+		"photo":       photoPath,
+	} {
+		if err := postCreateCmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("setting %s flag: %v", name, err)
+		}
+	}
+
+	var out bytes.Buffer
+	postCreateCmd.SetOut(&out)
+	postCreateCmd.SetContext(t.Context())
+	if err := postCreateCmd.RunE(postCreateCmd, nil); err != nil {
+		t.Fatalf("unexpected error running post create: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "photo_count: 1") {
+		t.Fatalf("expected photo_count: 1; output was %q", out.String())
+	}
+	stored, err := filepath.Glob(filepath.Join(photoDir, "v2", "posts", "*", "*.png"))
+	if err != nil || len(stored) != 2 {
+		t.Fatalf("expected post + ticker variants on disk, got %v (err=%v)", stored, err)
+	}
+	rel, err := filepath.Rel(photoDir, stored[0])
+	if err != nil {
+		t.Fatalf("relative photo path: %v", err)
+	}
+	key := filepath.ToSlash(rel)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	root, routes, err := localPhotoRoutes(cfg)
+	if err != nil {
+		t.Fatalf("unexpected routes error: %v", err)
+	}
+	if root != photoDir || len(routes) != 2 || routes[0] != "/v2/posts/" || routes[1] != "/posts/" {
+		t.Fatalf("unexpected photo routes %q %v", root, routes)
+	}
+
+	rec := httptest.NewRecorder()
+	adapters.NewLocalPhotoHandler(root).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+key+"?1772366400", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected uploaded photo to be served, got %d", rec.Code)
+	}
+}
+
+func TestPostPhotos_KeepMetadataRefusedForNonAdmins(t *testing.T) {
+	viper.Set("database_url", "")
+	viper.Set("photo_storage", "local")
+	viper.Set("photo_local_dir", t.TempDir())
+	viper.Set("supabase_url", "http://127.0.0.1:1")
+	viper.Set("supabase_publishable_key", "sb_publishable_test")
+	viper.Set("auth_session_file", filepath.Join(t.TempDir(), "missing.json"))
+	t.Cleanup(func() {
+		viper.Set("supabase_url", "")
+		viper.Set("supabase_publishable_key", "")
+		viper.Set("photo_storage", "")
+		viper.Set("photo_local_dir", "")
+		viper.Set("auth_session_file", "")
+		_ = postPhotosCmd.Flags().Set("keep-metadata", "false")
+		postPhotosCmd.Flags().Lookup("keep-metadata").Changed = false
+	})
+	if err := postPhotosCmd.Flags().Set("keep-metadata", "true"); err != nil {
+		t.Fatalf("setting keep-metadata flag: %v", err)
+	}
+	run := func() error {
+		t.Helper()
+		postPhotosCmd.SetOut(&bytes.Buffer{})
+		postPhotosCmd.SetContext(t.Context())
+		return postPhotosCmd.RunE(postPhotosCmd, []string{"token-1", "add"})
+	}
+
+	if err := run(); !errors.Is(err, domain.ErrUnauthorized) || !strings.Contains(err.Error(), "--keep-metadata is admin only") {
+		t.Fatalf("expected logged-out --keep-metadata to be refused, got %v", err)
+	}
+
+	loginForTest(t, "pat@stanford.edu")
+	if err := run(); !errors.Is(err, domain.ErrUnauthorized) || !strings.Contains(err.Error(), "pat@stanford.edu is not an admin") {
+		t.Fatalf("expected non-admin --keep-metadata to be refused, got %v", err)
+	}
+
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"user-1","email":"pat@stanford.edu","app_metadata":{"role":"admin"}}`))
+	}))
+	defer admin.Close()
+	viper.Set("supabase_url", admin.URL)
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("loading config: %v", err)
+	}
+	if err := requireMetadataAdmin(t.Context(), cfg); err != nil {
+		t.Fatalf("expected an app_metadata admin to pass, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Inspect account and posting policies",
+}
+
+var policyCheckCmd = &cobra.Command{
+	Use:   "check <email>",
+	Short: "Check an email against the domain policy",
+	Long: `Check whether signup, post create, and email login accept an address, and
+which allowlist, suffix, or blocklist entry decided it. The policy comes from
+email_policy_college, email_allowed_domains, email_allowed_suffixes,
+email_blocked_domains, and email_policy_colleges.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		checker, err := emailDomainChecker(cfg)
+		if err != nil {
+			return err
+		}
+
+		decision := checker.Check(args[0])
+		if useTextAuthOutput(cmd, cfg.Format) {
+			return adapters.RenderEmailPolicyDecision(cmd.OutOrStdout(), decision)
+		}
+		return adapters.Render(cfg.Format, decision)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+	policyCmd.AddCommand(policyCheckCmd)
+}
+
+// emailDomainChecker builds the email domain policy from config for
+// signup, post create, login, and policy check.
+func emailDomainChecker(cfg *config.Config) (*service.EmailDomainChecker, error) {
+	policy := domain.EmailDomainPolicy{
+		College: cfg.EmailPolicyCollege,
+		Rules: domain.EmailDomainRules{
+			AllowedDomains:  cfg.EmailAllowedDomains,
+			AllowedSuffixes: cfg.EmailAllowedSuffixes,
+			BlockedDomains:  cfg.EmailBlockedDomains,
+		},
+	}
+	if len(cfg.EmailPolicyColleges) > 0 {
+		policy.Colleges = make(map[string]domain.EmailDomainRules, len(cfg.EmailPolicyColleges))
+		for college, rules := range cfg.EmailPolicyColleges {
+			policy.Colleges[college] = domain.EmailDomainRules{
+				Name:            rules.Name,
+				Hint:            rules.Hint,
+				AllowedDomains:  rules.AllowedDomains,
+				AllowedSuffixes: rules.AllowedSuffixes,
+				BlockedDomains:  rules.BlockedDomains,
+			}
+		}
+	}
+	checker, err := service.NewEmailDomainChecker(policy)
+	if err != nil {
+		return nil, fmt.Errorf("configuring email domain policy: %w", err)
+	}
+	return checker, nil
+}
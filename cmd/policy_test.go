@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestPolicyCheck_ReportsDecidingRule(t *testing.T) {
+	viper.Set("format", "json")
+	viper.Set("email_blocked_domains", []string{"lpch.org"})
+	t.Cleanup(func() {
+		viper.Set("email_blocked_domains", nil)
+	})
+
+	run := func(email string) string {
+		t.Helper()
+		var out bytes.Buffer
+		policyCheckCmd.SetOut(&out)
+		policyCheckCmd.SetContext(t.Context())
+		if err := policyCheckCmd.RunE(policyCheckCmd, []string{email}); err != nil {
+			t.Fatalf("unexpected policy check error: %v", err)
+		}
+		return out.String()
+	}
+
+	output := run("pat@cs.stanford.edu")
+	if !strings.Contains(output, "allowed: pat@cs.stanford.edu") || !strings.Contains(output, "rule: allowed_suffix (.stanford.edu)") {
+		t.Fatalf("unexpected policy check output:\n%s", output)
+	}
+	output = run("pat@lpch.org")
+	if !strings.Contains(output, "rejected: pat@lpch.org") || !strings.Contains(output, "rule: blocked_domain (lpch.org)") {
+		t.Fatalf("unexpected policy check output:\n%s", output)
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/Capmus-Team/supost-cli/internal/adapters"
 	"github.com/Capmus-Team/supost-cli/internal/config"
 	"github.com/Capmus-Team/supost-cli/internal/domain"
-	"github.com/Capmus-Team/supost-cli/internal/repository"
 	"github.com/Capmus-Team/supost-cli/internal/service"
 	"github.com/spf13/cobra"
 )
@@ -29,19 +28,9 @@ var postCmd = &cobra.Command{
 			return err
 		}
 
-		var (
-			repo      service.PostRepository
-			closeRepo func() error
-		)
-		if cfg.DatabaseURL != "" {
-			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
-			if err != nil {
-				return fmt.Errorf("connecting to postgres: %w", err)
-			}
-			repo = pgRepo
-			closeRepo = pgRepo.Close
-		} else {
-			repo = repository.NewInMemory()
+		repo, closeRepo, err := openDataStore(cmd.Context(), cfg)
+		if err != nil {
+			return err
 		}
 		if closeRepo != nil {
 			defer func() {
@@ -58,7 +47,7 @@ var postCmd = &cobra.Command{
 			return fmt.Errorf("fetching post %d: %w", postID, err)
 		}
 
-		return renderPostOutput(cmd, cfg.Format, post)
+		return renderPostOutput(cmd, cfg.Format, photoBaseURL(cfg), post)
 	},
 }
 
@@ -66,12 +55,12 @@ func init() {
 	rootCmd.AddCommand(postCmd)
 }
 
-func renderPostOutput(cmd *cobra.Command, format string, post domain.Post) error {
+func renderPostOutput(cmd *cobra.Command, format string, photoBaseURL string, post domain.Post) error {
 	if !cmd.Flags().Changed("format") && (format == "" || format == "json") {
-		return adapters.RenderPostPage(cmd.OutOrStdout(), post)
+		return adapters.RenderPostPage(cmd.OutOrStdout(), post, photoBaseURL)
 	}
 	if format == "text" || format == "table" {
-		return adapters.RenderPostPage(cmd.OutOrStdout(), post)
+		return adapters.RenderPostPage(cmd.OutOrStdout(), post, photoBaseURL)
 	}
 	return adapters.Render(format, post)
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/Capmus-Team/supost-cli/internal/adapters"
 	"github.com/Capmus-Team/supost-cli/internal/config"
 	"github.com/Capmus-Team/supost-cli/internal/domain"
-	"github.com/Capmus-Team/supost-cli/internal/repository"
 	"github.com/Capmus-Team/supost-cli/internal/service"
 	"github.com/spf13/cobra"
 )
@@ -35,19 +34,9 @@ var postCreateCmd = &cobra.Command{
 			return fmt.Errorf("reading subcategory flag: %w", err)
 		}
 
-		var (
-			repo      service.PostCreateRepository
-			closeRepo func() error
-		)
-		if cfg.DatabaseURL != "" {
-			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
-			if err != nil {
-				return fmt.Errorf("connecting to postgres: %w", err)
-			}
-			repo = pgRepo
-			closeRepo = pgRepo.Close
-		} else {
-			repo = repository.NewInMemory()
+		repo, closeRepo, err := openDataStore(cmd.Context(), cfg)
+		if err != nil {
+			return err
 		}
 		if closeRepo != nil {
 			defer func() {
@@ -55,7 +44,15 @@ var postCreateCmd = &cobra.Command{
 			}()
 		}
 
-		svc := service.NewPostCreateService(repo)
+		checker, err := emailDomainChecker(cfg)
+		if err != nil {
+			return err
+		}
+
+		svc := service.NewPostCreateService(repo).
+			WithEmailDomainPolicy(checker).
+			WithPhotoLimits(photoLimits(cfg)).
+			WithPhotoUploadConcurrency(cfg.PhotoUploadConcurrency)
 
 		if postCreateSubmitRequested(cmd) {
 			name, err := cmd.Flags().GetString("name")
@@ -86,6 +83,15 @@ var postCreateCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("reading dry-run flag: %w", err)
 			}
+			keepMetadata, err := cmd.Flags().GetBool("keep-metadata")
+			if err != nil {
+				return fmt.Errorf("reading keep-metadata flag: %w", err)
+			}
+			if keepMetadata {
+				if err := requireMetadataAdmin(cmd.Context(), cfg); err != nil {
+					return err
+				}
+			}
 			photos, err := loadPostCreatePhotos(photoPaths)
 			if err != nil {
 				return err
@@ -103,34 +109,27 @@ var postCreateCmd = &cobra.Command{
 				Photos:        photos,
 			}
 
+			templates, err := loadEmailTemplates(cfg)
+			if err != nil {
+				return err
+			}
+			svc.WithEmailTemplates(templates)
+
 			var sender service.PostCreateEmailSender
 			if !dryRun {
-				mailgunSender, err := adapters.NewMailgunSender(
-					cfg.MailgunAPIBase,
-					cfg.MailgunDomain,
-					cfg.MailgunAPIKey,
-					cfg.MailgunFromEmail,
-					cfg.MailgunSendTimeout,
-				)
+				mailSender, err := newEmailSender(cfg)
 				if err != nil {
-					return fmt.Errorf("configuring mailgun sender: %w", err)
+					return fmt.Errorf("configuring email sender: %w", err)
 				}
-				sender = mailgunSender
+				sender = mailSender
 			}
 
 			var photoUploader service.PostCreatePhotoUploader
 			if !dryRun && len(photos) > 0 {
-				s3Uploader, err := adapters.NewS3PostPhotoUploader(
-					cmd.Context(),
-					cfg.S3PhotoRegion,
-					cfg.S3PhotoBucket,
-					cfg.S3PhotoPrefix,
-					cfg.S3PhotoAWSProfile,
-				)
+				photoUploader, err = newPhotoUploader(cmd.Context(), cfg, keepMetadata)
 				if err != nil {
-					return fmt.Errorf("configuring s3 photo uploader: %w", err)
+					return err
 				}
-				photoUploader = s3Uploader
 			}
 
 			result, err := svc.Submit(
@@ -138,7 +137,7 @@ var postCreateCmd = &cobra.Command{
 				input,
 				dryRun,
 				cfg.SupostBaseURL,
-				cfg.MailgunFromEmail,
+				mailFromEmail(cfg),
 				sender,
 				photoUploader,
 			)
@@ -167,6 +166,7 @@ func init() {
 	postCreateCmd.Flags().Float64("price", 0, "post price")
 	postCreateCmd.Flags().String("ip", "", "poster IP address (optional)")
 	postCreateCmd.Flags().StringArray("photo", nil, "photo file path (repeat up to 4 times)")
+	postCreateCmd.Flags().Bool("keep-metadata", false, "admin only (checked with Supabase): keep EXIF/XMP/ICC metadata (including GPS) in uploaded photos")
 	postCreateCmd.Flags().Bool("dry-run", false, "validate and render publish email without inserting/sending")
 }
 
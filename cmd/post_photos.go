@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+	"github.com/Capmus-Team/supost-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var postPhotosCmd = &cobra.Command{
+	Use:   "photos <access_token> [list|add|replace <position>|remove <position>|reorder <order>]",
+	Short: "Manage the photos of an existing post",
+	Long: `List, add, replace, remove, or reorder the photos of a post identified by its access token.
+Positions stay contiguous from 0 (at most 4 photos). Examples:
+
+  supost post photos <access_token> list
+  supost post photos <access_token> add --photo ./desk.jpg
+  supost post photos <access_token> replace 1 --photo ./better.jpg
+  supost post photos <access_token> remove 2
+  supost post photos <access_token> reorder 2,0,1,3`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		accessToken := strings.TrimSpace(args[0])
+		action := "list"
+		if len(args) > 1 {
+			action = strings.ToLower(strings.TrimSpace(args[1]))
+		}
+		operand := ""
+		if len(args) > 2 {
+			operand = strings.TrimSpace(args[2])
+		}
+		switch action {
+		case "list", "add":
+			if operand != "" {
+				return fmt.Errorf("post photos %s takes no argument after the action", action)
+			}
+		case "replace", "remove", "reorder":
+			if operand == "" {
+				return fmt.Errorf("post photos %s requires an argument", action)
+			}
+		default:
+			return fmt.Errorf("unknown post photos action %q (expected list, add, replace, remove, or reorder)", action)
+		}
+
+		photoPaths, err := cmd.Flags().GetStringArray("photo")
+		if err != nil {
+			return fmt.Errorf("reading photo flag: %w", err)
+		}
+		keepMetadata, err := cmd.Flags().GetBool("keep-metadata")
+		if err != nil {
+			return fmt.Errorf("reading keep-metadata flag: %w", err)
+		}
+		if keepMetadata {
+			if err := requireMetadataAdmin(cmd.Context(), cfg); err != nil {
+				return err
+			}
+		}
+		if len(photoPaths) > 0 && action != "add" && action != "replace" {
+			return fmt.Errorf("--photo is only used with add or replace")
+		}
+
+		var (
+			repo      service.PostPhotoRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := openPostgres(cmd.Context(), cfg)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
+
+		svc := service.NewPostPhotoService(repo).
+			WithPhotoLimits(photoLimits(cfg)).
+			WithPhotoUploadConcurrency(cfg.PhotoUploadConcurrency)
+
+		var photoUploader service.PostCreatePhotoUploader
+		// Reorder only touches storage to refresh local storage's ticker copy.
+		if action != "list" && (action != "reorder" || photoStorage(cfg) == photoStorageLocal) {
+			photoUploader, err = newPhotoUploader(cmd.Context(), cfg, keepMetadata)
+			if err != nil {
+				return err
+			}
+		}
+
+		var result domain.PostPhotosResult
+		switch action {
+		case "list":
+			result, err = svc.List(cmd.Context(), accessToken)
+		case "add":
+			if len(photoPaths) == 0 {
+				return fmt.Errorf("post photos add requires at least one --photo")
+			}
+			photos, loadErr := loadPostCreatePhotos(photoPaths)
+			if loadErr != nil {
+				return loadErr
+			}
+			result, err = svc.Add(cmd.Context(), accessToken, photos, photoUploader)
+		case "replace":
+			position, parseErr := parsePhotoPositionArg(operand)
+			if parseErr != nil {
+				return parseErr
+			}
+			if len(photoPaths) != 1 {
+				return fmt.Errorf("post photos replace requires exactly one --photo")
+			}
+			photos, loadErr := loadPostCreatePhotos(photoPaths)
+			if loadErr != nil {
+				return loadErr
+			}
+			result, err = svc.Replace(cmd.Context(), accessToken, position, photos[0], photoUploader)
+		case "remove":
+			position, parseErr := parsePhotoPositionArg(operand)
+			if parseErr != nil {
+				return parseErr
+			}
+			result, err = svc.Remove(cmd.Context(), accessToken, position, photoUploader)
+		case "reorder":
+			order, parseErr := parsePhotoOrderArg(operand)
+			if parseErr != nil {
+				return parseErr
+			}
+			result, err = svc.Reorder(cmd.Context(), accessToken, order, photoUploader)
+		}
+		if err != nil {
+			if result.PostID == 0 {
+				return fmt.Errorf("post photos %s: %w", action, err)
+			}
+			// The photo rows changed but cleanup failed: show the new state too.
+			if renderErr := renderPostPhotosOutput(cmd, cfg.Format, photoBaseURL(cfg), result); renderErr != nil {
+				return renderErr
+			}
+			return fmt.Errorf("post photos %s: %w", action, err)
+		}
+		return renderPostPhotosOutput(cmd, cfg.Format, photoBaseURL(cfg), result)
+	},
+}
+
+func init() {
+	postCmd.AddCommand(postPhotosCmd)
+	postPhotosCmd.Flags().StringArray("photo", nil, "photo file path for add (repeatable) or replace")
+	postPhotosCmd.Flags().Bool("keep-metadata", false, "admin only (checked with Supabase): keep EXIF/XMP/ICC metadata (including GPS) in uploaded photos")
+}
+
+func renderPostPhotosOutput(cmd *cobra.Command, format string, photoBaseURL string, result domain.PostPhotosResult) error {
+	if !cmd.Flags().Changed("format") && (format == "" || format == "json") {
+		return adapters.RenderPostPhotosResult(cmd.OutOrStdout(), result, photoBaseURL)
+	}
+	if format == "text" || format == "table" {
+		return adapters.RenderPostPhotosResult(cmd.OutOrStdout(), result, photoBaseURL)
+	}
+	return adapters.Render(format, result)
+}
+
+func parsePhotoPositionArg(raw string) (int, error) {
+	position, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || position < 0 || position >= domain.MaxPostPhotos {
+		return 0, fmt.Errorf("invalid photo position %q (expected 0..%d)", raw, domain.MaxPostPhotos-1)
+	}
+	return position, nil
+}
+
+// parsePhotoOrderArg parses "2,0,1,3": new position i takes the photo that is
+// currently at the i-th listed position.
+func parsePhotoOrderArg(raw string) ([]int, error) {
+	parts := strings.Split(raw, ",")
+	order := make([]int, 0, len(parts))
+	for _, part := range parts {
+		position, err := parsePhotoPositionArg(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid photo order %q: %w", raw, err)
+		}
+		order = append(order, position)
+	}
+	return order, nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePhotoOrderArg(t *testing.T) {
+	order, err := parsePhotoOrderArg("2, 0,1,3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(order, []int{2, 0, 1, 3}) {
+		t.Fatalf("unexpected order %v", order)
+	}
+	for _, raw := range []string{"", "0,,1", "0,4", "a,b", "-1"} {
+		if _, err := parsePhotoOrderArg(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/Capmus-Team/supost-cli/internal/adapters"
 	"github.com/Capmus-Team/supost-cli/internal/config"
 	"github.com/Capmus-Team/supost-cli/internal/domain"
-	"github.com/Capmus-Team/supost-cli/internal/repository"
 	"github.com/Capmus-Team/supost-cli/internal/service"
 	"github.com/spf13/cobra"
 )
@@ -44,19 +43,9 @@ var postRespondCmd = &cobra.Command{
 			return fmt.Errorf("reading dry-run flag: %w", err)
 		}
 
-		var (
-			repo      service.PostRespondRepository
-			closeRepo func() error
-		)
-		if cfg.DatabaseURL != "" {
-			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
-			if err != nil {
-				return fmt.Errorf("connecting to postgres: %w", err)
-			}
-			repo = pgRepo
-			closeRepo = pgRepo.Close
-		} else {
-			repo = repository.NewInMemory()
+		repo, closeRepo, err := openDataStore(cmd.Context(), cfg)
+		if err != nil {
+			return err
 		}
 		if closeRepo != nil {
 			defer func() {
@@ -66,20 +55,25 @@ var postRespondCmd = &cobra.Command{
 
 		var sender service.PostRespondEmailSender
 		if !dryRun {
-			mailgunSender, err := adapters.NewMailgunSender(
-				cfg.MailgunAPIBase,
-				cfg.MailgunDomain,
-				cfg.MailgunAPIKey,
-				cfg.MailgunFromEmail,
-				cfg.MailgunSendTimeout,
-			)
+			mailSender, err := newEmailSender(cfg)
 			if err != nil {
-				return fmt.Errorf("configuring mailgun sender: %w", err)
+				return fmt.Errorf("configuring email sender: %w", err)
 			}
-			sender = mailgunSender
+			sender = mailSender
 		}
 
-		svc := service.NewPostRespondService(repo)
+		templates, err := loadEmailTemplates(cfg)
+		if err != nil {
+			return err
+		}
+		svc := service.NewPostRespondService(repo).WithEmailTemplates(templates)
+		if cfg.MailRelayEnabled {
+			relay, err := newMailRelay(cfg)
+			if err != nil {
+				return err
+			}
+			svc.WithReplyRelay(relay)
+		}
 		result, err := svc.Respond(
 			cmd.Context(),
 			domain.PostRespondSubmission{
@@ -91,7 +85,7 @@ var postRespondCmd = &cobra.Command{
 			},
 			dryRun,
 			cfg.SupostBaseURL,
-			cfg.MailgunFromEmail,
+			mailFromEmail(cfg),
 			sender,
 		)
 		if err != nil {
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+)
+
+// openPostgres connects to DATABASE_URL for user-facing commands. With
+// database_rls set, every statement runs as the logged-in user, or anon
+// when nobody is logged in, so the same RLS policies apply as for the web
+// app. Admin commands keep calling repository.NewPostgres directly.
+func openPostgres(ctx context.Context, cfg *config.Config) (*repository.Postgres, error) {
+	pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
+	if err != nil || !cfg.DatabaseRLS {
+		return pgRepo, err
+	}
+
+	sessions, _, err := newAuthSessionService(cfg)
+	if err != nil {
+		_ = pgRepo.Close()
+		return nil, fmt.Errorf("database_rls needs the Supabase auth settings: %w", err)
+	}
+	claims, err := sessions.DatabaseClaims(ctx)
+	if err != nil {
+		_ = pgRepo.Close()
+		return nil, fmt.Errorf("loading claims for database_rls: %w", err)
+	}
+	scoped, err := pgRepo.WithRLS(claims.Role, claims.Claims)
+	if err != nil {
+		_ = pgRepo.Close()
+		return nil, fmt.Errorf("scoping database_rls: %w", err)
+	}
+	return scoped, nil
+}
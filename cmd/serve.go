@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
 	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
 	"github.com/Capmus-Team/supost-cli/internal/repository"
 	"github.com/Capmus-Team/supost-cli/internal/service"
 
@@ -18,7 +21,17 @@ var serveCmd = &cobra.Command{
 	Short: "Start a preview HTTP server",
 	Long: `Start a lightweight HTTP server that exposes the service layer as JSON
 endpoints. This is for prototyping only — it will be replaced by Next.js
-API routes in production. Uses in-memory data by default.`,
+API routes in production. Uses in-memory data by default (Postgres when
+database_url is set).
+
+When a Mailgun webhook signing key (or API key) and relay domain are
+configured, POST /webhooks/mailgun/inbound accepts Mailgun inbound-route
+deliveries for relay addresses and forwards them to the other party, and
+POST /webhooks/mailgun/events records delivered/failed/complained/unsubscribed
+events on the matching message row.
+
+With photo_storage: local, stored post photos are served at the same paths
+the post and home renderers link to (/v2/posts/... and /posts/...).`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load()
 		if err != nil {
@@ -31,7 +44,25 @@ API routes in production. Uses in-memory data by default.`,
 		}
 
 		// Composition root: choose the repository adapter.
-		repo := repository.NewInMemory()
+		var (
+			repo      serveRepository
+			closeRepo func() error
+		)
+		if cfg.DatabaseURL != "" {
+			pgRepo, err := repository.NewPostgres(cfg.DatabaseURL)
+			if err != nil {
+				return fmt.Errorf("connecting to postgres: %w", err)
+			}
+			repo = pgRepo
+			closeRepo = pgRepo.Close
+		} else {
+			repo = repository.NewInMemory()
+		}
+		if closeRepo != nil {
+			defer func() {
+				_ = closeRepo()
+			}()
+		}
 		homeSvc := service.NewHomeService(repo)
 
 		mux := http.NewServeMux()
@@ -53,15 +84,139 @@ API routes in production. Uses in-memory data by default.`,
 			json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 		})
 
+		inboundEnabled := false
+		if handler, err := newMailgunInboundHandler(cfg, repo); err != nil {
+			log.Printf("Mailgun inbound relay disabled: %v", err)
+		} else {
+			mux.Handle("POST /webhooks/mailgun/inbound", handler)
+			inboundEnabled = true
+		}
+
+		eventsEnabled := false
+		if handler, err := newMailgunEventsHandler(cfg, repo); err != nil {
+			log.Printf("Mailgun event webhook disabled: %v", err)
+		} else {
+			mux.Handle("POST /webhooks/mailgun/events", handler)
+			eventsEnabled = true
+		}
+
+		var photoRoutes []string
+		if photoStorage(cfg) == photoStorageLocal {
+			root, routes, err := localPhotoRoutes(cfg)
+			if err != nil {
+				return err
+			}
+			photos := adapters.NewLocalPhotoHandler(root)
+			for _, route := range routes {
+				mux.Handle("GET "+route, photos)
+			}
+			photoRoutes = routes
+		}
+
 		addr := fmt.Sprintf(":%d", port)
 		log.Printf("Preview server running at http://localhost%s", addr)
 		log.Printf("  GET /api/posts")
 		log.Printf("  GET /api/health")
+		if inboundEnabled {
+			log.Printf("  POST /webhooks/mailgun/inbound")
+		}
+		if eventsEnabled {
+			log.Printf("  POST /webhooks/mailgun/events")
+		}
+		for _, route := range photoRoutes {
+			log.Printf("  GET %s... (local photos)", route)
+		}
 		log.Printf("Press Ctrl+C to stop.")
 		return http.ListenAndServe(addr, mux)
 	},
 }
 
+// serveRepository is every read/write the preview server's handlers need.
+type serveRepository interface {
+	service.HomeRepository
+	service.MailRelayRepository
+	service.MessageStatusRepository
+}
+
+// newMailgunInboundHandler wires signature verification, relay parsing, and
+// the outgoing mail transport for inbound relay replies.
+func newMailgunInboundHandler(cfg *config.Config, repo service.MailRelayRepository) (http.Handler, error) {
+	verifier, err := adapters.NewMailgunWebhookVerifier(mailgunWebhookSigningKey(cfg), 0)
+	if err != nil {
+		return nil, err
+	}
+	relay, err := newMailRelay(cfg)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := newEmailSender(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuring email sender: %w", err)
+	}
+	relaySvc := service.NewMailRelayService(repo, relay)
+	fromEmail := mailFromEmail(cfg)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inbound, sig, err := adapters.ParseMailgunInbound(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := verifier.Verify(sig); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		result, err := relaySvc.Forward(r.Context(), inbound, fromEmail, sender)
+		if err != nil {
+			// 406 tells Mailgun not to retry: the address will never be valid.
+			if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrUnauthorized) {
+				http.Error(w, err.Error(), http.StatusNotAcceptable)
+				return
+			}
+			log.Printf("mailgun inbound relay failed: %v", err)
+			// Mailgun retries 5xx with the same token; let that retry through.
+			verifier.Forget(sig)
+			http.Error(w, "relay failed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}), nil
+}
+
+// newMailgunEventsHandler verifies Mailgun event webhooks and applies them to
+// message delivery status.
+func newMailgunEventsHandler(cfg *config.Config, repo service.MessageStatusRepository) (http.Handler, error) {
+	verifier, err := adapters.NewMailgunWebhookVerifier(mailgunWebhookSigningKey(cfg), 0)
+	if err != nil {
+		return nil, err
+	}
+	statusSvc := service.NewMessageStatusService(repo)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, sig, err := adapters.ParseMailgunEvent(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := verifier.Verify(sig); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		result, err := statusSvc.ApplyEvent(r.Context(), event)
+		if err != nil {
+			log.Printf("mailgun event failed: %v", err)
+			verifier.Forget(sig)
+			http.Error(w, "event failed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}), nil
+}
+
 func init() {
 	rootCmd.AddCommand(serveCmd)
 	serveCmd.Flags().IntP("port", "p", 8080, "port to listen on")
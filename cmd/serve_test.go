@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+)
+
+func TestMailgunInboundHandler_RelaysReplyToResponder(t *testing.T) {
+	cfg := &config.Config{
+		MailProvider:     "file",
+		MailFileDir:      t.TempDir(),
+		MailgunDomain:    "mg.supost.com",
+		MailgunAPIKey:    "key-test",
+		MailgunFromEmail: "response@mg.supost.com",
+	}
+	repo := repository.NewInMemory()
+	message, err := repo.CreateResponseMessage(context.Background(), 130031783, "buyer@gmail.com", "Still available?", "", "supost-cli")
+	if err != nil {
+		t.Fatalf("seeding message: %v", err)
+	}
+	relay, err := newMailRelay(cfg)
+	if err != nil {
+		t.Fatalf("unexpected relay error: %v", err)
+	}
+
+	handler, err := newMailgunInboundHandler(cfg, repo)
+	if err != nil {
+		t.Fatalf("unexpected handler error: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	post := func(signingKey, issuedTo, from string) *http.Response {
+		t.Helper()
+		req, err := adapters.NewMailgunInboundRequest(context.Background(), server.URL, signingKey, domain.InboundEmail{
+			Recipient: relay.Address(message.ID, domain.RelayToResponder, issuedTo),
+			Sender:    from,
+			From:      from,
+			Subject:   "Re: Looking for a buddy",
+			Text:      "Yes! Friday works.",
+		}, time.Now())
+		if err != nil {
+			t.Fatalf("building inbound request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("posting inbound request: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	poster := "wientjes@alumni.stanford.edu"
+	if resp := post("key-wrong", poster, poster); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad signature, got %d", resp.StatusCode)
+	}
+	if resp := post("key-test", "stranger@example.com", poster); resp.StatusCode != http.StatusNotAcceptable {
+		t.Fatalf("expected 406 for an address not issued to the poster, got %d", resp.StatusCode)
+	}
+	if resp := post("key-test", poster, poster); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for valid relay reply, got %d", resp.StatusCode)
+	}
+
+	sink, _ := adapters.NewFileMailSender(cfg.MailFileDir, "")
+	emails, err := sink.List()
+	if err != nil || len(emails) != 1 {
+		t.Fatalf("expected exactly one relayed email, got %d (err=%v)", len(emails), err)
+	}
+	got := emails[0]
+	if got.To != "buyer@gmail.com" || got.Text != "Yes! Friday works." {
+		t.Fatalf("unexpected relayed email %+v", got)
+	}
+	if got.ReplyTo != relay.Address(message.ID, domain.RelayToPoster, "buyer@gmail.com") || strings.Contains(got.ReplyTo, "stanford.edu") {
+		t.Fatalf("expected reply-to relay back to poster, got %q", got.ReplyTo)
+	}
+}
+
+func TestMailgunEventsHandler_UpdatesMessageStatus(t *testing.T) {
+	cfg := &config.Config{MailgunAPIKey: "key-test"}
+	repo := repository.NewInMemory()
+	message, err := repo.CreateResponseMessage(context.Background(), 130031783, "buyer@gmail.com", "Still available?", "", "supost-cli")
+	if err != nil {
+		t.Fatalf("seeding message: %v", err)
+	}
+	if err := repo.SetMessageMailgunID(context.Background(), message.ID, "20260301.1@mg.supost.com"); err != nil {
+		t.Fatalf("seeding mailgun id: %v", err)
+	}
+
+	handler, err := newMailgunEventsHandler(cfg, repo)
+	if err != nil {
+		t.Fatalf("unexpected handler error: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	post := func(signingKey, event string) *http.Response {
+		t.Helper()
+		req, err := adapters.NewMailgunEventRequest(context.Background(), server.URL, signingKey, domain.MailEvent{
+			Event:     event,
+			MessageID: "<20260301.1@mg.supost.com>",
+			Recipient: "wientjes@alumni.stanford.edu",
+		}, time.Now())
+		if err != nil {
+			t.Fatalf("building event request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("posting event request: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	if resp := post("key-wrong", "delivered"); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad signature, got %d", resp.StatusCode)
+	}
+	if resp := post("key-test", "delivered"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 for delivered event, got %d", resp.StatusCode)
+	}
+
+	got, err := repo.GetMessageByID(context.Background(), message.ID)
+	if err != nil {
+		t.Fatalf("reloading message: %v", err)
+	}
+	if got.Status != domain.MessageStatusDelivered {
+		t.Fatalf("expected delivered status, got %q", got.Status)
+	}
+}
+
+// failingStatusRepo fails the first status update, like a database blip.
+type failingStatusRepo struct {
+	*repository.InMemory
+	failures int
+}
+
+func (r *failingStatusRepo) UpdateMessageStatus(ctx context.Context, messageID int64, status string) (domain.Message, error) {
+	if r.failures > 0 {
+		r.failures--
+		return domain.Message{}, errors.New("database unavailable")
+	}
+	return r.InMemory.UpdateMessageStatus(ctx, messageID, status)
+}
+
+func TestMailgunEventsHandler_AcceptsRetryAfterServerError(t *testing.T) {
+	cfg := &config.Config{MailgunAPIKey: "key-test"}
+	repo := &failingStatusRepo{InMemory: repository.NewInMemory(), failures: 1}
+	message, err := repo.CreateResponseMessage(context.Background(), 130031783, "buyer@gmail.com", "Still available?", "", "supost-cli")
+	if err != nil {
+		t.Fatalf("seeding message: %v", err)
+	}
+	if err := repo.SetMessageMailgunID(context.Background(), message.ID, "20260301.1@mg.supost.com"); err != nil {
+		t.Fatalf("seeding mailgun id: %v", err)
+	}
+
+	handler, err := newMailgunEventsHandler(cfg, repo)
+	if err != nil {
+		t.Fatalf("unexpected handler error: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	signed, err := adapters.NewMailgunEventRequest(context.Background(), server.URL, "key-test", domain.MailEvent{
+		Event:     "delivered",
+		MessageID: "<20260301.1@mg.supost.com>",
+		Recipient: "wientjes@alumni.stanford.edu",
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("building event request: %v", err)
+	}
+	payload, err := io.ReadAll(signed.Body)
+	if err != nil {
+		t.Fatalf("reading event payload: %v", err)
+	}
+	// Mailgun retries by resending the identical signed payload.
+	deliver := func() int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader(payload))
+		if err != nil {
+			t.Fatalf("building retry request: %v", err)
+		}
+		req.Header.Set("Content-Type", signed.Header.Get("Content-Type"))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("posting event request: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := deliver(); status != http.StatusInternalServerError {
+		t.Fatalf("expected 500 while the repository fails, got %d", status)
+	}
+	if status := deliver(); status != http.StatusOK {
+		t.Fatalf("expected Mailgun's retry to be accepted, got %d", status)
+	}
+	if status := deliver(); status != http.StatusUnauthorized {
+		t.Fatalf("expected a replay after success to be rejected, got %d", status)
+	}
+	got, err := repo.GetMessageByID(context.Background(), message.ID)
+	if err != nil || got.Status != domain.MessageStatusDelivered {
+		t.Fatalf("expected delivered status after retry, got %q (%v)", got.Status, err)
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/Capmus-Team/supost-cli/internal/adapters"
 	"github.com/Capmus-Team/supost-cli/internal/config"
@@ -39,17 +38,19 @@ var signupCmd = &cobra.Command{
 			return fmt.Errorf("reading password flag: %w", err)
 		}
 
-		apiKey := strings.TrimSpace(cfg.SupabasePublishableKey)
-		if apiKey == "" {
-			apiKey = strings.TrimSpace(cfg.SupabaseAnonKey)
+		provider, err := adapters.NewSupabaseAuthSignupClient(cfg.SupabaseURL, supabaseAPIKey(cfg), cfg.SupabaseSecretKey)
+		if err != nil {
+			return fmt.Errorf("configuring supabase auth signup: %w", err)
 		}
 
-		provider, err := adapters.NewSupabaseAuthSignupClient(cfg.SupabaseURL, apiKey, cfg.SupabaseSecretKey)
+		checker, err := emailDomainChecker(cfg)
 		if err != nil {
-			return fmt.Errorf("configuring supabase auth signup: %w", err)
+			return err
 		}
 
-		svc := service.NewUserSignupService(provider)
+		svc := service.NewUserSignupService(provider).
+			WithEmailDomainPolicy(checker).
+			WithPasswordChecker(service.NewPasswordChecker(passwordPolicy(cfg)))
 		result, err := svc.SignUp(cmd.Context(), domain.UserSignupSubmission{
 			DisplayName: displayName,
 			Email:       email,
@@ -64,12 +65,22 @@ var signupCmd = &cobra.Command{
 	},
 }
 
+// passwordPolicy maps the password_* settings onto the signup policy.
+func passwordPolicy(cfg *config.Config) domain.PasswordPolicy {
+	return domain.PasswordPolicy{
+		MinLength:           cfg.PasswordMinLength,
+		MinCharacterClasses: cfg.PasswordMinCharacterClasses,
+		PassphraseLength:    cfg.PasswordPassphraseLength,
+		MinEntropyBits:      cfg.PasswordMinEntropyBits,
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(signupCmd)
 	signupCmd.Flags().String("display-name", "", "user display name")
 	signupCmd.Flags().String("email", "", "user email")
 	signupCmd.Flags().String("phone", "", "user phone in international format, e.g. +16505551234")
-	signupCmd.Flags().String("password", "", "user password (minimum 8 characters)")
+	signupCmd.Flags().String("password", "", "user password (see the password policy in the README)")
 	_ = signupCmd.MarkFlagRequired("display-name")
 	_ = signupCmd.MarkFlagRequired("email")
 	_ = signupCmd.MarkFlagRequired("phone")
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/Capmus-Team/supost-cli/internal/adapters"
+	"github.com/Capmus-Team/supost-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the logged-in user",
+	Long:  "Decode the stored session's access token and show the user it belongs to. An expired session is refreshed first.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		svc, _, err := newAuthSessionService(cfg)
+		if err != nil {
+			return err
+		}
+		identity, err := svc.WhoAmI(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if useTextAuthOutput(cmd, cfg.Format) {
+			return adapters.RenderAuthIdentity(cmd.OutOrStdout(), identity)
+		}
+		return adapters.Render(cfg.Format, identity)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whoamiCmd)
+}
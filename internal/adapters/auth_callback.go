@@ -0,0 +1,119 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const authCallbackPath = "/auth/callback"
+
+// authCallbackPage forwards the URL fragment, which browsers never send to
+// the server, back to the listener. Supabase's implicit flow puts the
+// session tokens there.
+const authCallbackPage = `<!doctype html>
+<html><head><meta charset="utf-8"><title>supost login</title></head>
+<body>
+<p id="status">Finishing login&hellip;</p>
+<script>
+fetch("` + authCallbackPath + `/complete" + location.search, {method: "POST", body: location.hash.replace(/^#/, "")})
+  .then(function (r) { document.getElementById("status").textContent = r.ok ? "Logged in. You can close this tab and return to the terminal." : "Login failed. Check the terminal."; })
+  .catch(function () { document.getElementById("status").textContent = "Login failed. Check the terminal."; });
+</script>
+</body></html>
+`
+
+// AuthCallbackListener is a one-shot local HTTP server that a magic link
+// redirects to. It captures the redirect URL, fragment included.
+type AuthCallbackListener struct {
+	listener net.Listener
+	server   *http.Server
+	result   chan string
+	once     sync.Once
+}
+
+// NewAuthCallbackListener listens on addr (for example 127.0.0.1:0) and
+// starts serving immediately, so URL is known before the link is sent.
+func NewAuthCallbackListener(addr string) (*AuthCallbackListener, error) {
+	if strings.TrimSpace(addr) == "" {
+		addr = "127.0.0.1:0"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listening for auth callback: %w", err)
+	}
+
+	l := &AuthCallbackListener{listener: listener, result: make(chan string, 1)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+authCallbackPath, l.handlePage)
+	mux.HandleFunc("POST "+authCallbackPath+"/complete", l.handleComplete)
+	l.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		_ = l.server.Serve(listener)
+	}()
+	return l, nil
+}
+
+// URL is the redirect target to hand to Supabase.
+func (l *AuthCallbackListener) URL() string {
+	return "http://" + l.listener.Addr().String() + authCallbackPath
+}
+
+// Wait blocks until the browser reaches the callback and returns the full
+// callback URL, query and fragment included.
+func (l *AuthCallbackListener) Wait(ctx context.Context) (string, error) {
+	select {
+	case callbackURL := <-l.result:
+		return callbackURL, nil
+	case <-ctx.Done():
+		return "", fmt.Errorf("waiting for magic link callback: %w", ctx.Err())
+	}
+}
+
+// Close stops the listener.
+func (l *AuthCallbackListener) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := l.server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (l *AuthCallbackListener) handlePage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = io.WriteString(w, authCallbackPage)
+}
+
+func (l *AuthCallbackListener) handleComplete(w http.ResponseWriter, r *http.Request) {
+	fragment, err := io.ReadAll(io.LimitReader(r.Body, 16*1024))
+	if err != nil {
+		http.Error(w, "reading callback", http.StatusBadRequest)
+		return
+	}
+	callbackURL := l.URL()
+	if r.URL.RawQuery != "" {
+		callbackURL += "?" + r.URL.RawQuery
+	}
+	if len(fragment) > 0 {
+		callbackURL += "#" + string(fragment)
+	}
+
+	delivered := false
+	l.once.Do(func() {
+		l.result <- callbackURL
+		delivered = true
+	})
+	if !delivered {
+		http.Error(w, "login already completed", http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthCallbackListener_CapturesFragmentFromBrowser(t *testing.T) {
+	listener, err := NewAuthCallbackListener("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected listen error: %v", err)
+	}
+	defer listener.Close()
+	if !strings.HasPrefix(listener.URL(), "http://127.0.0.1:") || !strings.HasSuffix(listener.URL(), "/auth/callback") {
+		t.Fatalf("unexpected callback url %q", listener.URL())
+	}
+
+	page, err := http.Get(listener.URL())
+	if err != nil {
+		t.Fatalf("loading callback page: %v", err)
+	}
+	html, _ := io.ReadAll(page.Body)
+	page.Body.Close()
+	if !strings.Contains(string(html), "location.hash") {
+		t.Fatalf("expected the page to forward the fragment:\n%s", html)
+	}
+
+	// What the page's script posts after Supabase redirects with #access_token=...
+	complete := func() int {
+		resp, err := http.Post(listener.URL()+"/complete?type=magiclink", "text/plain", strings.NewReader("access_token=a&refresh_token=r&expires_in=3600"))
+		if err != nil {
+			t.Fatalf("posting callback: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := complete(); status != http.StatusNoContent {
+		t.Fatalf("expected the first callback accepted, got %d", status)
+	}
+	if status := complete(); status != http.StatusConflict {
+		t.Fatalf("expected a second callback refused, got %d", status)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	got, err := listener.Wait(ctx)
+	if err != nil {
+		t.Fatalf("unexpected wait error: %v", err)
+	}
+	if want := listener.URL() + "?type=magiclink#access_token=a&refresh_token=r&expires_in=3600"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestAuthCallbackListener_WaitHonorsContext(t *testing.T) {
+	listener, err := NewAuthCallbackListener("")
+	if err != nil {
+		t.Fatalf("unexpected listen error: %v", err)
+	}
+	defer listener.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := listener.Wait(ctx); err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderAuthLogin renders a completed `supost login`.
+func RenderAuthLogin(w io.Writer, result domain.AuthLoginResult) error {
+	lines := []string{
+		fmt.Sprintf("logged in as %s", result.Email),
+		fmt.Sprintf("user_id: %s", result.UserID),
+	}
+	if result.Phone != "" {
+		lines = append(lines, fmt.Sprintf("phone: %s", result.Phone))
+	}
+	lines = append(lines,
+		fmt.Sprintf("expires_at: %s", formatAuthTime(result.ExpiresAt)),
+		fmt.Sprintf("session_file: %s", result.SessionFile),
+	)
+	if result.ProfileCreated {
+		lines = append(lines, "profile: created")
+	}
+	return writeAuthLines(w, lines)
+}
+
+// RenderAuthIdentity renders `supost whoami`.
+func RenderAuthIdentity(w io.Writer, identity domain.AuthIdentity) error {
+	lines := []string{
+		fmt.Sprintf("user_id: %s", identity.UserID),
+		fmt.Sprintf("email: %s", identity.Email),
+	}
+	if identity.DisplayName != "" {
+		lines = append(lines, fmt.Sprintf("display_name: %s", identity.DisplayName))
+	}
+	if identity.Phone != "" {
+		lines = append(lines, fmt.Sprintf("phone: %s", identity.Phone))
+	}
+	lines = append(lines,
+		fmt.Sprintf("role: %s", identity.Role),
+		fmt.Sprintf("issued_at: %s", formatAuthTime(identity.IssuedAt)),
+		fmt.Sprintf("expires_at: %s", formatAuthTime(identity.ExpiresAt)),
+		fmt.Sprintf("refreshed: %t", identity.Refreshed),
+	)
+	return writeAuthLines(w, lines)
+}
+
+// RenderAuthLogout renders `supost logout`.
+func RenderAuthLogout(w io.Writer, result domain.AuthLogoutResult) error {
+	if !result.LoggedOut {
+		return writeAuthLines(w, []string{"not logged in"})
+	}
+	lines := []string{
+		fmt.Sprintf("logged out %s", result.Email),
+		fmt.Sprintf("revoked: %t", result.Revoked),
+	}
+	if result.RevokeError != "" {
+		lines = append(lines, fmt.Sprintf("revoke_error: %s", result.RevokeError))
+	}
+	return writeAuthLines(w, lines)
+}
+
+func formatAuthTime(value time.Time) string {
+	if value.IsZero() {
+		return "-"
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+func writeAuthLines(w io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package adapters
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// outgoingEmail is the provider-neutral shape used to build RFC 5322 messages
+// for transports that speak raw MIME (SMTP, file sink).
+type outgoingEmail struct {
+	From      string
+	To        string
+	ReplyTo   string
+	Subject   string
+	Text      string
+	HTML      string
+	Date      time.Time
+	MessageID string
+}
+
+// validate mirrors the Mailgun adapter's required-field rules.
+func (e outgoingEmail) validate() error {
+	if strings.TrimSpace(e.From) == "" || strings.TrimSpace(e.To) == "" ||
+		strings.TrimSpace(e.Subject) == "" || strings.TrimSpace(e.Text) == "" {
+		return fmt.Errorf("email from/to/subject/text are required")
+	}
+	return nil
+}
+
+// bytes renders the message as RFC 5322 with CRLF line endings. Text-only
+// messages use a single quoted-printable UTF-8 body; messages with HTML use
+// multipart/alternative (text first, HTML last, per RFC 2046 preference order).
+func (e outgoingEmail) bytes() ([]byte, error) {
+	date := e.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var buf bytes.Buffer
+	writeEmailHeader(&buf, "From", e.From)
+	writeEmailHeader(&buf, "To", e.To)
+	if replyTo := strings.TrimSpace(e.ReplyTo); replyTo != "" {
+		writeEmailHeader(&buf, "Reply-To", replyTo)
+	}
+	writeEmailHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", strings.TrimSpace(e.Subject)))
+	writeEmailHeader(&buf, "Date", date.Format(time.RFC1123Z))
+	if messageID := strings.TrimSpace(e.MessageID); messageID != "" {
+		writeEmailHeader(&buf, "Message-ID", messageID)
+	}
+	writeEmailHeader(&buf, "MIME-Version", "1.0")
+
+	html := strings.TrimSpace(e.HTML)
+	if html == "" {
+		writeEmailHeader(&buf, "Content-Type", "text/plain; charset=UTF-8")
+		writeEmailHeader(&buf, "Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, e.Text); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	writeEmailHeader(&buf, "Content-Type", "multipart/alternative; boundary="+parts.Boundary())
+	buf.WriteString("\r\n")
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=UTF-8", body: e.Text},
+		{contentType: "text/html; charset=UTF-8", body: html},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		w, err := parts.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("creating email part: %w", err)
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("closing multipart email: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(normalizeCRLF(strings.TrimSpace(body)))); err != nil {
+		return fmt.Errorf("encoding email body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("encoding email body: %w", err)
+	}
+	return nil
+}
+
+func writeEmailHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(strings.NewReplacer("\r", "", "\n", "").Replace(strings.TrimSpace(value)))
+	buf.WriteString("\r\n")
+}
+
+func normalizeCRLF(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "\r\n")
+}
+
+// normalizeProviderMessageID strips angle brackets so ids from send responses,
+// Message-ID headers, and webhook payloads compare equal.
+func normalizeProviderMessageID(id string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(id), "<"), ">")
+}
+
+// envelopeAddress extracts the bare addr-spec from a header-style address.
+func envelopeAddress(raw string) (string, error) {
+	parsed, err := mail.ParseAddress(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("parsing email address %q: %w", raw, err)
+	}
+	return parsed.Address, nil
+}
+
+// newMessageID returns a random Message-ID scoped to the sender's domain.
+func newMessageID(from string) string {
+	domainPart := "supost.local"
+	if addr, err := envelopeAddress(from); err == nil {
+		if at := strings.LastIndex(addr, "@"); at >= 0 && at < len(addr)-1 {
+			domainPart = addr[at+1:]
+		}
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("<%d@%s>", time.Now().UnixNano(), domainPart)
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(buf), domainPart)
+}
@@ -0,0 +1,32 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderEmailPolicyDecision renders `supost policy check`.
+func RenderEmailPolicyDecision(w io.Writer, decision domain.EmailPolicyDecision) error {
+	verdict := "allowed"
+	if !decision.Allowed {
+		verdict = "rejected"
+	}
+	lines := []string{
+		fmt.Sprintf("%s: %s", verdict, decision.Email),
+		fmt.Sprintf("college: %s", decision.College),
+	}
+	if decision.Domain != "" {
+		lines = append(lines, fmt.Sprintf("domain: %s", decision.Domain))
+	}
+	rule := decision.Rule
+	if decision.Match != "" {
+		rule += " (" + decision.Match + ")"
+	}
+	lines = append(lines,
+		fmt.Sprintf("rule: %s", rule),
+		fmt.Sprintf("reason: %s", decision.Reason),
+	)
+	return writeAuthLines(w, lines)
+}
@@ -0,0 +1,264 @@
+package adapters
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const capturedEmailExt = ".eml"
+
+// FileMailSender writes each outgoing email as an RFC 5322 .eml file instead of
+// delivering it. Used for offline development and integration tests.
+type FileMailSender struct {
+	dir         string
+	defaultFrom string
+	now         func() time.Time
+}
+
+// NewFileMailSender constructs a file mail sink rooted at dir.
+// A blank dir falls back to DefaultMailSinkDir.
+func NewFileMailSender(dir string, defaultFrom string) (*FileMailSender, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		dir = DefaultMailSinkDir()
+	}
+	if strings.TrimSpace(defaultFrom) == "" {
+		defaultFrom = "response@mg.supost.com"
+	}
+	return &FileMailSender{
+		dir:         dir,
+		defaultFrom: strings.TrimSpace(defaultFrom),
+		now:         time.Now,
+	}, nil
+}
+
+// DefaultMailSinkDir is the per-user directory used when mail_file_dir is unset.
+func DefaultMailSinkDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "supost-cli", "mail")
+}
+
+// Dir returns the directory captured messages are written to.
+func (f *FileMailSender) Dir() string {
+	return f.dir
+}
+
+// SendPublishEmail writes one publish message to the sink.
+func (f *FileMailSender) SendPublishEmail(_ context.Context, msg domain.PublishEmailMessage) error {
+	_, err := f.write(msg.From, msg.To, "", msg.Subject, msg.Text, msg.HTML)
+	return err
+}
+
+// SendResponseEmail writes one response message (with Reply-To) to the sink
+// and returns its Message-ID.
+func (f *FileMailSender) SendResponseEmail(_ context.Context, msg domain.ResponseEmailMessage) (string, error) {
+	email, err := f.write(msg.From, msg.To, msg.ReplyTo, msg.Subject, msg.Text, msg.HTML)
+	if err != nil {
+		return "", err
+	}
+	return normalizeProviderMessageID(email.MessageID), nil
+}
+
+func (f *FileMailSender) write(fromRaw, toRaw, replyToRaw, subjectRaw, textRaw, htmlRaw string) (outgoingEmail, error) {
+	from := strings.TrimSpace(fromRaw)
+	if from == "" {
+		from = f.defaultFrom
+	}
+	now := f.now()
+	email := outgoingEmail{
+		From:      from,
+		To:        strings.TrimSpace(toRaw),
+		ReplyTo:   strings.TrimSpace(replyToRaw),
+		Subject:   strings.TrimSpace(subjectRaw),
+		Text:      strings.TrimSpace(textRaw),
+		HTML:      strings.TrimSpace(htmlRaw),
+		Date:      now,
+		MessageID: newMessageID(from),
+	}
+	if err := email.validate(); err != nil {
+		return outgoingEmail{}, fmt.Errorf("file mail message: %w", err)
+	}
+	raw, err := email.bytes()
+	if err != nil {
+		return outgoingEmail{}, err
+	}
+
+	if err := os.MkdirAll(f.dir, 0o755); err != nil {
+		return outgoingEmail{}, fmt.Errorf("creating mail sink directory: %w", err)
+	}
+	path := filepath.Join(f.dir, newCapturedEmailID(now)+capturedEmailExt)
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		return outgoingEmail{}, fmt.Errorf("writing captured email: %w", err)
+	}
+	return email, nil
+}
+
+// List returns captured messages, newest first.
+func (f *FileMailSender) List() ([]domain.CapturedEmail, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []domain.CapturedEmail{}, nil
+		}
+		return nil, fmt.Errorf("reading mail sink directory: %w", err)
+	}
+
+	emails := make([]domain.CapturedEmail, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != capturedEmailExt {
+			continue
+		}
+		id := strings.TrimSuffix(entry.Name(), capturedEmailExt)
+		email, err := f.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, email)
+	}
+
+	sort.Slice(emails, func(i, j int) bool {
+		return emails[i].ID > emails[j].ID
+	})
+	return emails, nil
+}
+
+// Get returns one captured message by id (file name without .eml).
+func (f *FileMailSender) Get(id string) (domain.CapturedEmail, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return domain.CapturedEmail{}, fmt.Errorf("invalid captured email id %q", id)
+	}
+
+	path := filepath.Join(f.dir, id+capturedEmailExt)
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return domain.CapturedEmail{}, domain.ErrNotFound
+		}
+		return domain.CapturedEmail{}, fmt.Errorf("opening captured email: %w", err)
+	}
+	defer file.Close()
+
+	email, err := parseCapturedEmail(file)
+	if err != nil {
+		return domain.CapturedEmail{}, fmt.Errorf("parsing captured email %s: %w", id, err)
+	}
+	email.ID = id
+	email.Path = path
+	return email, nil
+}
+
+func parseCapturedEmail(r io.Reader) (domain.CapturedEmail, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return domain.CapturedEmail{}, err
+	}
+
+	decoder := new(mime.WordDecoder)
+	header := func(name string) string {
+		value := msg.Header.Get(name)
+		if decoded, err := decoder.DecodeHeader(value); err == nil {
+			return strings.TrimSpace(decoded)
+		}
+		return strings.TrimSpace(value)
+	}
+
+	text, html, err := readCapturedEmailBody(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return domain.CapturedEmail{}, err
+	}
+
+	date, _ := msg.Header.Date()
+	return domain.CapturedEmail{
+		MessageID: header("Message-ID"),
+		From:      header("From"),
+		To:        header("To"),
+		ReplyTo:   header("Reply-To"),
+		Subject:   header("Subject"),
+		Date:      date,
+		Text:      text,
+		HTML:      html,
+	}, nil
+}
+
+// readCapturedEmailBody returns the text and HTML bodies of a single-part or
+// multipart/alternative message.
+func readCapturedEmailBody(contentType, transferEncoding string, body io.Reader) (string, string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		decoded, err := readCapturedEmailPart(transferEncoding, body)
+		if err != nil {
+			return "", "", err
+		}
+		if mediaType == "text/html" {
+			return "", decoded, nil
+		}
+		return decoded, "", nil
+	}
+
+	var text, html string
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("reading multipart body: %w", err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		// multipart.Reader already decodes quoted-printable parts.
+		decoded, err := readCapturedEmailPart("", part)
+		if err != nil {
+			return "", "", err
+		}
+		switch partType {
+		case "text/plain":
+			text = decoded
+		case "text/html":
+			html = decoded
+		}
+	}
+	return text, html, nil
+}
+
+func readCapturedEmailPart(transferEncoding string, body io.Reader) (string, error) {
+	if strings.EqualFold(transferEncoding, "quoted-printable") {
+		body = quotedprintable.NewReader(body)
+	}
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("reading body: %w", err)
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(raw), "\r\n", "\n")), nil
+}
+
+// newCapturedEmailID returns a lexically sortable, filename-safe id.
+func newCapturedEmailID(now time.Time) string {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return now.UTC().Format("20060102-150405.000000000")
+	}
+	return now.UTC().Format("20060102-150405.000000") + "-" + hex.EncodeToString(suffix)
+}
@@ -0,0 +1,159 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestFileMailSender_SendResponseEmail_WritesEMLWithReplyToAndDate(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := NewFileMailSender(dir, "response@mg.supost.com")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	sent := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	sender.now = func() time.Time { return sent }
+
+	_, err = sender.SendResponseEmail(context.Background(), domain.ResponseEmailMessage{
+		To:      "owner@stanford.edu",
+		ReplyTo: "buyer@gmail.com",
+		Subject: "SUpost - Response: Café table",
+		Text:    "Is this still available?\nThanks!",
+	})
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v (err=%v)", files, err)
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("reading eml: %v", err)
+	}
+	for _, needle := range []string{
+		"From: response@mg.supost.com\r\n",
+		"To: owner@stanford.edu\r\n",
+		"Reply-To: buyer@gmail.com\r\n",
+		"Date: Sun, 01 Mar 2026 12:30:00 +0000\r\n",
+	} {
+		if !strings.Contains(string(raw), needle) {
+			t.Fatalf("expected eml to contain %q; got %q", needle, raw)
+		}
+	}
+
+	emails, err := sender.List()
+	if err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	if len(emails) != 1 {
+		t.Fatalf("expected one captured email, got %d", len(emails))
+	}
+	got, err := sender.Get(emails[0].ID)
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if got.ReplyTo != "buyer@gmail.com" || got.Subject != "SUpost - Response: Café table" {
+		t.Fatalf("unexpected decoded headers: %+v", got)
+	}
+	if !got.Date.Equal(sent) {
+		t.Fatalf("expected date %v, got %v", sent, got.Date)
+	}
+	if got.Text != "Is this still available?\nThanks!" {
+		t.Fatalf("unexpected decoded body %q", got.Text)
+	}
+}
+
+func TestFileMailSender_SendPublishEmail_MultipartRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := NewFileMailSender(dir, "")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	err = sender.SendPublishEmail(context.Background(), domain.PublishEmailMessage{
+		To:      "owner@stanford.edu",
+		Subject: "SUpost - Publish your post! Desk",
+		Text:    "Publish your post by pressing:\n\nhttps://supost.com/post/publish/abc",
+		HTML:    `<p>Publish your post by pressing:</p><p><a href="https://supost.com/post/publish/abc">Publish</a></p>`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	emails, err := sender.List()
+	if err != nil || len(emails) != 1 {
+		t.Fatalf("expected one captured email, got %d (err=%v)", len(emails), err)
+	}
+	raw, err := os.ReadFile(emails[0].Path)
+	if err != nil {
+		t.Fatalf("reading eml: %v", err)
+	}
+	if !strings.Contains(string(raw), "Content-Type: multipart/alternative; boundary=") {
+		t.Fatalf("expected multipart/alternative message; got %q", raw)
+	}
+	if strings.Index(string(raw), "text/plain") > strings.Index(string(raw), "text/html") {
+		t.Fatalf("expected text/plain part before text/html part")
+	}
+	if emails[0].Text != "Publish your post by pressing:\n\nhttps://supost.com/post/publish/abc" {
+		t.Fatalf("unexpected decoded text %q", emails[0].Text)
+	}
+	if !strings.Contains(emails[0].HTML, `<a href="https://supost.com/post/publish/abc">`) {
+		t.Fatalf("unexpected decoded html %q", emails[0].HTML)
+	}
+}
+
+func TestFileMailSender_List_NewestFirst(t *testing.T) {
+	sender, err := NewFileMailSender(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, subject := range []string{"first", "second", "third"} {
+		sender.now = func() time.Time { return base.Add(time.Duration(i) * time.Minute) }
+		if err := sender.SendPublishEmail(context.Background(), domain.PublishEmailMessage{
+			To:      "owner@stanford.edu",
+			Subject: subject,
+			Text:    "body",
+		}); err != nil {
+			t.Fatalf("unexpected send error: %v", err)
+		}
+	}
+
+	emails, err := sender.List()
+	if err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	var subjects []string
+	for _, email := range emails {
+		subjects = append(subjects, email.Subject)
+	}
+	if strings.Join(subjects, ",") != "third,second,first" {
+		t.Fatalf("expected newest-first order, got %v", subjects)
+	}
+}
+
+func TestFileMailSender_Get_MissingAndInvalidIDs(t *testing.T) {
+	sender, err := NewFileMailSender(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	if _, err := sender.Get("20260301-120000.000000-deadbeef"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := sender.Get("../secrets"); err == nil || errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected invalid id error, got %v", err)
+	}
+
+	emails, err := sender.List()
+	if err != nil || len(emails) != 0 {
+		t.Fatalf("expected empty list for missing dir, got %v (err=%v)", emails, err)
+	}
+}
@@ -37,8 +37,9 @@ type styledWord struct {
 	color string
 }
 
-// RenderHomePosts renders the terminal homepage list.
-func RenderHomePosts(w io.Writer, posts []domain.Post, featuredPosts []domain.Post, sections []domain.HomeCategorySection) error {
+// RenderHomePosts renders the terminal homepage list. Ticker image URLs are
+// built from photoBaseURL (blank = DefaultPhotoBaseURL).
+func RenderHomePosts(w io.Writer, posts []domain.Post, featuredPosts []domain.Post, sections []domain.HomeCategorySection, photoBaseURL string) error {
 	now := time.Now()
 
 	if err := RenderPageHeader(w, PageHeaderOptions{
@@ -49,7 +50,7 @@ func RenderHomePosts(w io.Writer, posts []domain.Post, featuredPosts []domain.Po
 	}); err != nil {
 		return err
 	}
-	if err := renderHomePhotoStrip(w, posts, now, homePageWidth); err != nil {
+	if err := renderHomePhotoStrip(w, posts, now, homePageWidth, photoBaseURL); err != nil {
 		return err
 	}
 
@@ -77,7 +78,7 @@ func renderHomeHeader(text string, width int) string {
 	return strings.Repeat(" ", left) + text + strings.Repeat(" ", right)
 }
 
-func renderHomePhotoStrip(w io.Writer, posts []domain.Post, now time.Time, width int) error {
+func renderHomePhotoStrip(w io.Writer, posts []domain.Post, now time.Time, width int, photoBaseURL string) error {
 	photos := selectRecentImagePosts(posts, homePhotoColumns)
 	if len(photos) == 0 {
 		return nil
@@ -91,7 +92,7 @@ func renderHomePhotoStrip(w io.Writer, posts []domain.Post, now time.Time, width
 	timeAgo := make([]string, 0, len(photos))
 
 	for _, post := range photos {
-		imageURLs = append(imageURLs, formatTickerImageURL(post, now))
+		imageURLs = append(imageURLs, formatTickerImageURL(photoBaseURL, post, now))
 		titles = append(titles, strings.TrimSpace(post.Name))
 		timeAgo = append(timeAgo, formatRelativeTime(postTimestamp(post), now))
 	}
@@ -328,7 +329,7 @@ func selectRecentImagePosts(posts []domain.Post, limit int) []domain.Post {
 	return selected
 }
 
-func formatTickerImageURL(post domain.Post, now time.Time) string {
+func formatTickerImageURL(photoBaseURL string, post domain.Post, now time.Time) string {
 	timestamp := post.TimePosted
 	if timestamp <= 0 {
 		if postedAt := postTimestamp(post); !postedAt.IsZero() {
@@ -338,7 +339,7 @@ func formatTickerImageURL(post domain.Post, now time.Time) string {
 	if timestamp <= 0 {
 		timestamp = now.Unix()
 	}
-	return fmt.Sprintf("https://supost-prod.s3.amazonaws.com/posts/%d/ticker_%da?%d", post.ID, post.ID, timestamp)
+	return fmt.Sprintf("%s/posts/%d/ticker_%da?%d", resolvePhotoBaseURL(photoBaseURL), post.ID, post.ID, timestamp)
 }
 
 func photoColumnWidth(totalWidth, columns, gap int) int {
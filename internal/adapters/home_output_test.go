@@ -86,13 +86,22 @@ func TestFormatTickerImageURL_UsesPattern(t *testing.T) {
 		TimePosted: 1772222959,
 		HasImage:   true,
 	}
-	got := formatTickerImageURL(post, now)
+	got := formatTickerImageURL("", post, now)
 	want := "https://supost-prod.s3.amazonaws.com/posts/130031934/ticker_130031934a?1772222959"
 	if got != want {
 		t.Fatalf("got %q, want %q", got, want)
 	}
 }
 
+func TestFormatTickerImageURL_UsesConfiguredBase(t *testing.T) {
+	post := domain.Post{ID: 130031934, TimePosted: 1772222959}
+	got := formatTickerImageURL("http://localhost:9000/supost-dev/", post, time.Now())
+	want := "http://localhost:9000/supost-dev/posts/130031934/ticker_130031934a?1772222959"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
 func TestRenderColumnRow_RespectsTotalWidth(t *testing.T) {
 	line := renderColumnRow([]string{"one", "two", "three", "four"}, 10, "", 4)
 	if got := len([]rune(line)); got != 46 {
@@ -0,0 +1,226 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are the ancillary PNG chunks that can carry EXIF, XMP
+// (iTXt), ICC profiles, free text, or capture timestamps.
+var pngMetadataChunks = map[string]struct{}{
+	"eXIf": {},
+	"iCCP": {},
+	"iTXt": {},
+	"tEXt": {},
+	"zTXt": {},
+	"tIME": {},
+}
+
+// applyPhotoMetadataPolicy runs on every encoded variant. By default it strips
+// all EXIF/XMP/ICC/comment metadata; with keep it copies the source's metadata
+// into the variant instead, resetting EXIF orientation because the pixels have
+// already been rotated upright.
+func applyPhotoMetadataPolicy(encoded []byte, contentType string, source []byte, keep bool) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(contentType)) {
+	case "image/jpeg":
+		stripped, err := stripJPEGMetadata(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if !keep {
+			return stripped, nil
+		}
+		return insertJPEGSegments(stripped, jpegMetadataSegments(source)), nil
+	case "image/png":
+		stripped, err := stripPNGMetadata(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if !keep {
+			return stripped, nil
+		}
+		return insertPNGChunks(stripped, pngMetadataChunkBytes(source)), nil
+	default:
+		// The GIF encoder writes no comment or application extensions.
+		return encoded, nil
+	}
+}
+
+// jpegSegment is one marker segment ahead of the scan data; raw includes the
+// 0xFF marker bytes and length.
+type jpegSegment struct {
+	marker byte
+	raw    []byte
+}
+
+// splitJPEG returns the marker segments between SOI and SOS and the remainder
+// starting at SOS.
+func splitJPEG(data []byte) ([]jpegSegment, []byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, nil, fmt.Errorf("jpeg: missing start-of-image marker")
+	}
+	segments := make([]jpegSegment, 0, 8)
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return nil, nil, fmt.Errorf("jpeg: invalid marker at offset %d", offset)
+		}
+		marker := data[offset+1]
+		if marker == 0xFF {
+			offset++
+			continue
+		}
+		if marker == 0xDA {
+			return segments, data[offset:], nil
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		if length < 2 || offset+2+length > len(data) {
+			return nil, nil, fmt.Errorf("jpeg: truncated segment at offset %d", offset)
+		}
+		segments = append(segments, jpegSegment{marker: marker, raw: data[offset : offset+2+length]})
+		offset += 2 + length
+	}
+	return nil, nil, fmt.Errorf("jpeg: missing start-of-scan marker")
+}
+
+// isJPEGMetadataMarker reports APP1-APP15 (Exif, XMP, ICC, IPTC, vendor data)
+// and COM. APP0 (JFIF) only describes pixel density and is kept.
+func isJPEGMetadataMarker(marker byte) bool {
+	return (marker >= 0xE1 && marker <= 0xEF) || marker == 0xFE
+}
+
+func stripJPEGMetadata(data []byte) ([]byte, error) {
+	segments, scan, err := splitJPEG(data)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	for _, segment := range segments {
+		if !isJPEGMetadataMarker(segment.marker) {
+			out = append(out, segment.raw...)
+		}
+	}
+	return append(out, scan...), nil
+}
+
+// jpegMetadataSegments copies the source's metadata segments with EXIF
+// orientation reset to 1. Unreadable sources yield no segments.
+func jpegMetadataSegments(data []byte) [][]byte {
+	segments, _, err := splitJPEG(data)
+	if err != nil {
+		return nil
+	}
+	kept := make([][]byte, 0, len(segments))
+	for _, segment := range segments {
+		if !isJPEGMetadataMarker(segment.marker) {
+			continue
+		}
+		raw := append([]byte(nil), segment.raw...)
+		if segment.marker == 0xE1 && bytes.HasPrefix(raw[4:], []byte("Exif\x00\x00")) {
+			tiff := raw[10:]
+			if order, offset, ok := exifOrientationEntry(tiff); ok {
+				order.PutUint16(tiff[offset:offset+2], 1)
+			}
+		}
+		kept = append(kept, raw)
+	}
+	return kept
+}
+
+// insertJPEGSegments places segments right after SOI.
+func insertJPEGSegments(data []byte, segments [][]byte) []byte {
+	if len(segments) == 0 {
+		return data
+	}
+	out := append([]byte(nil), data[:2]...)
+	for _, segment := range segments {
+		out = append(out, segment...)
+	}
+	return append(out, data[2:]...)
+}
+
+// pngChunk is one length/type/data/CRC chunk; raw covers all four fields.
+type pngChunk struct {
+	kind string
+	raw  []byte
+}
+
+func splitPNG(data []byte) ([]pngChunk, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("png: missing signature")
+	}
+	chunks := make([]pngChunk, 0, 8)
+	offset := len(pngSignature)
+	for offset < len(data) {
+		if offset+12 > len(data) {
+			return nil, fmt.Errorf("png: truncated chunk at offset %d", offset)
+		}
+		length := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+		end := offset + 12 + length
+		if end > len(data) {
+			return nil, fmt.Errorf("png: truncated chunk at offset %d", offset)
+		}
+		chunks = append(chunks, pngChunk{kind: string(data[offset+4 : offset+8]), raw: data[offset:end]})
+		offset = end
+	}
+	return chunks, nil
+}
+
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	chunks, err := splitPNG(data)
+	if err != nil {
+		return nil, err
+	}
+	out := append(make([]byte, 0, len(data)), pngSignature...)
+	for _, chunk := range chunks {
+		if _, ok := pngMetadataChunks[chunk.kind]; !ok {
+			out = append(out, chunk.raw...)
+		}
+	}
+	return out, nil
+}
+
+// pngMetadataChunkBytes copies the source's metadata chunks. An eXIf chunk has
+// its orientation reset to 1 and its CRC recomputed.
+func pngMetadataChunkBytes(data []byte) [][]byte {
+	chunks, err := splitPNG(data)
+	if err != nil {
+		return nil
+	}
+	kept := make([][]byte, 0, len(chunks))
+	for _, chunk := range chunks {
+		if _, ok := pngMetadataChunks[chunk.kind]; !ok {
+			continue
+		}
+		raw := append([]byte(nil), chunk.raw...)
+		if chunk.kind == "eXIf" {
+			tiff := raw[8 : len(raw)-4]
+			if order, offset, ok := exifOrientationEntry(tiff); ok {
+				order.PutUint16(tiff[offset:offset+2], 1)
+				binary.BigEndian.PutUint32(raw[len(raw)-4:], crc32.ChecksumIEEE(raw[4:len(raw)-4]))
+			}
+		}
+		kept = append(kept, raw)
+	}
+	return kept
+}
+
+// insertPNGChunks places chunks right after IHDR, ahead of PLTE/IDAT as iCCP
+// requires.
+func insertPNGChunks(data []byte, chunks [][]byte) []byte {
+	if len(chunks) == 0 {
+		return data
+	}
+	ihdrEnd := len(pngSignature) + 12 + int(binary.BigEndian.Uint32(data[len(pngSignature):len(pngSignature)+4]))
+	out := append([]byte(nil), data[:ihdrEnd]...)
+	for _, chunk := range chunks {
+		out = append(out, chunk...)
+	}
+	return append(out, data[ihdrEnd:]...)
+}
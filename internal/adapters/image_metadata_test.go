@@ -0,0 +1,234 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// gpsSentinel is the coordinate pair planted in every fixture's EXIF GPS IFD,
+// XMP packet, ICC profile, and comment.
+const gpsSentinel = "37.4275,-122.1697"
+
+// gpsEXIFTIFF builds a little-endian TIFF block whose IFD0 holds an
+// orientation and a GPSInfo pointer to a GPS IFD with latitude/longitude refs
+// and a GPSAreaInformation string.
+func gpsEXIFTIFF(orientation uint16) []byte {
+	const (
+		ifd0Offset = 8
+		ifd0Size   = 2 + 2*12 + 4
+		gpsOffset  = ifd0Offset + ifd0Size
+		gpsSize    = 2 + 3*12 + 4
+		areaOffset = gpsOffset + gpsSize
+	)
+	order := binary.LittleEndian
+	var buf bytes.Buffer
+	entry := func(tag, kind uint16, count, value uint32) {
+		_ = binary.Write(&buf, order, tag)
+		_ = binary.Write(&buf, order, kind)
+		_ = binary.Write(&buf, order, count)
+		_ = binary.Write(&buf, order, value)
+	}
+
+	buf.WriteString("II")
+	_ = binary.Write(&buf, order, uint16(42))
+	_ = binary.Write(&buf, order, uint32(ifd0Offset))
+
+	_ = binary.Write(&buf, order, uint16(2))
+	entry(exifOrientationTag, 3, 1, uint32(orientation))
+	entry(0x8825, 4, 1, gpsOffset) // GPSInfo IFD pointer
+	_ = binary.Write(&buf, order, uint32(0))
+
+	_ = binary.Write(&buf, order, uint16(3))
+	entry(0x0001, 2, 2, uint32('N'))                         // GPSLatitudeRef
+	entry(0x0003, 2, 2, uint32('W'))                         // GPSLongitudeRef
+	entry(0x001C, 7, uint32(len(gpsSentinel)+8), areaOffset) // GPSAreaInformation
+	_ = binary.Write(&buf, order, uint32(0))
+
+	buf.WriteString("ASCII\x00\x00\x00" + gpsSentinel)
+	return buf.Bytes()
+}
+
+func jpegAPPSegment(marker byte, payload []byte) []byte {
+	segment := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// gpsFixtureJPEG returns a JPEG carrying Exif (with GPS), XMP, ICC, and COM
+// segments, all mentioning gpsSentinel.
+func gpsFixtureJPEG(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+	plain := testJPEG(t, width, height)
+	segments := [][]byte{
+		jpegAPPSegment(0xE1, append([]byte("Exif\x00\x00"), gpsEXIFTIFF(orientation)...)),
+		jpegAPPSegment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta><exif:GPSLatitude>"+gpsSentinel+"</exif:GPSLatitude></x:xmpmeta>")),
+		jpegAPPSegment(0xE2, []byte("ICC_PROFILE\x00\x01\x01desc "+gpsSentinel)),
+		jpegAPPSegment(0xFE, []byte("taken at "+gpsSentinel)),
+	}
+	return insertJPEGSegments(plain, segments)
+}
+
+func pngChunkBytes(kind string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk[:4], uint32(len(data)))
+	copy(chunk[4:8], kind)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// gpsFixturePNG returns a PNG carrying eXIf (with GPS), XMP iTXt, and tEXt
+// chunks.
+func gpsFixturePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	return insertPNGChunks(testPNG(t, width, height), [][]byte{
+		pngChunkBytes("eXIf", gpsEXIFTIFF(1)),
+		pngChunkBytes("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<exif:GPSLatitude>"+gpsSentinel+"</exif:GPSLatitude>")),
+		pngChunkBytes("tEXt", []byte("Comment\x00"+gpsSentinel)),
+	})
+}
+
+func assertNoPhotoMetadata(t *testing.T, name string, data []byte) {
+	t.Helper()
+	for _, needle := range []string{gpsSentinel, "Exif\x00\x00", "http://ns.adobe.com/xap/1.0/", "ICC_PROFILE", "eXIf", "iTXt", "tEXt"} {
+		if bytes.Contains(data, []byte(needle)) {
+			t.Fatalf("%s still contains %q", name, needle)
+		}
+	}
+	if _, _, err := image.Decode(bytes.NewReader(data)); err != nil {
+		t.Fatalf("%s no longer decodes: %v", name, err)
+	}
+}
+
+func TestGPSFixtures_CarryMetadata(t *testing.T) {
+	for name, fixture := range map[string][]byte{
+		"jpeg": gpsFixtureJPEG(t, 16, 8, 1),
+		"png":  gpsFixturePNG(t, 16, 8),
+	} {
+		if !bytes.Contains(fixture, []byte(gpsSentinel)) {
+			t.Fatalf("%s fixture is missing the gps sentinel", name)
+		}
+		if _, _, err := image.Decode(bytes.NewReader(fixture)); err != nil {
+			t.Fatalf("%s fixture does not decode: %v", name, err)
+		}
+	}
+	if got := jpegEXIFOrientation(gpsFixtureJPEG(t, 16, 8, 6)); got != 6 {
+		t.Fatalf("expected fixture orientation 6, got %d", got)
+	}
+}
+
+func TestS3PostPhotoUploader_StripsGPSMetadataFromBothVariants(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies = map[string][]byte{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies[r.URL.Path] = body
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	uploader, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "v2/posts", "", server.URL, true, "minio-key", "minio-secret")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	for name, fixture := range map[string][]byte{
+		"dorm.jpg": gpsFixtureJPEG(t, 800, 600, 6),
+		"dorm.png": gpsFixturePNG(t, 800, 600),
+	} {
+		saved, err := uploader.UploadPostPhoto(context.Background(), 42, domain.PostCreatePhotoUpload{FileName: name, Content: fixture})
+		if err != nil {
+			t.Fatalf("unexpected upload error for %s: %v", name, err)
+		}
+		for _, key := range []string{saved.S3Key, saved.TickerS3Key} {
+			body, ok := bodies["/supost-dev/"+key]
+			if !ok {
+				t.Fatalf("expected upload of %s", key)
+			}
+			assertNoPhotoMetadata(t, key, body)
+		}
+	}
+}
+
+func TestLocalPostPhotoUploader_StripsGPSMetadata(t *testing.T) {
+	root := t.TempDir()
+	uploader, err := NewLocalPostPhotoUploader(root, "")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	saved, err := uploader.UploadPostPhoto(context.Background(), 7, domain.PostCreatePhotoUpload{
+		FileName: "dorm.jpg",
+		Content:  gpsFixtureJPEG(t, 400, 300, 1),
+	})
+	if err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+	for _, key := range []string{saved.S3Key, saved.TickerS3Key, "posts/7/ticker_7a"} {
+		raw, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(key)))
+		if err != nil {
+			t.Fatalf("reading %s: %v", key, err)
+		}
+		assertNoPhotoMetadata(t, key, raw)
+	}
+}
+
+func TestEncodePostPhotoVariants_KeepMetadataCopiesSourceWithUprightOrientation(t *testing.T) {
+	variants, err := encodePostPhotoVariants(domain.PostCreatePhotoUpload{
+		FileName: "dorm.jpg",
+		Content:  gpsFixtureJPEG(t, 600, 400, 6),
+	}, true)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	for name, data := range map[string][]byte{"post": variants.post, "ticker": variants.ticker} {
+		for _, needle := range []string{gpsSentinel, "http://ns.adobe.com/xap/1.0/", "ICC_PROFILE"} {
+			if !bytes.Contains(data, []byte(needle)) {
+				t.Fatalf("expected %s variant to keep %q", name, needle)
+			}
+		}
+		if got := jpegEXIFOrientation(data); got != 1 {
+			t.Fatalf("expected %s variant orientation reset to 1, got %d", name, got)
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("decoding %s variant: %v", name, err)
+		}
+		if cfg.Height <= cfg.Width {
+			t.Fatalf("expected %s variant rotated to portrait, got %dx%d", name, cfg.Width, cfg.Height)
+		}
+	}
+
+	pngVariants, err := encodePostPhotoVariants(domain.PostCreatePhotoUpload{FileName: "dorm.png", Content: gpsFixturePNG(t, 400, 200)}, true)
+	if err != nil {
+		t.Fatalf("unexpected png encode error: %v", err)
+	}
+	if !bytes.Contains(pngVariants.post, []byte(gpsSentinel)) {
+		t.Fatalf("expected png variant to keep metadata chunks")
+	}
+	if _, _, err := image.Decode(bytes.NewReader(pngVariants.post)); err != nil {
+		t.Fatalf("png variant with kept metadata does not decode: %v", err)
+	}
+}
+
+func TestStripJPEGMetadata_RejectsTruncatedInput(t *testing.T) {
+	if _, err := stripJPEGMetadata([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x40}); err == nil {
+		t.Fatalf("expected truncated segment error")
+	}
+	if _, err := stripPNGMetadata([]byte("not a png")); err == nil {
+		t.Fatalf("expected png signature error")
+	}
+}
@@ -0,0 +1,139 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const exifOrientationTag = 0x0112
+
+// jpegEXIFOrientation returns the EXIF Orientation (1-8) stored in a JPEG's
+// APP1 segment, or 1 when absent or unreadable.
+func jpegEXIFOrientation(data []byte) int {
+	tiff := jpegEXIFPayload(data)
+	if tiff == nil {
+		return 1
+	}
+	return exifOrientation(tiff)
+}
+
+// jpegEXIFPayload returns the TIFF structure inside the first Exif APP1
+// segment, scanning markers up to start-of-scan.
+func jpegEXIFPayload(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return nil
+		}
+		marker := data[offset+1]
+		if marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01 || marker == 0xFF {
+			offset++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		if length < 2 || offset+2+length > len(data) {
+			return nil
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		offset += 2 + length
+	}
+	return nil
+}
+
+func exifOrientation(tiff []byte) int {
+	order, offset, ok := exifOrientationEntry(tiff)
+	if !ok {
+		return 1
+	}
+	value := int(order.Uint16(tiff[offset : offset+2]))
+	if value < 1 || value > 8 {
+		return 1
+	}
+	return value
+}
+
+// exifOrientationEntry locates the Orientation value in IFD0 and returns the
+// block's byte order and the value's offset within tiff.
+func exifOrientationEntry(tiff []byte) (binary.ByteOrder, int, bool) {
+	if len(tiff) < 8 {
+		return nil, 0, false
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, 0, false
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return nil, 0, false
+	}
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return nil, 0, false
+	}
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return nil, 0, false
+		}
+		if order.Uint16(tiff[entry:entry+2]) == exifOrientationTag {
+			return order, entry + 8, true
+		}
+	}
+	return nil, 0, false
+}
+
+// applyEXIFOrientation returns src transformed so it displays upright for the
+// given EXIF orientation. Orientations 5-8 swap width and height.
+func applyEXIFOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	rgba := toRGBA(src)
+	w, h := rgba.Rect.Dx(), rgba.Rect.Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirror horizontal
+				sx, sy = w-1-x, y
+			case 3: // rotate 180
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirror vertical
+				sx, sy = x, h-1-y
+			case 5: // transpose
+				sx, sy = y, x
+			case 6: // rotate 90 clockwise
+				sx, sy = y, h-1-x
+			case 7: // transverse
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotate 90 counter-clockwise
+				sx, sy = w-1-y, x
+			}
+			si := sy*rgba.Stride + sx*4
+			di := y*dst.Stride + x*4
+			copy(dst.Pix[di:di+4], rgba.Pix[si:si+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,117 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// exifTIFF builds a minimal little-endian TIFF block holding IFD0 entries.
+func exifTIFF(entries [][3]uint32) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("II")
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(42))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(8))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(len(entries)))
+	for _, entry := range entries {
+		_ = binary.Write(&buf, binary.LittleEndian, uint16(entry[0])) // tag
+		_ = binary.Write(&buf, binary.LittleEndian, uint16(3))        // SHORT
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(1))
+		_ = binary.Write(&buf, binary.LittleEndian, uint32(entry[2]))
+	}
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(0))
+	return buf.Bytes()
+}
+
+// withAPP1 inserts an Exif APP1 segment right after the JPEG SOI marker.
+func withAPP1(jpegBytes []byte, tiff []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	out := append([]byte{}, jpegBytes[:2]...)
+	out = append(out, segment...)
+	out = append(out, payload...)
+	return append(out, jpegBytes[2:]...)
+}
+
+func testJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 4), G: uint8(y * 4), B: 64, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("encoding test jpeg: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestJPEGEXIFOrientation(t *testing.T) {
+	plain := testJPEG(t, 8, 4)
+	if got := jpegEXIFOrientation(plain); got != 1 {
+		t.Fatalf("expected 1 without exif, got %d", got)
+	}
+	tagged := withAPP1(plain, exifTIFF([][3]uint32{{0x010F, 3, 0}, {exifOrientationTag, 3, 6}}))
+	if got := jpegEXIFOrientation(tagged); got != 6 {
+		t.Fatalf("expected orientation 6, got %d", got)
+	}
+	if got := jpegEXIFOrientation([]byte("not a jpeg")); got != 1 {
+		t.Fatalf("expected 1 for non-jpeg, got %d", got)
+	}
+}
+
+func TestApplyEXIFOrientation_RotatesAndMirrors(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	cases := map[int][]color.RGBA{
+		2: {blue, red}, // 2x1, mirrored
+		3: {blue, red}, // 2x1, rotated 180
+		6: {red, blue}, // 1x2, red on top
+		8: {blue, red}, // 1x2, blue on top
+	}
+	for orientation, want := range cases {
+		out := applyEXIFOrientation(src, orientation).(*image.RGBA)
+		var got []color.RGBA
+		bounds := out.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				got = append(got, out.RGBAAt(x, y))
+			}
+		}
+		if orientation >= 5 && (bounds.Dx() != 1 || bounds.Dy() != 2) {
+			t.Fatalf("orientation %d: expected 1x2, got %v", orientation, bounds)
+		}
+		if got[0] != want[0] || got[1] != want[1] {
+			t.Fatalf("orientation %d: got %v, want %v", orientation, got, want)
+		}
+	}
+}
+
+func TestEncodePostPhotoVariants_AppliesEXIFOrientation(t *testing.T) {
+	landscape := testJPEG(t, 60, 30)
+	portrait := withAPP1(landscape, exifTIFF([][3]uint32{{exifOrientationTag, 3, 6}}))
+
+	variants, err := encodePostPhotoVariants(domain.PostCreatePhotoUpload{FileName: "phone.jpg", Content: portrait}, false)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(variants.post))
+	if err != nil {
+		t.Fatalf("decoding post variant: %v", err)
+	}
+	if cfg.Width != 30 || cfg.Height != 60 {
+		t.Fatalf("expected upright 30x60 post image, got %dx%d", cfg.Width, cfg.Height)
+	}
+}
@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// LocalPostPhotoUploader stores post photos on disk under the same
+// {prefix}/{post_id}/{uuid}.{ext} keys the S3 uploader uses, so rendered
+// image URLs resolve against `supost serve` with no AWS credentials.
+type LocalPostPhotoUploader struct {
+	root         string
+	prefix       string
+	keepMetadata bool
+}
+
+// NewLocalPostPhotoUploader constructs a disk-backed uploader rooted at root.
+// A blank root falls back to DefaultPhotoStorageDir.
+func NewLocalPostPhotoUploader(root string, prefix string) (*LocalPostPhotoUploader, error) {
+	root = strings.TrimSpace(root)
+	if root == "" {
+		root = DefaultPhotoStorageDir()
+	}
+	prefix = strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		prefix = defaultS3PhotoPrefix
+	}
+	return &LocalPostPhotoUploader{root: root, prefix: prefix}, nil
+}
+
+// DefaultPhotoStorageDir is the per-user directory used when photo_local_dir is unset.
+func DefaultPhotoStorageDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "supost-cli", "photos")
+}
+
+// WithKeepMetadata keeps the source's EXIF/XMP/ICC metadata in stored
+// variants instead of stripping it.
+func (u *LocalPostPhotoUploader) WithKeepMetadata(keep bool) *LocalPostPhotoUploader {
+	u.keepMetadata = keep
+	return u
+}
+
+// Root returns the directory photo keys are resolved against.
+func (u *LocalPostPhotoUploader) Root() string {
+	return u.root
+}
+
+// Prefix returns the key prefix new photos are written under.
+func (u *LocalPostPhotoUploader) Prefix() string {
+	return u.prefix
+}
+
+// UploadPostPhoto writes the post and ticker variants and returns their keys.
+// The first photo is also written to the legacy posts/{id}/ticker_{id}a key
+// that the homepage photo strip links to.
+func (u *LocalPostPhotoUploader) UploadPostPhoto(
+	_ context.Context,
+	postID int64,
+	photo domain.PostCreatePhotoUpload,
+) (domain.PostCreateSavedPhoto, error) {
+	if postID <= 0 {
+		return domain.PostCreateSavedPhoto{}, fmt.Errorf("post id must be positive")
+	}
+	if len(photo.Content) == 0 {
+		return domain.PostCreateSavedPhoto{}, fmt.Errorf("photo content is empty")
+	}
+
+	variants, err := encodePostPhotoVariants(photo, u.keepMetadata)
+	if err != nil {
+		return domain.PostCreateSavedPhoto{}, err
+	}
+	key, tickerKey := newPostPhotoKeys(u.prefix, postID, variants.ext)
+
+	if err := u.writeObject(key, variants.post); err != nil {
+		return domain.PostCreateSavedPhoto{}, err
+	}
+	if err := u.writeObject(tickerKey, variants.ticker); err != nil {
+		return domain.PostCreateSavedPhoto{}, err
+	}
+	if photo.Position == 0 {
+		if err := u.writeObject(legacyTickerKey(postID), variants.ticker); err != nil {
+			return domain.PostCreateSavedPhoto{}, err
+		}
+	}
+
+	return domain.PostCreateSavedPhoto{
+		PostID:      postID,
+		S3Key:       key,
+		TickerS3Key: tickerKey,
+		Position:    photo.Position,
+	}, nil
+}
+
+// DeletePostPhoto removes both variants of a stored photo, plus the legacy
+// ticker path for position 0. Missing files are not an error.
+func (u *LocalPostPhotoUploader) DeletePostPhoto(_ context.Context, photo domain.PostCreateSavedPhoto) error {
+	keys := []string{photo.S3Key, photo.TickerS3Key}
+	if photo.Position == 0 && photo.PostID > 0 {
+		keys = append(keys, legacyTickerKey(photo.PostID))
+	}
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if err := u.removeObject(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *LocalPostPhotoUploader) removeObject(key string) error {
+	cleaned := path.Clean("/" + key)
+	target := filepath.Join(u.root, filepath.FromSlash(cleaned))
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing photo %q: %w", key, err)
+	}
+	return nil
+}
+
+func (u *LocalPostPhotoUploader) writeObject(key string, content []byte) error {
+	target := filepath.Join(u.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("creating photo directory for %q: %w", key, err)
+	}
+	if err := os.WriteFile(target, content, 0o644); err != nil {
+		return fmt.Errorf("writing photo %q: %w", key, err)
+	}
+	return nil
+}
+
+// RefreshPostTicker copies a photo's ticker variant to the legacy
+// posts/{id}/ticker_{id}a key after photo becomes the post's first photo.
+func (u *LocalPostPhotoUploader) RefreshPostTicker(_ context.Context, photo domain.PostCreateSavedPhoto) error {
+	key := strings.TrimSpace(photo.TickerS3Key)
+	if photo.PostID <= 0 || key == "" {
+		return fmt.Errorf("ticker photo needs a post id and ticker key")
+	}
+	content, err := os.ReadFile(filepath.Join(u.root, filepath.FromSlash(path.Clean("/"+key))))
+	if err != nil {
+		return fmt.Errorf("reading ticker photo %q: %w", key, err)
+	}
+	return u.writeObject(legacyTickerKey(photo.PostID), content)
+}
+
+// legacyTickerKey matches the path formatTickerImageURL links to.
+func legacyTickerKey(postID int64) string {
+	return fmt.Sprintf("posts/%d/ticker_%da", postID, postID)
+}
+
+// NewLocalPhotoHandler serves stored photo keys as files, e.g.
+// GET /v2/posts/{post_id}/{uuid}.jpg. Query strings (cache busters) are
+// ignored and directory listings are refused.
+func NewLocalPhotoHandler(root string) http.Handler {
+	files := http.FileServer(http.Dir(root))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") || path.Clean(r.URL.Path) == "/" {
+			http.NotFound(w, r)
+			return
+		}
+		if info, err := os.Stat(filepath.Join(root, filepath.FromSlash(path.Clean(r.URL.Path)))); err == nil && info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+}
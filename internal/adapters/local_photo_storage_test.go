@@ -0,0 +1,169 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("encoding test png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestLocalPostPhotoUploader_WritesPostAndTickerVariants(t *testing.T) {
+	root := t.TempDir()
+	uploader, err := NewLocalPostPhotoUploader(root, "")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	saved, err := uploader.UploadPostPhoto(context.Background(), 130031901, domain.PostCreatePhotoUpload{
+		FileName: "desk.png",
+		Content:  testPNG(t, 680, 340),
+		Position: 0,
+	})
+	if err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+
+	keyPattern := regexp.MustCompile(`^v2/posts/130031901/(ticker_)?[0-9a-f-]{36}\.png$`)
+	if !keyPattern.MatchString(saved.S3Key) || !keyPattern.MatchString(saved.TickerS3Key) {
+		t.Fatalf("unexpected keys %q %q", saved.S3Key, saved.TickerS3Key)
+	}
+
+	for key, wantWidth := range map[string]int{
+		saved.S3Key:                         maxPostPhotoWidth,
+		saved.TickerS3Key:                   maxTickerPhotoWidth,
+		"posts/130031901/ticker_130031901a": maxTickerPhotoWidth,
+	} {
+		raw, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(key)))
+		if err != nil {
+			t.Fatalf("expected %s on disk: %v", key, err)
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(raw))
+		if err != nil {
+			t.Fatalf("decoding %s: %v", key, err)
+		}
+		if cfg.Width != wantWidth {
+			t.Fatalf("expected %s width %d, got %d", key, wantWidth, cfg.Width)
+		}
+	}
+}
+
+func TestLocalPhotoHandler_ServesRenderedURLPaths(t *testing.T) {
+	root := t.TempDir()
+	uploader, _ := NewLocalPostPhotoUploader(root, "v2/posts")
+	saved, err := uploader.UploadPostPhoto(context.Background(), 42, domain.PostCreatePhotoUpload{
+		FileName: "bike.png",
+		Content:  testPNG(t, 100, 50),
+	})
+	if err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+
+	post := domain.Post{ID: 42, ImageSource1: saved.S3Key, TimePosted: 1772366400}
+	now := time.Unix(1772366400, 0)
+	handler := NewLocalPhotoHandler(root)
+	for _, rawURL := range []string{
+		formatPostPhotoS3KeyURL("http://localhost:8080", saved.S3Key, post, now),
+		formatTickerImageURL("http://localhost:8080", post, now),
+	} {
+		req := httptest.NewRequest(http.MethodGet, rawURL, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d", rawURL, rec.Code)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+			t.Fatalf("expected image/png for %s, got %q", rawURL, ct)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/posts/42/", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected directory listing to be refused, got %d", rec.Code)
+	}
+}
+
+func TestLocalPostPhotoUploader_DeletePostPhotoRemovesAllVariants(t *testing.T) {
+	root := t.TempDir()
+	uploader, err := NewLocalPostPhotoUploader(root, "")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	saved, err := uploader.UploadPostPhoto(context.Background(), 130031902, domain.PostCreatePhotoUpload{
+		FileName: "desk.png",
+		Content:  testPNG(t, 400, 300),
+	})
+	if err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+	saved.PostID, saved.Position = 130031902, 0
+
+	if err := uploader.DeletePostPhoto(context.Background(), saved); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	for _, key := range []string{saved.S3Key, saved.TickerS3Key, "posts/130031902/ticker_130031902a"} {
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(key))); !os.IsNotExist(err) {
+			t.Fatalf("expected %s removed, stat err %v", key, err)
+		}
+	}
+	if err := uploader.DeletePostPhoto(context.Background(), saved); err != nil {
+		t.Fatalf("expected deleting missing files to succeed, got %v", err)
+	}
+}
+
+func TestLocalPostPhotoUploader_RefreshPostTickerCopiesNewFirstPhoto(t *testing.T) {
+	root := t.TempDir()
+	uploader, err := NewLocalPostPhotoUploader(root, "")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	second, err := uploader.UploadPostPhoto(context.Background(), 130031903, domain.PostCreatePhotoUpload{
+		FileName: "second.png",
+		Content:  testPNG(t, 300, 300),
+		Position: 1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "posts", "130031903", "ticker_130031903a")); !os.IsNotExist(err) {
+		t.Fatalf("expected no legacy ticker for a non-first photo")
+	}
+
+	second.PostID = 130031903
+	if err := uploader.RefreshPostTicker(context.Background(), second); err != nil {
+		t.Fatalf("unexpected refresh error: %v", err)
+	}
+	legacy, err := os.ReadFile(filepath.Join(root, "posts", "130031903", "ticker_130031903a"))
+	if err != nil {
+		t.Fatalf("expected legacy ticker written: %v", err)
+	}
+	ticker, _ := os.ReadFile(filepath.Join(root, filepath.FromSlash(second.TickerS3Key)))
+	if !bytes.Equal(legacy, ticker) {
+		t.Fatalf("expected legacy ticker to match the photo's ticker variant")
+	}
+}
@@ -0,0 +1,99 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderCapturedEmailList renders one line per captured email, newest first.
+func RenderCapturedEmailList(w io.Writer, dir string, emails []domain.CapturedEmail) error {
+	if len(emails) == 0 {
+		_, err := fmt.Fprintf(w, "no captured emails in %s\n", dir)
+		return err
+	}
+	for _, email := range emails {
+		line := fmt.Sprintf("%s  %s  %s  %s",
+			email.ID,
+			formatCapturedEmailDate(email.Date),
+			email.To,
+			email.Subject,
+		)
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderCapturedEmail renders headers and body for one captured email.
+func RenderCapturedEmail(w io.Writer, email domain.CapturedEmail) error {
+	lines := []string{
+		fmt.Sprintf("id: %s", email.ID),
+		fmt.Sprintf("date: %s", formatCapturedEmailDate(email.Date)),
+		fmt.Sprintf("from: %s", email.From),
+		fmt.Sprintf("to: %s", email.To),
+	}
+	if strings.TrimSpace(email.ReplyTo) != "" {
+		lines = append(lines, fmt.Sprintf("reply_to: %s", email.ReplyTo))
+	}
+	lines = append(lines,
+		fmt.Sprintf("subject: %s", email.Subject),
+		fmt.Sprintf("message_id: %s", email.MessageID),
+		fmt.Sprintf("path: %s", email.Path),
+	)
+	if strings.TrimSpace(email.HTML) != "" {
+		lines = append(lines, "parts: text/plain, text/html")
+	}
+	lines = append(lines, "", email.Text)
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidEmailPreviewPart reports whether part is text, html, or both.
+func ValidEmailPreviewPart(part string) bool {
+	switch part {
+	case "text", "html", "both":
+		return true
+	}
+	return false
+}
+
+// RenderEmailPreview renders a templated email; part selects text, html, or both bodies.
+func RenderEmailPreview(w io.Writer, preview domain.EmailPreview, part string) error {
+	lines := []string{
+		fmt.Sprintf("[PREVIEW] %s email", preview.Kind),
+		fmt.Sprintf("post_id: %d", preview.PostID),
+		fmt.Sprintf("to: %s", preview.To),
+	}
+	if strings.TrimSpace(preview.ReplyTo) != "" {
+		lines = append(lines, fmt.Sprintf("reply_to: %s", preview.ReplyTo))
+	}
+	lines = append(lines, fmt.Sprintf("subject: %s", preview.Subject))
+	if part == "text" || part == "both" {
+		lines = append(lines, "", "--- text/plain ---", preview.Text)
+	}
+	if part == "html" || part == "both" {
+		lines = append(lines, "", "--- text/html ---", preview.HTML)
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatCapturedEmailDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -50,22 +51,26 @@ func NewMailgunSender(apiBase, domain, apiKey, defaultFrom string, timeout time.
 	}, nil
 }
 
-// SendPublishEmail sends one plain-text publish message.
+// SendPublishEmail sends one publish message (text, plus HTML when present).
 func (m *MailgunSender) SendPublishEmail(ctx context.Context, msg domain.PublishEmailMessage) error {
-	return m.sendTextEmail(ctx, msg.From, msg.To, "", msg.Subject, msg.Text)
+	_, err := m.sendTextEmail(ctx, msg.From, msg.To, "", msg.Subject, msg.Text, msg.HTML)
+	return err
 }
 
-// SendResponseEmail sends one plain-text response message with Reply-To.
-func (m *MailgunSender) SendResponseEmail(ctx context.Context, msg domain.ResponseEmailMessage) error {
-	return m.sendTextEmail(ctx, msg.From, msg.To, msg.ReplyTo, msg.Subject, msg.Text)
+// SendResponseEmail sends one response message with Reply-To (text, plus HTML
+// when present) and returns the Mailgun message id.
+func (m *MailgunSender) SendResponseEmail(ctx context.Context, msg domain.ResponseEmailMessage) (string, error) {
+	return m.sendTextEmail(ctx, msg.From, msg.To, msg.ReplyTo, msg.Subject, msg.Text, msg.HTML)
 }
 
-func (m *MailgunSender) sendTextEmail(ctx context.Context, fromRaw, toRaw, replyToRaw, subjectRaw, textRaw string) error {
+// sendTextEmail posts one message and returns the Mailgun message id
+// (without angle brackets) from the send response.
+func (m *MailgunSender) sendTextEmail(ctx context.Context, fromRaw, toRaw, replyToRaw, subjectRaw, textRaw, htmlRaw string) (string, error) {
 	to := strings.TrimSpace(toRaw)
 	subject := strings.TrimSpace(subjectRaw)
 	text := strings.TrimSpace(textRaw)
 	if to == "" || subject == "" || text == "" {
-		return fmt.Errorf("mailgun message to/subject/text are required")
+		return "", fmt.Errorf("mailgun message to/subject/text are required")
 	}
 
 	from := strings.TrimSpace(fromRaw)
@@ -79,6 +84,9 @@ func (m *MailgunSender) sendTextEmail(ctx context.Context, fromRaw, toRaw, reply
 	form.Set("to", to)
 	form.Set("subject", subject)
 	form.Set("text", text)
+	if html := strings.TrimSpace(htmlRaw); html != "" {
+		form.Set("html", html)
+	}
 	if replyTo != "" {
 		form.Set("h:Reply-To", replyTo)
 	}
@@ -86,20 +94,27 @@ func (m *MailgunSender) sendTextEmail(ctx context.Context, fromRaw, toRaw, reply
 	endpoint := fmt.Sprintf("%s/v3/%s/messages", m.apiBase, m.domain)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
 	if err != nil {
-		return fmt.Errorf("creating mailgun request: %w", err)
+		return "", fmt.Errorf("creating mailgun request: %w", err)
 	}
 	req.SetBasicAuth("api", m.apiKey)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := m.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("sending mailgun request: %w", err)
+		return "", fmt.Errorf("sending mailgun request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
-		return fmt.Errorf("mailgun send failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+		return "", fmt.Errorf("mailgun send failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var sent struct {
+		ID string `json:"id"`
 	}
-	return nil
+	// Older API versions and test doubles may reply without JSON; the id is
+	// best-effort and only used to correlate event webhooks.
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&sent)
+	return normalizeProviderMessageID(sent.ID), nil
 }
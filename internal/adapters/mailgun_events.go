@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// mailgunEventPayload is the JSON body Mailgun posts to event webhooks.
+type mailgunEventPayload struct {
+	Signature MailgunSignature `json:"signature"`
+	EventData struct {
+		Event     string  `json:"event"`
+		Severity  string  `json:"severity"`
+		Recipient string  `json:"recipient"`
+		Timestamp float64 `json:"timestamp"`
+		Message   struct {
+			Headers struct {
+				MessageID string `json:"message-id"`
+			} `json:"headers"`
+		} `json:"message"`
+	} `json:"event-data"`
+}
+
+// ParseMailgunEvent reads a Mailgun event webhook POST into a MailEvent plus
+// its signature. The message id is normalized to match the id stored on send.
+func ParseMailgunEvent(r *http.Request) (domain.MailEvent, MailgunSignature, error) {
+	r.Body = http.MaxBytesReader(nil, r.Body, maxMailgunWebhookBody)
+	var payload mailgunEventPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return domain.MailEvent{}, MailgunSignature{}, fmt.Errorf("decoding mailgun event: %w", err)
+	}
+
+	data := payload.EventData
+	event := domain.MailEvent{
+		Event:     strings.ToLower(strings.TrimSpace(data.Event)),
+		Severity:  strings.ToLower(strings.TrimSpace(data.Severity)),
+		MessageID: normalizeProviderMessageID(data.Message.Headers.MessageID),
+		Recipient: strings.TrimSpace(data.Recipient),
+	}
+	if data.Timestamp > 0 {
+		seconds, fraction := math.Modf(data.Timestamp)
+		event.OccurredAt = time.Unix(int64(seconds), int64(fraction*1e9)).UTC()
+	}
+	return event, payload.Signature, nil
+}
+
+// NewMailgunEventRequest builds a signed JSON POST shaped like a Mailgun event
+// webhook, for local simulation.
+func NewMailgunEventRequest(ctx context.Context, endpoint, signingKey string, event domain.MailEvent, now time.Time) (*http.Request, error) {
+	sig, err := SignMailgunWebhook(signingKey, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload mailgunEventPayload
+	payload.Signature = sig
+	payload.EventData.Event = event.Event
+	payload.EventData.Severity = event.Severity
+	payload.EventData.Recipient = event.Recipient
+	payload.EventData.Timestamp = float64(now.Unix())
+	payload.EventData.Message.Headers.MessageID = event.MessageID
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encoding mailgun event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating event request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
@@ -0,0 +1,47 @@
+package adapters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMailgunEvent_NormalizesMessageID(t *testing.T) {
+	body := `{
+  "signature": {"timestamp": "1772366400", "token": "tok", "signature": "abc"},
+  "event-data": {
+    "event": "failed",
+    "severity": "permanent",
+    "recipient": "owner@stanford.edu",
+    "timestamp": 1772366400.5,
+    "message": {"headers": {"message-id": "<20260301.77@mg.supost.com>"}}
+  }
+}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/mailgun/events", strings.NewReader(body))
+
+	event, sig, err := ParseMailgunEvent(req)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if event.Event != "failed" || event.Severity != "permanent" || event.Recipient != "owner@stanford.edu" {
+		t.Fatalf("unexpected event %+v", event)
+	}
+	if event.MessageID != "20260301.77@mg.supost.com" {
+		t.Fatalf("expected normalized message id, got %q", event.MessageID)
+	}
+	if want := time.Unix(1772366400, 5e8).UTC(); !event.OccurredAt.Equal(want) {
+		t.Fatalf("expected occurred_at %s, got %s", want, event.OccurredAt)
+	}
+	if sig.Timestamp != "1772366400" || sig.Token != "tok" || sig.Signature != "abc" {
+		t.Fatalf("unexpected signature %+v", sig)
+	}
+}
+
+func TestParseMailgunEvent_RejectsInvalidJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/mailgun/events", strings.NewReader("event=delivered"))
+	if _, _, err := ParseMailgunEvent(req); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
@@ -79,13 +79,13 @@ func TestMailgunSender_SendResponseEmail_UsesReplyTo(t *testing.T) {
 			capturedBody = string(body)
 			return &http.Response{
 				StatusCode: http.StatusOK,
-				Body:       io.NopCloser(strings.NewReader("ok")),
+				Body:       io.NopCloser(strings.NewReader(`{"id":"<20260226.1@mg.supost.com>","message":"Queued. Thank you."}`)),
 				Header:     make(http.Header),
 			}, nil
 		}),
 	}
 
-	err = sender.SendResponseEmail(context.Background(), domain.ResponseEmailMessage{
+	messageID, err := sender.SendResponseEmail(context.Background(), domain.ResponseEmailMessage{
 		To:      "owner@stanford.edu",
 		ReplyTo: "gwientjes@gmail.com",
 		Subject: "SUpost - gwientjes@gmail.com response: Looking for a buddy",
@@ -94,6 +94,9 @@ func TestMailgunSender_SendResponseEmail_UsesReplyTo(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected send error: %v", err)
 	}
+	if messageID != "20260226.1@mg.supost.com" {
+		t.Fatalf("unexpected mailgun message id %q", messageID)
+	}
 	values, err := url.ParseQuery(capturedBody)
 	if err != nil {
 		t.Fatalf("unexpected parse error: %v", err)
@@ -101,6 +104,48 @@ func TestMailgunSender_SendResponseEmail_UsesReplyTo(t *testing.T) {
 	if values.Get("h:Reply-To") != "gwientjes@gmail.com" {
 		t.Fatalf("missing Reply-To header in payload")
 	}
+	if _, ok := values["html"]; ok {
+		t.Fatalf("did not expect html field for text-only message")
+	}
+}
+
+func TestMailgunSender_SendPublishEmail_IncludesHTML(t *testing.T) {
+	var capturedBody string
+	sender, err := NewMailgunSender("https://api.mailgun.net", "mg.supost.com", "test-key", "response@mg.supost.com", 2*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	sender.client = &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(r.Body)
+			capturedBody = string(body)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("ok")),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+
+	err = sender.SendPublishEmail(context.Background(), domain.PublishEmailMessage{
+		To:      "wientjes@alumni.stanford.edu",
+		Subject: "SUpost - Publish your post! Test",
+		Text:    "Publish your post by pressing:",
+		HTML:    "<p>Publish your post by pressing:</p>",
+	})
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+	values, err := url.ParseQuery(capturedBody)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if values.Get("text") != "Publish your post by pressing:" {
+		t.Fatalf("unexpected text %q", values.Get("text"))
+	}
+	if values.Get("html") != "<p>Publish your post by pressing:</p>" {
+		t.Fatalf("unexpected html %q", values.Get("html"))
+	}
 }
 
 func TestMailgunSender_SendPublishEmail_Non2xx(t *testing.T) {
@@ -0,0 +1,207 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	defaultMailgunWebhookMaxAge = 5 * time.Minute
+	maxMailgunWebhookBody       = 10 << 20
+)
+
+// MailgunSignature is the timestamp/token/signature triple Mailgun attaches
+// to inbound-route and event webhook payloads.
+type MailgunSignature struct {
+	Timestamp string `json:"timestamp"`
+	Token     string `json:"token"`
+	Signature string `json:"signature"`
+}
+
+// MailgunWebhookVerifier checks webhook signatures (HMAC-SHA256 of
+// timestamp+token keyed by the signing key), rejects stale timestamps, and
+// refuses replayed tokens within the freshness window.
+type MailgunWebhookVerifier struct {
+	signingKey []byte
+	maxAge     time.Duration
+	now        func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMailgunWebhookVerifier constructs a verifier. maxAge <= 0 uses 5 minutes.
+func NewMailgunWebhookVerifier(signingKey string, maxAge time.Duration) (*MailgunWebhookVerifier, error) {
+	signingKey = strings.TrimSpace(signingKey)
+	if signingKey == "" {
+		return nil, fmt.Errorf("mailgun webhook signing key is required")
+	}
+	if maxAge <= 0 {
+		maxAge = defaultMailgunWebhookMaxAge
+	}
+	return &MailgunWebhookVerifier{
+		signingKey: []byte(signingKey),
+		maxAge:     maxAge,
+		now:        time.Now,
+		seen:       make(map[string]time.Time),
+	}, nil
+}
+
+// Verify returns a wrapped domain.ErrUnauthorized when the signature is invalid.
+func (v *MailgunWebhookVerifier) Verify(sig MailgunSignature) error {
+	timestamp := strings.TrimSpace(sig.Timestamp)
+	token := strings.TrimSpace(sig.Token)
+	signature := strings.ToLower(strings.TrimSpace(sig.Signature))
+	if timestamp == "" || token == "" || signature == "" {
+		return fmt.Errorf("%w: missing mailgun signature fields", domain.ErrUnauthorized)
+	}
+
+	expected := signMailgunWebhook(v.signingKey, timestamp, token)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return fmt.Errorf("%w: mailgun signature mismatch", domain.ErrUnauthorized)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid mailgun timestamp", domain.ErrUnauthorized)
+	}
+	now := v.now()
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > v.maxAge || age < -v.maxAge {
+		return fmt.Errorf("%w: stale mailgun timestamp", domain.ErrUnauthorized)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for seenToken, seenAt := range v.seen {
+		if now.Sub(seenAt) > v.maxAge {
+			delete(v.seen, seenToken)
+		}
+	}
+	if _, replayed := v.seen[token]; replayed {
+		return fmt.Errorf("%w: replayed mailgun token", domain.ErrUnauthorized)
+	}
+	v.seen[token] = now
+	return nil
+}
+
+// Forget drops a verified token from the replay set. Handlers call it when
+// they fail with a retryable status, so Mailgun's retry of the same signed
+// payload is accepted instead of looking like a replay.
+func (v *MailgunWebhookVerifier) Forget(sig MailgunSignature) {
+	token := strings.TrimSpace(sig.Token)
+	if token == "" {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.seen, token)
+}
+
+// SignMailgunWebhook returns a fresh signature triple, as Mailgun would send.
+// Used by the local webhook simulators.
+func SignMailgunWebhook(signingKey string, now time.Time) (MailgunSignature, error) {
+	buf := make([]byte, 25)
+	if _, err := rand.Read(buf); err != nil {
+		return MailgunSignature{}, fmt.Errorf("generating mailgun token: %w", err)
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	token := hex.EncodeToString(buf)
+	return MailgunSignature{
+		Timestamp: timestamp,
+		Token:     token,
+		Signature: signMailgunWebhook([]byte(strings.TrimSpace(signingKey)), timestamp, token),
+	}, nil
+}
+
+func signMailgunWebhook(key []byte, timestamp, token string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp + token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseMailgunInbound reads a Mailgun inbound-route POST (multipart or
+// urlencoded form) into an InboundEmail plus its signature.
+func ParseMailgunInbound(r *http.Request) (domain.InboundEmail, MailgunSignature, error) {
+	r.Body = http.MaxBytesReader(nil, r.Body, maxMailgunWebhookBody)
+	if err := r.ParseMultipartForm(maxMailgunWebhookBody); err != nil {
+		if !errors.Is(err, http.ErrNotMultipart) {
+			return domain.InboundEmail{}, MailgunSignature{}, fmt.Errorf("parsing mailgun inbound form: %w", err)
+		}
+		if err := r.ParseForm(); err != nil {
+			return domain.InboundEmail{}, MailgunSignature{}, fmt.Errorf("parsing mailgun inbound form: %w", err)
+		}
+	}
+
+	text := r.PostFormValue("body-plain")
+	if strings.TrimSpace(text) == "" {
+		text = r.PostFormValue("stripped-text")
+	}
+	inbound := domain.InboundEmail{
+		Recipient: strings.TrimSpace(r.PostFormValue("recipient")),
+		Sender:    strings.TrimSpace(r.PostFormValue("sender")),
+		From:      strings.TrimSpace(r.PostFormValue("from")),
+		Subject:   strings.TrimSpace(r.PostFormValue("subject")),
+		Text:      strings.TrimSpace(text),
+		HTML:      strings.TrimSpace(r.PostFormValue("body-html")),
+	}
+	sig := MailgunSignature{
+		Timestamp: r.PostFormValue("timestamp"),
+		Token:     r.PostFormValue("token"),
+		Signature: r.PostFormValue("signature"),
+	}
+	return inbound, sig, nil
+}
+
+// NewMailgunInboundRequest builds a signed multipart POST shaped like a
+// Mailgun inbound-route delivery, for local simulation.
+func NewMailgunInboundRequest(ctx context.Context, endpoint, signingKey string, inbound domain.InboundEmail, now time.Time) (*http.Request, error) {
+	sig, err := SignMailgunWebhook(signingKey, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	fields := [][2]string{
+		{"recipient", inbound.Recipient},
+		{"sender", inbound.Sender},
+		{"from", inbound.From},
+		{"subject", inbound.Subject},
+		{"body-plain", inbound.Text},
+		{"stripped-text", inbound.Text},
+		{"body-html", inbound.HTML},
+		{"timestamp", sig.Timestamp},
+		{"token", sig.Token},
+		{"signature", sig.Signature},
+	}
+	for _, field := range fields {
+		if err := form.WriteField(field[0], field[1]); err != nil {
+			return nil, fmt.Errorf("writing inbound form field %s: %w", field[0], err)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("closing inbound form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, fmt.Errorf("creating inbound request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req, nil
+}
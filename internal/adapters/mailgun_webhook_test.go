@@ -0,0 +1,112 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestMailgunWebhookVerifier_AcceptsValidSignatureOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	verifier, err := NewMailgunWebhookVerifier("key-test", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	verifier.now = func() time.Time { return now }
+
+	sig, err := SignMailgunWebhook("key-test", now)
+	if err != nil {
+		t.Fatalf("unexpected sign error: %v", err)
+	}
+	if err := verifier.Verify(sig); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+	if err := verifier.Verify(sig); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected replayed token to be rejected, got %v", err)
+	}
+}
+
+func TestMailgunWebhookVerifier_ForgetAllowsRetry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	verifier, err := NewMailgunWebhookVerifier("key-test", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	verifier.now = func() time.Time { return now }
+
+	sig, err := SignMailgunWebhook("key-test", now)
+	if err != nil {
+		t.Fatalf("unexpected sign error: %v", err)
+	}
+	if err := verifier.Verify(sig); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+	verifier.Forget(sig)
+	if err := verifier.Verify(sig); err != nil {
+		t.Fatalf("expected a forgotten token to verify again, got %v", err)
+	}
+	if err := verifier.Verify(sig); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected the retried token to be recorded again, got %v", err)
+	}
+}
+
+func TestMailgunWebhookVerifier_RejectsBadOrStaleSignatures(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	verifier, err := NewMailgunWebhookVerifier("key-test", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	verifier.now = func() time.Time { return now }
+
+	wrongKey, _ := SignMailgunWebhook("key-other", now)
+	stale, _ := SignMailgunWebhook("key-test", now.Add(-2*time.Minute))
+	for name, sig := range map[string]MailgunSignature{
+		"wrong key": wrongKey,
+		"stale":     stale,
+		"missing":   {Timestamp: "1", Token: "t"},
+	} {
+		if err := verifier.Verify(sig); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("%s: expected unauthorized, got %v", name, err)
+		}
+	}
+}
+
+func TestParseMailgunInbound_RoundTripsSimulatorRequest(t *testing.T) {
+	inbound := domain.InboundEmail{
+		Recipient: "reply+77.p.0123456789abcdef@mg.supost.com",
+		Sender:    "buyer@gmail.com",
+		From:      "Buyer <buyer@gmail.com>",
+		Subject:   "Re: Desk",
+		Text:      "See you at 5.",
+	}
+	now := time.Now()
+	req, err := NewMailgunInboundRequest(context.Background(), "http://localhost/webhooks/mailgun/inbound", "key-test", inbound, now)
+	if err != nil {
+		t.Fatalf("unexpected request error: %v", err)
+	}
+
+	var (
+		got    domain.InboundEmail
+		gotSig MailgunSignature
+	)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, gotSig, err = ParseMailgunInbound(r)
+	})
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if got != inbound {
+		t.Fatalf("expected %+v, got %+v", inbound, got)
+	}
+
+	verifier, _ := NewMailgunWebhookVerifier("key-test", time.Minute)
+	if err := verifier.Verify(gotSig); err != nil {
+		t.Fatalf("expected simulator signature to verify, got %v", err)
+	}
+}
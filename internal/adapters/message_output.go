@@ -0,0 +1,32 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderMessageStatus renders the delivery status of one response message.
+func RenderMessageStatus(w io.Writer, message domain.Message) error {
+	mailgunID := message.MailgunMessageID
+	if mailgunID == "" {
+		mailgunID = "(none)"
+	}
+	lines := []string{
+		fmt.Sprintf("message_id: %d", message.ID),
+		fmt.Sprintf("post_id: %d", message.PostID),
+		fmt.Sprintf("reply_to: %s", message.Email),
+		fmt.Sprintf("status: %s", message.Status),
+		fmt.Sprintf("mailgun_message_id: %s", mailgunID),
+		fmt.Sprintf("created_at: %s", message.CreatedAt.Format(time.RFC3339)),
+		fmt.Sprintf("updated_at: %s", message.UpdatedAt.Format(time.RFC3339)),
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const myMessagesIndent = "    "
+
+// RenderMyMessages renders `supost me messages` as a threaded inbox: one
+// header bar per post, then its responses oldest first.
+func RenderMyMessages(w io.Writer, result domain.MyMessagesResult) error {
+	if err := RenderPageHeader(w, PageHeaderOptions{
+		Width:      postPageWidth,
+		Location:   "Stanford, California",
+		RightLabel: "inbox",
+		Now:        time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("Inbox: %s  %s on %s",
+		result.Email,
+		pluralCount(result.MessageCount, "response"),
+		pluralCount(len(result.Threads), "post"))
+	if _, err := fmt.Fprintln(w, fitText(summary, postPageWidth)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	if len(result.Threads) == 0 {
+		if _, err := fmt.Fprintln(w, "No responses yet."); err != nil {
+			return err
+		}
+	}
+	for _, thread := range result.Threads {
+		if err := renderMessageThread(w, thread, postPageWidth); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	return RenderPageFooter(w, PageFooterOptions{Width: postPageWidth})
+}
+
+func renderMessageThread(w io.Writer, thread domain.MessageThread, width int) error {
+	title := strings.TrimSpace(thread.PostName)
+	if title == "" {
+		title = "(untitled post)"
+	}
+	header := fmt.Sprintf(" %s  #%d  [%s]  %s", title, thread.PostID, thread.PostStatus, pluralCount(len(thread.Messages), "response"))
+	if _, err := fmt.Fprintln(w, ansiHeader+fitText(header, width)+ansiReset); err != nil {
+		return err
+	}
+
+	if len(thread.Messages) == 0 {
+		if _, err := fmt.Fprintln(w, fitText(myMessagesIndent+"No responses yet.", width)); err != nil {
+			return err
+		}
+	}
+	for _, message := range thread.Messages {
+		if _, err := fmt.Fprintln(w, renderInboxMessageMeta(message, width)); err != nil {
+			return err
+		}
+		for _, line := range wrapPlainText(message.Message, width-len(myMessagesIndent)) {
+			if _, err := fmt.Fprintln(w, myMessagesIndent+line); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func renderInboxMessageMeta(message domain.InboxMessage, width int) string {
+	parts := []string{"Reply to: " + message.ReplyTo}
+	if !message.CreatedAt.IsZero() {
+		parts = append(parts, message.CreatedAt.Format("Mon, Jan 2, 2006 03:04 PM"))
+	}
+	if message.Status != "" {
+		parts = append(parts, message.Status)
+	}
+	line := fitText(strings.Join(parts, "  |  "), width)
+	if message.Scammed {
+		line = fitText(strings.Join(parts, "  |  "), width-len("  [SCAM]")) + "  " + ansiMagenta + "[SCAM]" + ansiReset
+	}
+	return line
+}
+
+func pluralCount(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestRenderMyMessages_RendersThreadsWithReplyToStatusAndScamFlag(t *testing.T) {
+	sentAt := time.Date(2026, 10, 18, 15, 4, 0, 0, time.UTC)
+	var out bytes.Buffer
+	err := RenderMyMessages(&out, domain.MyMessagesResult{
+		Email:        "pat@stanford.edu",
+		MessageCount: 2,
+		Threads: []domain.MessageThread{{
+			PostID:     130031896,
+			PostName:   "Magic Keyboard",
+			PostStatus: "active",
+			Messages: []domain.InboxMessage{
+				{ID: 1, ReplyTo: "buyer@stanford.edu", Message: "Is it still available?", Status: domain.MessageStatusDelivered, CreatedAt: sentAt},
+				{ID: 2, ReplyTo: "scam@example.com", Message: "I will send a check", Status: domain.MessageStatusQueued, Scammed: true, CreatedAt: sentAt},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	for _, want := range []string{
+		"Inbox: pat@stanford.edu  2 responses on 1 post",
+		" Magic Keyboard  #130031896  [active]  2 responses",
+		"Reply to: buyer@stanford.edu  |  Sun, Oct 18, 2026 03:04 PM  |  delivered",
+		"    Is it still available?",
+		"Reply to: scam@example.com",
+		"[SCAM]",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRenderMyMessages_EmptyInbox(t *testing.T) {
+	var out bytes.Buffer
+	if err := RenderMyMessages(&out, domain.MyMessagesResult{Email: "pat@stanford.edu"}); err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No responses yet.") {
+		t.Fatalf("expected empty inbox notice:\n%s", out.String())
+	}
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderMyPosts renders `supost me posts`, one post per line.
+func RenderMyPosts(w io.Writer, result domain.MyPostsResult) error {
+	lines := []string{
+		fmt.Sprintf("my posts: %s", result.Email),
+		fmt.Sprintf("post_count: %d", len(result.Posts)),
+	}
+	for _, post := range result.Posts {
+		line := fmt.Sprintf("  %d  %-7s  %s", post.ID, domain.PostStatusLabel(post.Status), formatMyPostDate(post))
+		if price := formatPrice(post.Price, post.HasPrice); price != "" {
+			line += "  " + price
+		}
+		if post.HasImage {
+			line += "  [photo]"
+		}
+		lines = append(lines, line+"  "+post.Name)
+	}
+	return writeAuthLines(w, lines)
+}
+
+// RenderMyPostAction renders the outcome of `me posts publish|edit|delete`.
+func RenderMyPostAction(w io.Writer, result domain.MyPostActionResult) error {
+	lines := []string{
+		fmt.Sprintf("[%s] post %d", result.Action, result.PostID),
+		fmt.Sprintf("post_name: %s", result.PostName),
+		fmt.Sprintf("status: %s", result.Status),
+	}
+	if price := formatPrice(result.Price, result.HasPrice); price != "" {
+		lines = append(lines, fmt.Sprintf("price: %s", price))
+	}
+	return writeAuthLines(w, lines)
+}
+
+func formatMyPostDate(post domain.Post) string {
+	if post.TimePosted > 0 {
+		return time.Unix(post.TimePosted, 0).UTC().Format("2006-01-02")
+	}
+	if !post.TimePostedAt.IsZero() && post.TimePostedAt.Unix() > 0 {
+		return post.TimePostedAt.UTC().Format("2006-01-02")
+	}
+	return "-"
+}
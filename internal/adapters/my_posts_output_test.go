@@ -0,0 +1,34 @@
+package adapters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestRenderMyPosts_ListsStatusPriceAndName(t *testing.T) {
+	var out bytes.Buffer
+	err := RenderMyPosts(&out, domain.MyPostsResult{
+		Email: "pat@stanford.edu",
+		Posts: []domain.Post{
+			{ID: 130031896, Status: domain.PostStatusPending, TimePosted: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC).Unix(), Name: "Magic Keyboard", Price: 40, HasPrice: true, HasImage: true},
+			{ID: 130031001, Status: domain.PostStatusExpired, Name: "Old lamp"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	for _, want := range []string{
+		"my posts: pat@stanford.edu",
+		"post_count: 2",
+		"  130031896  pending  2026-10-01  $40  [photo]  Magic Keyboard",
+		"  130031001  expired  -  Old lamp",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+var backfillJobNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// FilePhotoBackfillCheckpoints stores one JSON checkpoint per backfill job
+// in a local directory. It replaces the dropped
+// app_private.backfill_checkpoint table.
+type FilePhotoBackfillCheckpoints struct {
+	dir string
+}
+
+// NewFilePhotoBackfillCheckpoints constructs a checkpoint store rooted at
+// dir. A blank dir falls back to DefaultBackfillCheckpointDir.
+func NewFilePhotoBackfillCheckpoints(dir string) *FilePhotoBackfillCheckpoints {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		dir = DefaultBackfillCheckpointDir()
+	}
+	return &FilePhotoBackfillCheckpoints{dir: dir}
+}
+
+// DefaultBackfillCheckpointDir is the per-user directory used when no
+// checkpoint directory is given.
+func DefaultBackfillCheckpointDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, "supost-cli", "backfill")
+}
+
+// Path returns the checkpoint file for job.
+func (c *FilePhotoBackfillCheckpoints) Path(job string) string {
+	return filepath.Join(c.dir, job+".json")
+}
+
+// LoadPhotoBackfillCheckpoint reads job's checkpoint. A job that has never
+// run starts from post id 0.
+func (c *FilePhotoBackfillCheckpoints) LoadPhotoBackfillCheckpoint(job string) (domain.PhotoBackfillCheckpoint, error) {
+	if !backfillJobNamePattern.MatchString(job) {
+		return domain.PhotoBackfillCheckpoint{}, fmt.Errorf("invalid backfill job name %q", job)
+	}
+	content, err := os.ReadFile(c.Path(job))
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.PhotoBackfillCheckpoint{Job: job}, nil
+	}
+	if err != nil {
+		return domain.PhotoBackfillCheckpoint{}, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	var checkpoint domain.PhotoBackfillCheckpoint
+	if err := json.Unmarshal(content, &checkpoint); err != nil {
+		return domain.PhotoBackfillCheckpoint{}, fmt.Errorf("parsing checkpoint %s: %w", c.Path(job), err)
+	}
+	checkpoint.Job = job
+	return checkpoint, nil
+}
+
+// SavePhotoBackfillCheckpoint writes the checkpoint via a temp file and
+// rename, so an interrupted run never leaves a truncated file behind.
+func (c *FilePhotoBackfillCheckpoints) SavePhotoBackfillCheckpoint(checkpoint domain.PhotoBackfillCheckpoint) error {
+	if !backfillJobNamePattern.MatchString(checkpoint.Job) {
+		return fmt.Errorf("invalid backfill job name %q", checkpoint.Job)
+	}
+	content, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding checkpoint: %w", err)
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("creating checkpoint directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, checkpoint.Job+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating checkpoint temp file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.Path(checkpoint.Job)); err != nil {
+		return fmt.Errorf("saving checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ResetPhotoBackfillCheckpoint deletes job's checkpoint so the next run
+// starts over.
+func (c *FilePhotoBackfillCheckpoints) ResetPhotoBackfillCheckpoint(job string) error {
+	if !backfillJobNamePattern.MatchString(job) {
+		return fmt.Errorf("invalid backfill job name %q", job)
+	}
+	if err := os.Remove(c.Path(job)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing checkpoint: %w", err)
+	}
+	return nil
+}
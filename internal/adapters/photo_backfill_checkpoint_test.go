@@ -0,0 +1,44 @@
+package adapters
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestFilePhotoBackfillCheckpoints_RoundTripAndReset(t *testing.T) {
+	store := NewFilePhotoBackfillCheckpoints(t.TempDir())
+
+	fresh, err := store.LoadPhotoBackfillCheckpoint("photo_backfill_v1")
+	if err != nil || fresh.Job != "photo_backfill_v1" || fresh.LastPostID != 0 {
+		t.Fatalf("expected an empty checkpoint for a new job, got %+v, %v", fresh, err)
+	}
+
+	saved := domain.PhotoBackfillCheckpoint{
+		Job:            "photo_backfill_v1",
+		LastPostID:     130031900,
+		ProcessedPosts: 2,
+		InsertedPhotos: 3,
+		UpdatedAt:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}
+	if err := store.SavePhotoBackfillCheckpoint(saved); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+	loaded, err := store.LoadPhotoBackfillCheckpoint("photo_backfill_v1")
+	if err != nil || !loaded.UpdatedAt.Equal(saved.UpdatedAt) || loaded.LastPostID != saved.LastPostID || loaded.InsertedPhotos != 3 {
+		t.Fatalf("expected %+v back, got %+v, %v", saved, loaded, err)
+	}
+
+	if err := store.ResetPhotoBackfillCheckpoint("photo_backfill_v1"); err != nil {
+		t.Fatalf("unexpected reset error: %v", err)
+	}
+	if _, err := os.Stat(store.Path("photo_backfill_v1")); !os.IsNotExist(err) {
+		t.Fatalf("expected checkpoint removed, stat err %v", err)
+	}
+
+	if _, err := store.LoadPhotoBackfillCheckpoint("../escape"); err == nil {
+		t.Fatalf("expected a path-like job name to be rejected")
+	}
+}
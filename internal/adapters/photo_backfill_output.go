@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderPhotoBackfillBatch writes one progress line for a finished batch.
+func RenderPhotoBackfillBatch(w io.Writer, batch domain.PhotoBackfillBatch) error {
+	_, err := fmt.Fprintf(w,
+		"batch %d: posts %d..%d selected=%d already_backfilled=%d prepared=%d inserted=%d missing_objects=%d\n",
+		batch.Batch, batch.FirstPostID, batch.LastPostID, batch.SelectedPosts,
+		batch.AlreadyBackfilled, batch.PreparedPhotos, batch.InsertedPhotos, batch.MissingObjects,
+	)
+	return err
+}
+
+// RenderPhotoBackfillReport renders the totals of an `admin photos
+// backfill` run and where its checkpoint is stored.
+func RenderPhotoBackfillReport(w io.Writer, report domain.PhotoBackfillReport, checkpointPath string) error {
+	mode := "backfill"
+	if report.DryRun {
+		mode = "dry-run"
+	}
+	status := "more posts remain; re-run to continue"
+	if report.Done {
+		status = "done"
+	}
+	lines := []string{
+		fmt.Sprintf("[%s] photo backfill", mode),
+		fmt.Sprintf("job: %s", report.Job),
+		fmt.Sprintf("batches: %d", report.Batches),
+		fmt.Sprintf("selected_posts: %d", report.SelectedPosts),
+		fmt.Sprintf("already_backfilled: %d", report.AlreadyBackfilled),
+		fmt.Sprintf("prepared_photos: %d", report.PreparedPhotos),
+		fmt.Sprintf("inserted_photos: %d", report.InsertedPhotos),
+		fmt.Sprintf("verified: %t", report.Verified),
+		fmt.Sprintf("missing_objects: %d", len(report.MissingObjects)),
+		fmt.Sprintf("checkpoint: last_post_id=%d processed_posts=%d (%s)", report.Checkpoint.LastPostID, report.Checkpoint.ProcessedPosts, checkpointPath),
+		fmt.Sprintf("status: %s", status),
+	}
+	for _, key := range report.MissingObjects {
+		lines = append(lines, fmt.Sprintf("  missing: %s", key))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderPhotoGCReport renders an `admin photos gc` pass: the counts, then
+// one line per orphaned object with its size and age.
+func RenderPhotoGCReport(w io.Writer, report domain.PhotoGCReport) error {
+	mode := "delete"
+	if report.DryRun {
+		mode = "dry-run"
+	}
+	lines := []string{
+		fmt.Sprintf("[%s] photo gc", mode),
+		fmt.Sprintf("prefix: %s", report.Prefix),
+		fmt.Sprintf("grace_period: %s", report.GracePeriod),
+		fmt.Sprintf("pages: %d", report.Pages),
+		fmt.Sprintf("scanned: %d", report.Scanned),
+		fmt.Sprintf("referenced: %d", report.Referenced),
+		fmt.Sprintf("too_recent: %d", report.TooRecent),
+		fmt.Sprintf("orphans: %d (%d bytes)", len(report.Orphans), report.OrphanBytes),
+	}
+	if !report.DryRun {
+		lines = append(lines, fmt.Sprintf("deleted: %d", report.Deleted))
+	}
+	for _, object := range report.Orphans {
+		lines = append(lines, fmt.Sprintf("  %s  %d bytes  modified %s", object.Key, object.Size, object.LastModified.UTC().Format("2006-01-02 15:04:05Z")))
+	}
+	for _, problem := range report.DeleteErrors {
+		lines = append(lines, fmt.Sprintf("delete_error: %s", problem))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
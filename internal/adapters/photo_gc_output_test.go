@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestRenderPhotoGCReport_ListsOrphansAndCounts(t *testing.T) {
+	var out bytes.Buffer
+	err := RenderPhotoGCReport(&out, domain.PhotoGCReport{
+		DryRun:      true,
+		Prefix:      "v2/posts",
+		GracePeriod: 24 * time.Hour,
+		Pages:       2,
+		Scanned:     5,
+		Referenced:  3,
+		TooRecent:   1,
+		Orphans: []domain.PhotoObject{
+			{Key: "v2/posts/2/orphan.jpg", Size: 300, LastModified: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)},
+		},
+		OrphanBytes: 300,
+	})
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	for _, want := range []string{
+		"[dry-run] photo gc",
+		"grace_period: 24h0m0s",
+		"scanned: 5",
+		"orphans: 1 (300 bytes)",
+		"  v2/posts/2/orphan.jpg  300 bytes  modified 2026-10-01 12:00:00Z",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "deleted:") {
+		t.Fatalf("expected no deleted count on a dry run:\n%s", out.String())
+	}
+}
+
+func TestRenderPhotoBackfillReport_ShowsTotalsAndCheckpoint(t *testing.T) {
+	var out bytes.Buffer
+	if err := RenderPhotoBackfillBatch(&out, domain.PhotoBackfillBatch{Batch: 1, FirstPostID: 10, LastPostID: 20, SelectedPosts: 2, PreparedPhotos: 3, InsertedPhotos: 3}); err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	err := RenderPhotoBackfillReport(&out, domain.PhotoBackfillReport{
+		Job:            "photo_backfill_v1",
+		Batches:        1,
+		InsertedPhotos: 3,
+		Verified:       true,
+		MissingObjects: []string{"posts/20/ticker_20b.jpg"},
+		Checkpoint:     domain.PhotoBackfillCheckpoint{LastPostID: 20, ProcessedPosts: 2},
+		Done:           true,
+	}, "/tmp/backfill/photo_backfill_v1.json")
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	for _, want := range []string{
+		"batch 1: posts 10..20 selected=2 already_backfilled=0 prepared=3 inserted=3 missing_objects=0",
+		"[backfill] photo backfill",
+		"inserted_photos: 3",
+		"checkpoint: last_post_id=20 processed_posts=2 (/tmp/backfill/photo_backfill_v1.json)",
+		"status: done",
+		"  missing: posts/20/ticker_20b.jpg",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// PhotoKeyPrefix returns the key prefix photos are stored under.
+func (u *S3PostPhotoUploader) PhotoKeyPrefix() string {
+	return u.prefix
+}
+
+// ListPhotoObjects lists one page of objects under the photo prefix.
+// pageToken is the S3 continuation token from the previous page.
+func (u *S3PostPhotoUploader) ListPhotoObjects(ctx context.Context, pageToken string, pageSize int) (domain.PhotoObjectPage, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(u.bucket),
+		Prefix:  aws.String(u.prefix + "/"),
+		MaxKeys: aws.Int32(int32(clampPhotoPageSize(pageSize))),
+	}
+	if pageToken != "" {
+		input.ContinuationToken = aws.String(pageToken)
+	}
+	out, err := u.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return domain.PhotoObjectPage{}, fmt.Errorf("list objects under %q: %w", u.prefix, err)
+	}
+
+	page := domain.PhotoObjectPage{Objects: make([]domain.PhotoObject, 0, len(out.Contents))}
+	for _, object := range out.Contents {
+		page.Objects = append(page.Objects, domain.PhotoObject{
+			Key:          aws.ToString(object.Key),
+			Size:         aws.ToInt64(object.Size),
+			LastModified: aws.ToTime(object.LastModified),
+		})
+	}
+	if aws.ToBool(out.IsTruncated) {
+		page.NextPageToken = aws.ToString(out.NextContinuationToken)
+	}
+	return page, nil
+}
+
+// DeletePhotoObject deletes a single object. Keys outside the photo prefix
+// are refused.
+func (u *S3PostPhotoUploader) DeletePhotoObject(ctx context.Context, key string) error {
+	if !hasPhotoKeyPrefix(key, u.prefix) {
+		return fmt.Errorf("refusing to delete %q outside photo prefix %q", key, u.prefix)
+	}
+	if _, err := u.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return fmt.Errorf("delete object %q: %w", key, err)
+	}
+	return nil
+}
+
+// PhotoObjectExists reports whether key exists in the bucket. Any key may be
+// checked, including legacy posts/{id}/ keys outside the photo prefix.
+func (u *S3PostPhotoUploader) PhotoObjectExists(ctx context.Context, key string) (bool, error) {
+	_, err := u.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+	})
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("head object %q: %w", key, err)
+	}
+	return true, nil
+}
+
+// PhotoKeyPrefix returns the key prefix photos are stored under.
+func (u *LocalPostPhotoUploader) PhotoKeyPrefix() string {
+	return u.prefix
+}
+
+// ListPhotoObjects lists one page of files under the photo prefix in key
+// order. pageToken is the last key of the previous page.
+func (u *LocalPostPhotoUploader) ListPhotoObjects(_ context.Context, pageToken string, pageSize int) (domain.PhotoObjectPage, error) {
+	pageSize = clampPhotoPageSize(pageSize)
+	dir := filepath.Join(u.root, filepath.FromSlash(u.prefix))
+	page := domain.PhotoObjectPage{Objects: make([]domain.PhotoObject, 0)}
+
+	errPageFull := errors.New("page full")
+	err := filepath.WalkDir(dir, func(target string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && target == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(u.root, target)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if pageToken != "" && key <= pageToken {
+			return nil
+		}
+		if len(page.Objects) == pageSize {
+			page.NextPageToken = page.Objects[len(page.Objects)-1].Key
+			return errPageFull
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		page.Objects = append(page.Objects, domain.PhotoObject{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPageFull) {
+		return domain.PhotoObjectPage{}, fmt.Errorf("listing photos under %q: %w", dir, err)
+	}
+	return page, nil
+}
+
+// DeletePhotoObject removes a single file. Keys outside the photo prefix are
+// refused; a missing file is not an error.
+func (u *LocalPostPhotoUploader) DeletePhotoObject(_ context.Context, key string) error {
+	if !hasPhotoKeyPrefix(key, u.prefix) {
+		return fmt.Errorf("refusing to delete %q outside photo prefix %q", key, u.prefix)
+	}
+	return u.removeObject(key)
+}
+
+// PhotoObjectExists reports whether a file exists for key under the storage
+// root.
+func (u *LocalPostPhotoUploader) PhotoObjectExists(_ context.Context, key string) (bool, error) {
+	target := filepath.Join(u.root, filepath.FromSlash(path.Clean("/"+key)))
+	info, err := os.Stat(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("checking photo %q: %w", key, err)
+	}
+	return !info.IsDir(), nil
+}
+
+func hasPhotoKeyPrefix(key, prefix string) bool {
+	cleaned := path.Clean("/" + strings.TrimSpace(key))
+	return strings.HasPrefix(cleaned, "/"+prefix+"/")
+}
+
+// clampPhotoPageSize keeps page sizes within the S3 ListObjectsV2 limit.
+func clampPhotoPageSize(pageSize int) int {
+	if pageSize <= 0 || pageSize > 1000 {
+		return 1000
+	}
+	return pageSize
+}
@@ -0,0 +1,214 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3ListServer serves ListObjectsV2 two keys per page and records DELETEs.
+func fakeS3ListServer(t *testing.T, keys []string) (*httptest.Server, *[]string, *[]string) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		tokens   []string
+		deleted  []string
+		modified = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("list-type") != "2" || query.Get("prefix") != "v2/posts/" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+		token := query.Get("continuation-token")
+		tokens = append(tokens, token)
+		start := 0
+		if token != "" {
+			fmt.Sscanf(token, "page-%d", &start)
+		}
+		end := min(start+2, len(keys))
+
+		var body strings.Builder
+		body.WriteString(`<?xml version="1.0" encoding="UTF-8"?><ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>supost-dev</Name>`)
+		for _, key := range keys[start:end] {
+			fmt.Fprintf(&body, "<Contents><Key>%s</Key><LastModified>%s</LastModified><Size>%d</Size></Contents>", key, modified, len(key))
+		}
+		if end < len(keys) {
+			fmt.Fprintf(&body, "<IsTruncated>true</IsTruncated><NextContinuationToken>page-%d</NextContinuationToken>", end)
+		} else {
+			body.WriteString("<IsTruncated>false</IsTruncated>")
+		}
+		body.WriteString("</ListBucketResult>")
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, body.String())
+	}))
+	t.Cleanup(server.Close)
+	return server, &tokens, &deleted
+}
+
+func TestS3PostPhotoUploader_ListPhotoObjectsFollowsContinuationTokens(t *testing.T) {
+	keys := []string{"v2/posts/1/a.jpg", "v2/posts/1/ticker_a.jpg", "v2/posts/2/b.jpg"}
+	server, tokens, _ := fakeS3ListServer(t, keys)
+	uploader, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "v2/posts", "", server.URL, true, "minio-key", "minio-secret")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	first, err := uploader.ListPhotoObjects(context.Background(), "", 2)
+	if err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	if len(first.Objects) != 2 || first.NextPageToken != "page-2" {
+		t.Fatalf("unexpected first page %+v", first)
+	}
+	if first.Objects[1].Key != keys[1] || first.Objects[1].Size != int64(len(keys[1])) || first.Objects[1].LastModified.IsZero() {
+		t.Fatalf("expected key, size, and last modified mapped, got %+v", first.Objects[1])
+	}
+
+	second, err := uploader.ListPhotoObjects(context.Background(), first.NextPageToken, 2)
+	if err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	if len(second.Objects) != 1 || second.Objects[0].Key != keys[2] || second.NextPageToken != "" {
+		t.Fatalf("unexpected last page %+v", second)
+	}
+	if got := strings.Join(*tokens, ","); got != ",page-2" {
+		t.Fatalf("expected continuation tokens [ page-2], got %q", got)
+	}
+}
+
+func TestS3PostPhotoUploader_DeletePhotoObjectStaysInsidePrefix(t *testing.T) {
+	server, _, deleted := fakeS3ListServer(t, nil)
+	uploader, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "v2/posts", "", server.URL, true, "minio-key", "minio-secret")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	if err := uploader.DeletePhotoObject(context.Background(), "v2/posts/1/a.jpg"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	for _, key := range []string{"posts/1/ticker_1a", "v2/posts/../secrets.txt"} {
+		if err := uploader.DeletePhotoObject(context.Background(), key); err == nil || !strings.Contains(err.Error(), "outside photo prefix") {
+			t.Fatalf("expected %q to be refused, got %v", key, err)
+		}
+	}
+	if len(*deleted) != 1 || (*deleted)[0] != "/supost-dev/v2/posts/1/a.jpg" {
+		t.Fatalf("expected one DELETE inside the prefix, got %v", *deleted)
+	}
+}
+
+func TestLocalPostPhotoUploader_ListPhotoObjectsPagesInKeyOrder(t *testing.T) {
+	root := t.TempDir()
+	uploader, err := NewLocalPostPhotoUploader(root, "v2/posts")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	empty, err := uploader.ListPhotoObjects(context.Background(), "", 2)
+	if err != nil || len(empty.Objects) != 0 || empty.NextPageToken != "" {
+		t.Fatalf("expected an empty listing before any upload, got %+v, %v", empty, err)
+	}
+
+	keys := []string{"v2/posts/1/a.jpg", "v2/posts/1/ticker_a.jpg", "v2/posts/2/b.jpg", "posts/1/ticker_1a"}
+	for _, key := range keys {
+		target := filepath.Join(root, filepath.FromSlash(key))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(target, []byte(key), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	var listed []string
+	token, pages := "", 0
+	for {
+		page, err := uploader.ListPhotoObjects(context.Background(), token, 2)
+		if err != nil {
+			t.Fatalf("unexpected list error: %v", err)
+		}
+		pages++
+		for _, object := range page.Objects {
+			if object.Size != int64(len(object.Key)) || object.LastModified.IsZero() {
+				t.Fatalf("expected size and mtime for %+v", object)
+			}
+			listed = append(listed, object.Key)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		token = page.NextPageToken
+	}
+	if got := strings.Join(listed, ","); got != strings.Join(keys[:3], ",") || pages != 2 {
+		t.Fatalf("expected the three prefixed keys over 2 pages, got %s over %d", got, pages)
+	}
+
+	if err := uploader.DeletePhotoObject(context.Background(), keys[0]); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(keys[0]))); !os.IsNotExist(err) {
+		t.Fatalf("expected %s removed, stat err %v", keys[0], err)
+	}
+	if err := uploader.DeletePhotoObject(context.Background(), keys[3]); err == nil {
+		t.Fatalf("expected a key outside the prefix to be refused")
+	}
+}
+
+func TestPhotoObjectExists_S3HeadAndLocalStat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path == "/supost-dev/posts/1/post_1a.jpg" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	s3Uploader, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "v2/posts", "", server.URL, true, "minio-key", "minio-secret")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "posts", "1"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "posts", "1", "post_1a.jpg"), []byte("jpeg"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	localUploader, err := NewLocalPostPhotoUploader(root, "v2/posts")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	for name, exists := range map[string]func(context.Context, string) (bool, error){
+		"s3":    s3Uploader.PhotoObjectExists,
+		"local": localUploader.PhotoObjectExists,
+	} {
+		if ok, err := exists(context.Background(), "posts/1/post_1a.jpg"); err != nil || !ok {
+			t.Fatalf("%s: expected existing object found, got %t, %v", name, ok, err)
+		}
+		if ok, err := exists(context.Background(), "posts/1/ticker_1a.jpg"); err != nil || ok {
+			t.Fatalf("%s: expected missing object reported, got %t, %v", name, ok, err)
+		}
+	}
+}
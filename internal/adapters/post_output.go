@@ -9,6 +9,9 @@ import (
 	"github.com/Capmus-Team/supost-cli/internal/domain"
 )
 
+// DefaultPhotoBaseURL is the public host for production post photos.
+const DefaultPhotoBaseURL = "https://supost-prod.s3.amazonaws.com"
+
 const (
 	postPageWidth        = homePageWidth
 	postContentGap       = 2
@@ -19,8 +22,9 @@ const (
 	postCommercialNotice = "please do not message this poster about other commercial services"
 )
 
-// RenderPostPage renders a single post view with shared page chrome.
-func RenderPostPage(w io.Writer, post domain.Post) error {
+// RenderPostPage renders a single post view with shared page chrome. Photo
+// URLs are built from photoBaseURL (blank = DefaultPhotoBaseURL).
+func RenderPostPage(w io.Writer, post domain.Post, photoBaseURL string) error {
 	now := time.Now()
 	if err := RenderPageHeader(w, PageHeaderOptions{
 		Width:      postPageWidth,
@@ -42,7 +46,7 @@ func RenderPostPage(w io.Writer, post domain.Post) error {
 	}
 
 	leftWidth, rightWidth := splitPostContentWidths(postPageWidth)
-	leftRows := renderPostMainRows(post, now, leftWidth)
+	leftRows := renderPostMainRows(post, now, leftWidth, photoBaseURL)
 	rightRows := renderPostMessagePosterRows(rightWidth)
 
 	totalRows := len(leftRows)
@@ -118,9 +122,9 @@ func splitPostContentWidths(width int) (int, int) {
 	return leftWidth, rightWidth
 }
 
-func renderPostMainRows(post domain.Post, now time.Time, width int) []string {
+func renderPostMainRows(post domain.Post, now time.Time, width int, photoBaseURL string) []string {
 	rows := make([]string, 0, 24)
-	gridRows := renderPostPhotoGridRows(post, now, width)
+	gridRows := renderPostPhotoGridRows(post, now, width, photoBaseURL)
 	rows = append(rows, gridRows...)
 	if len(gridRows) > 0 {
 		rows = append(rows, "")
@@ -139,8 +143,8 @@ func renderPostMainRows(post domain.Post, now time.Time, width int) []string {
 	return rows
 }
 
-func renderPostPhotoGridRows(post domain.Post, now time.Time, width int) []string {
-	quadrants := postPhotoQuadrantURLs(post, now)
+func renderPostPhotoGridRows(post domain.Post, now time.Time, width int, photoBaseURL string) []string {
+	quadrants := postPhotoQuadrantURLs(photoBaseURL, post, now)
 	hasAny := false
 	for _, value := range quadrants {
 		if value != "" {
@@ -189,7 +193,7 @@ func renderPostPhotoGridRows(post domain.Post, now time.Time, width int) []strin
 	return rows
 }
 
-func postPhotoQuadrantURLs(post domain.Post, now time.Time) [4]string {
+func postPhotoQuadrantURLs(photoBaseURL string, post domain.Post, now time.Time) [4]string {
 	s3Keys := [4]string{
 		strings.TrimSpace(post.ImageSource1),
 		strings.TrimSpace(post.ImageSource2),
@@ -205,27 +209,27 @@ func postPhotoQuadrantURLs(post domain.Post, now time.Time) [4]string {
 
 	var urls [4]string
 	for i := 0; i < len(urls); i++ {
-		if resolved := formatPostPhotoS3KeyURL(s3Keys[i], post, now); resolved != "" {
+		if resolved := formatPostPhotoS3KeyURL(photoBaseURL, s3Keys[i], post, now); resolved != "" {
 			urls[i] = resolved
 			continue
 		}
-		if resolved := formatPostPhotoS3KeyURL(legacyFiles[i], post, now); resolved != "" {
+		if resolved := formatPostPhotoS3KeyURL(photoBaseURL, legacyFiles[i], post, now); resolved != "" {
 			urls[i] = resolved
 			continue
 		}
 		if legacyFiles[i] != "" {
-			urls[i] = formatPostPhotoURL(post, i, now)
+			urls[i] = formatPostPhotoURL(photoBaseURL, post, i, now)
 		}
 	}
 	return urls
 }
 
-func formatPostPhotoURL(post domain.Post, index int, now time.Time) string {
+func formatPostPhotoURL(photoBaseURL string, post domain.Post, index int, now time.Time) string {
 	suffix := rune('a' + index)
-	return fmt.Sprintf("https://supost-prod.s3.amazonaws.com/posts/%d/post_%d%c?%d", post.ID, post.ID, suffix, postPhotoTimestampUnix(post, now))
+	return fmt.Sprintf("%s/posts/%d/post_%d%c?%d", resolvePhotoBaseURL(photoBaseURL), post.ID, post.ID, suffix, postPhotoTimestampUnix(post, now))
 }
 
-func formatPostPhotoS3KeyURL(value string, post domain.Post, now time.Time) string {
+func formatPostPhotoS3KeyURL(photoBaseURL string, value string, post domain.Post, now time.Time) string {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
 		return ""
@@ -238,7 +242,17 @@ func formatPostPhotoS3KeyURL(value string, post domain.Post, now time.Time) stri
 	if !strings.Contains(key, "/") {
 		return ""
 	}
-	return fmt.Sprintf("https://supost-prod.s3.amazonaws.com/%s?%d", key, postPhotoTimestampUnix(post, now))
+	return fmt.Sprintf("%s/%s?%d", resolvePhotoBaseURL(photoBaseURL), key, postPhotoTimestampUnix(post, now))
+}
+
+// resolvePhotoBaseURL trims a configured photo base URL, defaulting to the
+// production bucket host.
+func resolvePhotoBaseURL(photoBaseURL string) string {
+	base := strings.TrimRight(strings.TrimSpace(photoBaseURL), "/")
+	if base == "" {
+		return DefaultPhotoBaseURL
+	}
+	return base
 }
 
 func postPhotoTimestampUnix(post domain.Post, now time.Time) int64 {
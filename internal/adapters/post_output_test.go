@@ -15,13 +15,22 @@ func TestFormatPostPhotoURL_UsesPostPattern(t *testing.T) {
 		ID:         130031961,
 		TimePosted: 1772238444,
 	}
-	got := formatPostPhotoURL(post, 0, now)
+	got := formatPostPhotoURL("", post, 0, now)
 	want := "https://supost-prod.s3.amazonaws.com/posts/130031961/post_130031961a?1772238444"
 	if got != want {
 		t.Fatalf("got %q, want %q", got, want)
 	}
 }
 
+func TestFormatPostPhotoS3KeyURL_UsesConfiguredBase(t *testing.T) {
+	post := domain.Post{ID: 130031961, TimePosted: 1772238444}
+	got := formatPostPhotoS3KeyURL("http://localhost:9000/supost-dev", "/v2/posts/130031961/abc.jpg", post, time.Now())
+	want := "http://localhost:9000/supost-dev/v2/posts/130031961/abc.jpg?1772238444"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
 func TestPostPhotoQuadrantURLs_MapsThirdPhotoToBottomLeft(t *testing.T) {
 	now := time.Date(2026, time.February, 27, 14, 25, 0, 0, time.UTC)
 	post := domain.Post{
@@ -32,7 +41,7 @@ func TestPostPhotoQuadrantURLs_MapsThirdPhotoToBottomLeft(t *testing.T) {
 		Photo3File: "c.jpg",
 	}
 
-	urls := postPhotoQuadrantURLs(post, now)
+	urls := postPhotoQuadrantURLs("", post, now)
 	if urls[2] == "" {
 		t.Fatalf("expected third slot (bottom-left) to be populated")
 	}
@@ -49,7 +58,7 @@ func TestPostPhotoQuadrantURLs_UsesS3KeyWhenPresent(t *testing.T) {
 		ImageSource1: "v2/posts/130031961/abcd-efgh.jpg",
 	}
 
-	urls := postPhotoQuadrantURLs(post, now)
+	urls := postPhotoQuadrantURLs("", post, now)
 	want := "https://supost-prod.s3.amazonaws.com/v2/posts/130031961/abcd-efgh.jpg?1772238444"
 	if urls[0] != want {
 		t.Fatalf("got %q, want %q", urls[0], want)
@@ -73,7 +82,7 @@ func TestRenderPostPage_ContainsHeaderBodyAndPosterBox(t *testing.T) {
 		TimePostedAt: time.Date(2026, time.February, 27, 16, 26, 0, 0, time.UTC),
 	}
 
-	if err := RenderPostPage(&out, post); err != nil {
+	if err := RenderPostPage(&out, post, ""); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -106,7 +115,7 @@ func TestRenderPostPage_NonHousingSkipsHousingNotice(t *testing.T) {
 		TimePosted: 1772238444,
 	}
 
-	if err := RenderPostPage(&out, post); err != nil {
+	if err := RenderPostPage(&out, post, ""); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
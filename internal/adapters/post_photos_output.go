@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderPostPhotosResult renders a post's photo list after a `post photos`
+// action, one photo per line with its image URL.
+func RenderPostPhotosResult(w io.Writer, result domain.PostPhotosResult, photoBaseURL string) error {
+	base := resolvePhotoBaseURL(photoBaseURL)
+	lines := []string{
+		fmt.Sprintf("[%s] post photos", result.Action),
+		fmt.Sprintf("post_id: %d", result.PostID),
+		fmt.Sprintf("post_name: %s", result.PostName),
+		fmt.Sprintf("has_image: %t", result.HasImage),
+		fmt.Sprintf("photo_count: %d", len(result.Photos)),
+	}
+	for _, photo := range result.Photos {
+		lines = append(lines, fmt.Sprintf("  %d  %s/%s", photo.Position, base, photo.S3Key))
+	}
+	for _, photo := range result.Removed {
+		lines = append(lines, fmt.Sprintf("removed: %s", photo.S3Key))
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package adapters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestRenderPostPhotosResult_ListsPhotosWithURLs(t *testing.T) {
+	var out bytes.Buffer
+	err := RenderPostPhotosResult(&out, domain.PostPhotosResult{
+		Action:   "reorder",
+		PostID:   7,
+		PostName: "Desk",
+		HasImage: true,
+		Photos: []domain.PostCreateSavedPhoto{
+			{S3Key: "v2/posts/7/b.jpg", Position: 0},
+			{S3Key: "v2/posts/7/a.jpg", Position: 1},
+		},
+		Removed: []domain.PostCreateSavedPhoto{{S3Key: "v2/posts/7/c.jpg"}},
+	}, "http://localhost:8080/")
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	for _, want := range []string{
+		"[reorder] post photos",
+		"photo_count: 2",
+		"  0  http://localhost:8080/v2/posts/7/b.jpg",
+		"  1  http://localhost:8080/v2/posts/7/a.jpg",
+		"removed: v2/posts/7/c.jpg",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
@@ -18,13 +18,23 @@ func RenderPostRespondResult(w io.Writer, result domain.PostRespondResult) error
 		fmt.Sprintf("post_id: %d", result.PostID),
 		fmt.Sprintf("post_email: %s", result.PostEmail),
 		fmt.Sprintf("reply_to: %s", result.ReplyTo),
+	}
+	if result.ReplyRelay != "" {
+		lines = append(lines, fmt.Sprintf("reply_relay: %s", result.ReplyRelay))
+	}
+	lines = append(lines,
 		fmt.Sprintf("message_id: %d", result.MessageID),
+	)
+	if result.MailgunMessageID != "" {
+		lines = append(lines, fmt.Sprintf("mailgun_message_id: %s", result.MailgunMessageID))
+	}
+	lines = append(lines,
 		fmt.Sprintf("message_saved: %t", result.MessageSaved),
 		fmt.Sprintf("email_sent: %t", result.EmailSent),
 		fmt.Sprintf("subject: %s", result.Subject),
 		"",
 		result.Body,
-	}
+	)
 	for _, line := range lines {
 		if _, err := fmt.Fprintln(w, line); err != nil {
 			return err
@@ -0,0 +1,28 @@
+package adapters
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// RenderProfile renders `supost me profile show|update`.
+func RenderProfile(w io.Writer, profile domain.User) error {
+	lines := []string{
+		fmt.Sprintf("user_id: %s", profile.ID),
+		fmt.Sprintf("email: %s", profileValue(profile.Email)),
+		fmt.Sprintf("display_name: %s", profileValue(profile.DisplayName)),
+		fmt.Sprintf("phone: %s", profileValue(profile.Phone)),
+		fmt.Sprintf("created_at: %s", formatAuthTime(profile.CreatedAt)),
+		fmt.Sprintf("updated_at: %s", formatAuthTime(profile.UpdatedAt)),
+	}
+	return writeAuthLines(w, lines)
+}
+
+func profileValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestRenderProfile_ShowsFieldsAndDashForBlank(t *testing.T) {
+	var out bytes.Buffer
+	err := RenderProfile(&out, domain.User{
+		ID:          "user-1",
+		Email:       "pat@stanford.edu",
+		DisplayName: "Pat Lee",
+		CreatedAt:   time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	for _, want := range []string{
+		"user_id: user-1",
+		"display_name: Pat Lee",
+		"phone: -",
+		"created_at: 2026-10-19T12:00:00Z",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+}
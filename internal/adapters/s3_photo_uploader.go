@@ -3,19 +3,25 @@ package adapters
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"image"
+	"image/draw"
 	"image/gif"
 	"image/jpeg"
 	"image/png"
+	"net/url"
 	"path/filepath"
 	"strings"
 
 	"github.com/Capmus-Team/supost-cli/internal/domain"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // registers the WebP decoder with image.Decode
 )
 
 const (
@@ -28,18 +34,26 @@ const (
 
 // S3PostPhotoUploader stores post photos in S3 under v2/posts/{post_id}/{uuid}.{ext}.
 type S3PostPhotoUploader struct {
-	client *s3.Client
-	bucket string
-	prefix string
+	client       *s3.Client
+	bucket       string
+	prefix       string
+	keepMetadata bool
 }
 
 // NewS3PostPhotoUploader builds an uploader using AWS default credentials or an optional profile.
+// A non-blank endpoint targets an S3-compatible server (MinIO, LocalStack);
+// usePathStyle addresses buckets as {endpoint}/{bucket}/{key}. A static
+// access key/secret pair overrides the default credential chain.
 func NewS3PostPhotoUploader(
 	ctx context.Context,
 	region string,
 	bucket string,
 	prefix string,
 	profile string,
+	endpoint string,
+	usePathStyle bool,
+	accessKeyID string,
+	secretAccessKey string,
 ) (*S3PostPhotoUploader, error) {
 	region = strings.TrimSpace(region)
 	if region == "" {
@@ -55,25 +69,57 @@ func NewS3PostPhotoUploader(
 		prefix = defaultS3PhotoPrefix
 	}
 
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	if endpoint != "" {
+		parsed, err := url.Parse(endpoint)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("s3 photo endpoint %q must be an absolute URL", endpoint)
+		}
+	}
+	accessKeyID = strings.TrimSpace(accessKeyID)
+	secretAccessKey = strings.TrimSpace(secretAccessKey)
+	if (accessKeyID == "") != (secretAccessKey == "") {
+		return nil, fmt.Errorf("s3 photo access key id and secret access key must be set together")
+	}
+
 	loadOptions := []func(*awsconfig.LoadOptions) error{
 		awsconfig.WithRegion(region),
 	}
 	if trimmedProfile := strings.TrimSpace(profile); trimmedProfile != "" {
 		loadOptions = append(loadOptions, awsconfig.WithSharedConfigProfile(trimmedProfile))
 	}
+	if accessKeyID != "" {
+		loadOptions = append(loadOptions, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, ""),
+		))
+	}
 
 	cfg, err := awsconfig.LoadDefaultConfig(ctx, loadOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("loading aws config: %w", err)
 	}
 
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = usePathStyle
+	})
+
 	return &S3PostPhotoUploader{
-		client: s3.NewFromConfig(cfg),
+		client: client,
 		bucket: bucket,
 		prefix: prefix,
 	}, nil
 }
 
+// WithKeepMetadata keeps the source's EXIF/XMP/ICC metadata in uploaded
+// variants instead of stripping it. Intended for admin re-uploads only.
+func (u *S3PostPhotoUploader) WithKeepMetadata(keep bool) *S3PostPhotoUploader {
+	u.keepMetadata = keep
+	return u
+}
+
 // UploadPostPhoto uploads a single photo and returns the S3 metadata for public.photo.
 func (u *S3PostPhotoUploader) UploadPostPhoto(
 	ctx context.Context,
@@ -87,32 +133,16 @@ func (u *S3PostPhotoUploader) UploadPostPhoto(
 		return domain.PostCreateSavedPhoto{}, fmt.Errorf("photo content is empty")
 	}
 
-	decoded, formatName, err := image.Decode(bytes.NewReader(photo.Content))
+	variants, err := encodePostPhotoVariants(photo, u.keepMetadata)
 	if err != nil {
-		return domain.PostCreateSavedPhoto{}, fmt.Errorf("decoding image: %w", err)
+		return domain.PostCreateSavedPhoto{}, err
 	}
+	key, tickerKey := newPostPhotoKeys(u.prefix, postID, variants.ext)
 
-	contentType, ext := imageOutputFormat(formatName, photo.FileName, photo.ContentType)
-	postImage := resizeToMaxWidth(decoded, maxPostPhotoWidth)
-	tickerImage := resizeToMaxWidth(decoded, maxTickerPhotoWidth)
-
-	postBytes, err := encodeImageBytes(postImage, contentType)
-	if err != nil {
-		return domain.PostCreateSavedPhoto{}, fmt.Errorf("encoding post image: %w", err)
-	}
-	tickerBytes, err := encodeImageBytes(tickerImage, contentType)
-	if err != nil {
-		return domain.PostCreateSavedPhoto{}, fmt.Errorf("encoding ticker image: %w", err)
-	}
-
-	objectID := uuid.NewString()
-	key := fmt.Sprintf("%s/%d/%s%s", u.prefix, postID, objectID, ext)
-	tickerKey := fmt.Sprintf("%s/%d/ticker_%s%s", u.prefix, postID, objectID, ext)
-
-	if err := u.putObject(ctx, key, postBytes, contentType); err != nil {
+	if err := u.putObject(ctx, key, variants.post, variants.contentType); err != nil {
 		return domain.PostCreateSavedPhoto{}, fmt.Errorf("put object %q: %w", key, err)
 	}
-	if err := u.putObject(ctx, tickerKey, tickerBytes, contentType); err != nil {
+	if err := u.putObject(ctx, tickerKey, variants.ticker, variants.contentType); err != nil {
 		return domain.PostCreateSavedPhoto{}, fmt.Errorf("put object %q: %w", tickerKey, err)
 	}
 
@@ -124,6 +154,79 @@ func (u *S3PostPhotoUploader) UploadPostPhoto(
 	}, nil
 }
 
+// DeletePostPhoto removes both variants of an uploaded photo. S3 deletes are
+// idempotent, so already-missing keys are not an error.
+func (u *S3PostPhotoUploader) DeletePostPhoto(ctx context.Context, photo domain.PostCreateSavedPhoto) error {
+	for _, key := range []string{photo.S3Key, photo.TickerS3Key} {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if _, err := u.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(u.bucket),
+			Key:    aws.String(key),
+		}); err != nil {
+			return fmt.Errorf("delete object %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// postPhotoVariants holds the encoded post-size and ticker-size images.
+type postPhotoVariants struct {
+	contentType string
+	ext         string
+	post        []byte
+	ticker      []byte
+}
+
+// encodePostPhotoVariants decodes an upload and re-encodes the post and
+// ticker sizes shared by every photo storage backend. Metadata is stripped
+// from both variants unless keepMetadata is set.
+func encodePostPhotoVariants(photo domain.PostCreatePhotoUpload, keepMetadata bool) (postPhotoVariants, error) {
+	decoded, formatName, err := image.Decode(bytes.NewReader(photo.Content))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return postPhotoVariants{}, fmt.Errorf("decoding image: unsupported format (expected JPEG, PNG, GIF, or WebP)")
+		}
+		return postPhotoVariants{}, fmt.Errorf("decoding image: %w", err)
+	}
+
+	if formatName == "jpeg" {
+		decoded = applyEXIFOrientation(decoded, jpegEXIFOrientation(photo.Content))
+	}
+
+	contentType, ext := imageOutputFormat(formatName, photo.FileName, photo.ContentType)
+	postBytes, err := encodeImageBytes(resizeToMaxWidth(decoded, maxPostPhotoWidth), contentType)
+	if err != nil {
+		return postPhotoVariants{}, fmt.Errorf("encoding post image: %w", err)
+	}
+	tickerBytes, err := encodeImageBytes(resizeToMaxWidth(decoded, maxTickerPhotoWidth), contentType)
+	if err != nil {
+		return postPhotoVariants{}, fmt.Errorf("encoding ticker image: %w", err)
+	}
+	if postBytes, err = applyPhotoMetadataPolicy(postBytes, contentType, photo.Content, keepMetadata); err != nil {
+		return postPhotoVariants{}, fmt.Errorf("sanitizing post image metadata: %w", err)
+	}
+	if tickerBytes, err = applyPhotoMetadataPolicy(tickerBytes, contentType, photo.Content, keepMetadata); err != nil {
+		return postPhotoVariants{}, fmt.Errorf("sanitizing ticker image metadata: %w", err)
+	}
+	return postPhotoVariants{
+		contentType: contentType,
+		ext:         ext,
+		post:        postBytes,
+		ticker:      tickerBytes,
+	}, nil
+}
+
+// newPostPhotoKeys returns {prefix}/{post_id}/{uuid}{ext} and its ticker_ sibling.
+func newPostPhotoKeys(prefix string, postID int64, ext string) (string, string) {
+	objectID := uuid.NewString()
+	key := fmt.Sprintf("%s/%d/%s%s", prefix, postID, objectID, ext)
+	tickerKey := fmt.Sprintf("%s/%d/ticker_%s%s", prefix, postID, objectID, ext)
+	return key, tickerKey
+}
+
 func (u *S3PostPhotoUploader) putObject(ctx context.Context, key string, content []byte, contentType string) error {
 	_, err := u.client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(u.bucket),
@@ -134,6 +237,11 @@ func (u *S3PostPhotoUploader) putObject(ctx context.Context, key string, content
 	return err
 }
 
+// resizeToMaxWidth downscales src to maxWidth. Large sources are first
+// area-averaged by an integer factor to at most 2x the target (directly on the
+// YCbCr planes for JPEGs), then finished with a Catmull-Rom filter, which keeps
+// phone photos fast while avoiding point-sampling aliasing. Images already within
+// maxWidth are returned as is.
 func resizeToMaxWidth(src image.Image, maxWidth int) image.Image {
 	if maxWidth <= 0 {
 		return src
@@ -151,17 +259,144 @@ func resizeToMaxWidth(src image.Image, maxWidth int) image.Image {
 		dstH = 1
 	}
 
+	typed := typedPixelBuffer(src)
+	if factor := srcW / (2 * dstW); factor >= 2 {
+		if ycbcr, ok := typed.(*image.YCbCr); ok {
+			typed = boxShrinkYCbCr(ycbcr, factor)
+		} else {
+			typed = boxShrink(toRGBA(typed), factor)
+		}
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), typed, typed.Bounds(), xdraw.Src, nil)
+	return dst
+}
+
+// boxShrink averages factor x factor blocks of src into one pixel. Edge
+// blocks that do not fill a whole block average the pixels they cover.
+func boxShrink(src *image.RGBA, factor int) *image.RGBA {
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+	dstW := (srcW + factor - 1) / factor
+	dstH := (srcH + factor - 1) / factor
 	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
-	for y := 0; y < dstH; y++ {
-		srcY := bounds.Min.Y + (y * srcH / dstH)
-		for x := 0; x < dstW; x++ {
-			srcX := bounds.Min.X + (x * srcW / dstW)
-			dst.Set(x, y, src.At(srcX, srcY))
+	sums := make([]uint32, dstW*4)
+
+	for dy := 0; dy < dstH; dy++ {
+		clear(sums)
+		y0, y1 := dy*factor, min((dy+1)*factor, srcH)
+		for sy := y0; sy < y1; sy++ {
+			row := src.Pix[sy*src.Stride : sy*src.Stride+srcW*4]
+			for dx := 0; dx < dstW; dx++ {
+				s := sums[dx*4 : dx*4+4 : dx*4+4]
+				x1 := min((dx+1)*factor, srcW)
+				for i := dx * factor * 4; i < x1*4; i += 4 {
+					s[0] += uint32(row[i])
+					s[1] += uint32(row[i+1])
+					s[2] += uint32(row[i+2])
+					s[3] += uint32(row[i+3])
+				}
+			}
+		}
+		out := dst.Pix[dy*dst.Stride : dy*dst.Stride+dstW*4]
+		for dx := 0; dx < dstW; dx++ {
+			n := uint32((min((dx+1)*factor, srcW) - dx*factor) * (y1 - y0))
+			for c := 0; c < 4; c++ {
+				out[dx*4+c] = uint8((sums[dx*4+c] + n/2) / n)
+			}
 		}
 	}
 	return dst
 }
 
+// boxShrinkYCbCr area-averages a decoded JPEG directly on its Y/Cb/Cr planes
+// into a 4:4:4 image, skipping a full-resolution RGBA conversion.
+func boxShrinkYCbCr(src *image.YCbCr, factor int) *image.YCbCr {
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+	dstW := (srcW + factor - 1) / factor
+	dstH := (srcH + factor - 1) / factor
+	dst := image.NewYCbCr(image.Rect(0, 0, dstW, dstH), image.YCbCrSubsampleRatio444)
+
+	hx, vy := ycbcrSubsampling(src.SubsampleRatio)
+	chromaW := (srcW + hx - 1) / hx
+	chromaH := (srcH + vy - 1) / vy
+
+	shrinkPlane(dst.Y, dst.YStride, src.Y[src.YOffset(src.Rect.Min.X, src.Rect.Min.Y):], src.YStride, srcW, srcH, dstW, dstH, factor, 1, 1)
+	cOffset := src.COffset(src.Rect.Min.X, src.Rect.Min.Y)
+	shrinkPlane(dst.Cb, dst.CStride, src.Cb[cOffset:], src.CStride, chromaW, chromaH, dstW, dstH, factor, hx, vy)
+	shrinkPlane(dst.Cr, dst.CStride, src.Cr[cOffset:], src.CStride, chromaW, chromaH, dstW, dstH, factor, hx, vy)
+	return dst
+}
+
+// shrinkPlane averages one 8-bit plane. The plane may be subsampled by hx/vy
+// relative to luma; each output pixel covers factor luma pixels per axis.
+func shrinkPlane(dst []byte, dstStride int, src []byte, srcStride, planeW, planeH, dstW, dstH, factor, hx, vy int) {
+	sums := make([]uint32, dstW)
+	counts := make([]uint32, dstW)
+	for dy := 0; dy < dstH; dy++ {
+		clear(sums)
+		clear(counts)
+		py0 := dy * factor / vy
+		py1 := min(max(((dy+1)*factor+vy-1)/vy, py0+1), planeH)
+		for py := py0; py < py1; py++ {
+			row := src[py*srcStride : py*srcStride+planeW]
+			for dx := 0; dx < dstW; dx++ {
+				px0 := dx * factor / hx
+				px1 := min(max(((dx+1)*factor+hx-1)/hx, px0+1), planeW)
+				var sum uint32
+				for _, v := range row[px0:px1] {
+					sum += uint32(v)
+				}
+				sums[dx] += sum
+				counts[dx] += uint32(px1 - px0)
+			}
+		}
+		out := dst[dy*dstStride : dy*dstStride+dstW]
+		for dx := 0; dx < dstW; dx++ {
+			if counts[dx] > 0 {
+				out[dx] = uint8((sums[dx] + counts[dx]/2) / counts[dx])
+			}
+		}
+	}
+}
+
+func ycbcrSubsampling(ratio image.YCbCrSubsampleRatio) (int, int) {
+	switch ratio {
+	case image.YCbCrSubsampleRatio422:
+		return 2, 1
+	case image.YCbCrSubsampleRatio420:
+		return 2, 2
+	case image.YCbCrSubsampleRatio440:
+		return 1, 2
+	case image.YCbCrSubsampleRatio411:
+		return 4, 1
+	case image.YCbCrSubsampleRatio410:
+		return 4, 2
+	default:
+		return 1, 1
+	}
+}
+
+// typedPixelBuffer returns src when x/image/draw has a fast path for its
+// concrete type, otherwise copies it into an RGBA buffer once so the scaler
+// never falls back to per-pixel At/Set interface calls.
+func typedPixelBuffer(src image.Image) image.Image {
+	switch src.(type) {
+	case *image.RGBA, *image.NRGBA, *image.YCbCr, *image.Gray:
+		return src
+	}
+	return toRGBA(src)
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	if rgba, ok := src.(*image.RGBA); ok && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
 func imageOutputFormat(decodedFormat string, fileName string, suppliedContentType string) (contentType string, ext string) {
 	switch strings.ToLower(strings.TrimSpace(decodedFormat)) {
 	case "png":
@@ -170,6 +405,9 @@ func imageOutputFormat(decodedFormat string, fileName string, suppliedContentTyp
 		return "image/gif", ".gif"
 	case "jpeg":
 		return "image/jpeg", ".jpg"
+	case "webp":
+		// No pure-Go WebP encoder; transcode to JPEG.
+		return "image/jpeg", ".jpg"
 	}
 
 	ext = photoFileExtension(fileName, suppliedContentType)
@@ -195,13 +433,26 @@ func encodeImageBytes(img image.Image, contentType string) ([]byte, error) {
 			return nil, err
 		}
 	default:
-		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+		if err := jpeg.Encode(&buf, flattenOntoWhite(img), &jpeg.Options{Quality: 85}); err != nil {
 			return nil, err
 		}
 	}
 	return buf.Bytes(), nil
 }
 
+// flattenOntoWhite composites translucent images onto white before JPEG
+// encoding; the JPEG encoder would otherwise render transparent pixels black.
+func flattenOntoWhite(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	return dst
+}
+
 func photoFileExtension(fileName string, contentType string) string {
 	ext := strings.ToLower(strings.TrimSpace(filepath.Ext(strings.TrimSpace(fileName))))
 	if validPhotoExtension(ext) {
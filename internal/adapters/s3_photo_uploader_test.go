@@ -1,10 +1,19 @@
 package adapters
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"image"
+	"image/color"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
 )
 
 func TestPhotoFileExtension_FromFileName(t *testing.T) {
@@ -41,7 +50,7 @@ func TestValidPhotoExtension(t *testing.T) {
 }
 
 func TestNewS3PostPhotoUploader_RequiresBucket(t *testing.T) {
-	_, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "", "v2/posts", "")
+	_, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "", "v2/posts", "", "", false, "", "")
 	if err == nil {
 		t.Fatalf("expected bucket validation error")
 	}
@@ -50,6 +59,93 @@ func TestNewS3PostPhotoUploader_RequiresBucket(t *testing.T) {
 	}
 }
 
+func TestNewS3PostPhotoUploader_RequiresKeyPairAndAbsoluteEndpoint(t *testing.T) {
+	_, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "", "", "", false, "minio", "")
+	if err == nil || !strings.Contains(err.Error(), "set together") {
+		t.Fatalf("expected key pair validation error, got %v", err)
+	}
+	_, err = NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "", "", "localhost:9000", true, "", "")
+	if err == nil || !strings.Contains(err.Error(), "absolute URL") {
+		t.Fatalf("expected endpoint validation error, got %v", err)
+	}
+}
+
+func TestS3PostPhotoUploader_PathStyleEndpointWithStaticCredentials(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		putPaths []string
+		authz    []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		if r.Method == http.MethodPut {
+			putPaths = append(putPaths, r.URL.Path)
+			authz = append(authz, r.Header.Get("Authorization"))
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	uploader, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "v2/posts", "", server.URL, true, "minio-key", "minio-secret")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	saved, err := uploader.UploadPostPhoto(context.Background(), 42, domain.PostCreatePhotoUpload{
+		FileName: "bike.png",
+		Content:  testPNG(t, 64, 32),
+	})
+	if err != nil {
+		t.Fatalf("unexpected upload error: %v", err)
+	}
+
+	want := []string{"/supost-dev/" + saved.S3Key, "/supost-dev/" + saved.TickerS3Key}
+	if len(putPaths) != 2 || putPaths[0] != want[0] || putPaths[1] != want[1] {
+		t.Fatalf("expected path-style PUTs %v, got %v", want, putPaths)
+	}
+	for _, header := range authz {
+		if !strings.Contains(header, "Credential=minio-key/") {
+			t.Fatalf("expected static credentials in authorization header, got %q", header)
+		}
+	}
+}
+
+func TestS3PostPhotoUploader_DeletePostPhotoDeletesBothKeys(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		deletePaths []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		mu.Lock()
+		if r.Method == http.MethodDelete {
+			deletePaths = append(deletePaths, r.URL.Path)
+		}
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	uploader, err := NewS3PostPhotoUploader(context.Background(), "us-east-1", "supost-dev", "v2/posts", "", server.URL, true, "minio-key", "minio-secret")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	err = uploader.DeletePostPhoto(context.Background(), domain.PostCreateSavedPhoto{
+		PostID:      42,
+		S3Key:       "v2/posts/42/a.jpg",
+		TickerS3Key: "v2/posts/42/ticker_a.jpg",
+	})
+	if err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+
+	want := []string{"/supost-dev/v2/posts/42/a.jpg", "/supost-dev/v2/posts/42/ticker_a.jpg"}
+	if len(deletePaths) != 2 || deletePaths[0] != want[0] || deletePaths[1] != want[1] {
+		t.Fatalf("expected DELETEs %v, got %v", want, deletePaths)
+	}
+}
+
 func TestResizeToMaxWidth_Downscales(t *testing.T) {
 	src := image.NewRGBA(image.Rect(0, 0, 1000, 500))
 	out := resizeToMaxWidth(src, 340)
@@ -85,3 +181,219 @@ func TestImageOutputFormat_FallbacksToJpeg(t *testing.T) {
 		t.Fatalf("unexpected fallback mapping: %s %s", contentType, ext)
 	}
 }
+
+func TestImageOutputFormat_TranscodesWebPToJpeg(t *testing.T) {
+	contentType, ext := imageOutputFormat("webp", "photo.webp", "image/webp")
+	if contentType != "image/jpeg" || ext != ".jpg" {
+		t.Fatalf("unexpected webp mapping: %s %s", contentType, ext)
+	}
+}
+
+// testWebP builds a lossless (VP8L) WebP of one solid color. Each of the five
+// prefix codes is a single-symbol "simple" code, so pixel data takes no bits.
+func testWebP(width, height int, c color.NRGBA) []byte {
+	var (
+		bits  []byte
+		acc   uint64
+		nbits uint
+	)
+	write := func(value uint64, n uint) {
+		acc |= value << nbits
+		nbits += n
+		for nbits >= 8 {
+			bits = append(bits, byte(acc))
+			acc >>= 8
+			nbits -= 8
+		}
+	}
+	write(0x2f, 8)
+	write(uint64(width-1), 14)
+	write(uint64(height-1), 14)
+	write(1, 1) // alpha_is_used
+	write(0, 3) // version
+	write(0, 1) // no transforms
+	write(0, 1) // no color cache
+	write(0, 1) // no meta prefix codes
+	for _, symbol := range []uint8{c.G, c.R, c.B, c.A, 0} {
+		write(1, 1) // simple code
+		write(0, 1) // one symbol
+		write(1, 1) // 8-bit symbol
+		write(uint64(symbol), 8)
+	}
+	if nbits > 0 {
+		bits = append(bits, byte(acc))
+	}
+	if len(bits)%2 == 1 {
+		bits = append(bits, 0)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(4+8+len(bits)))
+	buf.WriteString("WEBPVP8L")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(bits)))
+	buf.Write(bits)
+	return buf.Bytes()
+}
+
+func TestEncodePostPhotoVariants_TranscodesWebPToJPEG(t *testing.T) {
+	variants, err := encodePostPhotoVariants(domain.PostCreatePhotoUpload{
+		FileName: "bike.webp",
+		Content:  testWebP(680, 340, color.NRGBA{R: 200, G: 40, B: 40, A: 255}),
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	if variants.contentType != "image/jpeg" || variants.ext != ".jpg" {
+		t.Fatalf("expected jpeg output, got %s %s", variants.contentType, variants.ext)
+	}
+	decoded, format, err := image.Decode(bytes.NewReader(variants.post))
+	if err != nil || format != "jpeg" {
+		t.Fatalf("expected jpeg post variant, got %q: %v", format, err)
+	}
+	if decoded.Bounds().Dx() != maxPostPhotoWidth {
+		t.Fatalf("expected width %d, got %d", maxPostPhotoWidth, decoded.Bounds().Dx())
+	}
+	r, g, b, _ := decoded.At(170, 85).RGBA()
+	if r>>8 < 180 || g>>8 > 70 || b>>8 > 70 {
+		t.Fatalf("expected red pixel, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestEncodePostPhotoVariants_FlattensTransparentWebPOntoWhite(t *testing.T) {
+	variants, err := encodePostPhotoVariants(domain.PostCreatePhotoUpload{
+		FileName: "logo.webp",
+		Content:  testWebP(64, 64, color.NRGBA{R: 0, G: 0, B: 0, A: 0}),
+	}, false)
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(variants.post))
+	if err != nil {
+		t.Fatalf("decoding post variant: %v", err)
+	}
+	r, g, b, _ := decoded.At(32, 32).RGBA()
+	if r>>8 < 245 || g>>8 < 245 || b>>8 < 245 {
+		t.Fatalf("expected transparent pixels flattened to white, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestEncodePostPhotoVariants_UnsupportedFormatError(t *testing.T) {
+	heic := append([]byte{0, 0, 0, 24}, []byte("ftypheic\x00\x00\x00\x00mif1heic")...)
+	_, err := encodePostPhotoVariants(domain.PostCreatePhotoUpload{FileName: "IMG_0001.HEIC", Content: heic}, false)
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+}
+
+// resizeNearestNeighbor is the previous point-sampling resize, kept as the
+// baseline for the quality test and benchmarks.
+func resizeNearestNeighbor(src image.Image, dstW, dstH int) image.Image {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		srcY := bounds.Min.Y + (y * bounds.Dy() / dstH)
+		for x := 0; x < dstW; x++ {
+			srcX := bounds.Min.X + (x * bounds.Dx() / dstW)
+			dst.Set(x, y, src.At(srcX, srcY))
+		}
+	}
+	return dst
+}
+
+// stripedPhoto is a high-frequency test pattern (1px stripes over a gradient)
+// that aliases badly under point sampling.
+func stripedPhoto(width, height int) *image.YCbCr {
+	img := image.NewYCbCr(image.Rect(0, 0, width, height), image.YCbCrSubsampleRatio420)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			luma := uint8(x * 200 / width)
+			if (x+y)%2 == 0 {
+				luma += 55
+			}
+			img.Y[img.YOffset(x, y)] = luma
+		}
+	}
+	for i := range img.Cb {
+		img.Cb[i], img.Cr[i] = 128, 128
+	}
+	return img
+}
+
+// downscaleError is the mean absolute luma difference between out and an
+// exact box-filter average of src over factor x factor blocks.
+func downscaleError(src *image.YCbCr, out image.Image, factor int) float64 {
+	var total float64
+	bounds := out.Bounds()
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			var sum int
+			for dy := 0; dy < factor; dy++ {
+				for dx := 0; dx < factor; dx++ {
+					sum += int(src.Y[src.YOffset(x*factor+dx, y*factor+dy)])
+				}
+			}
+			want := float64(sum) / float64(factor*factor)
+			r, g, b, _ := out.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			got := (0.299*float64(r>>8) + 0.587*float64(g>>8) + 0.114*float64(b>>8))
+			diff := got - want
+			if diff < 0 {
+				diff = -diff
+			}
+			total += diff
+		}
+	}
+	return total / float64(bounds.Dx()*bounds.Dy())
+}
+
+func TestResizeToMaxWidth_TracksAreaAverageBetterThanNearestNeighbor(t *testing.T) {
+	src := stripedPhoto(3400, 1700)
+	filtered := resizeToMaxWidth(src, 340)
+	nearest := resizeNearestNeighbor(src, 340, 170)
+
+	filteredErr := downscaleError(src, filtered, 10)
+	nearestErr := downscaleError(src, nearest, 10)
+	if filteredErr >= nearestErr/4 {
+		t.Fatalf("expected filtered error %.2f to be well below nearest-neighbor error %.2f", filteredErr, nearestErr)
+	}
+}
+
+func TestResizeToMaxWidth_ConvertsUntypedSources(t *testing.T) {
+	src := image.NewPaletted(image.Rect(0, 0, 680, 100), color.Palette{color.Black, color.White})
+	out := resizeToMaxWidth(src, 340)
+	if _, ok := out.(*image.RGBA); !ok || out.Bounds().Dx() != 340 {
+		t.Fatalf("expected 340px RGBA output, got %T %v", out, out.Bounds())
+	}
+}
+
+func BenchmarkResizeToMaxWidth_CatmullRom(b *testing.B) {
+	src := stripedPhoto(3024, 4032)
+	b.ReportAllocs()
+	b.ResetTimer()
+	var out image.Image
+	for i := 0; i < b.N; i++ {
+		out = resizeToMaxWidth(src, maxPostPhotoWidth)
+	}
+	b.StopTimer()
+	b.ReportMetric(downscaleErrorApprox(src, out), "mae")
+}
+
+func BenchmarkResizeToMaxWidth_NearestNeighbor(b *testing.B) {
+	src := stripedPhoto(3024, 4032)
+	dstH := 4032 * maxPostPhotoWidth / 3024
+	b.ReportAllocs()
+	b.ResetTimer()
+	var out image.Image
+	for i := 0; i < b.N; i++ {
+		out = resizeNearestNeighbor(src, maxPostPhotoWidth, dstH)
+	}
+	b.StopTimer()
+	b.ReportMetric(downscaleErrorApprox(src, out), "mae")
+}
+
+// downscaleErrorApprox compares against the box average over the integer part
+// of the scale factor, for non-integer benchmark ratios.
+func downscaleErrorApprox(src *image.YCbCr, out image.Image) float64 {
+	factor := src.Bounds().Dx() / out.Bounds().Dx()
+	return downscaleError(src, out, factor)
+}
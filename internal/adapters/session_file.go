@@ -0,0 +1,123 @@
+package adapters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	sessionFileMode = 0o600
+	sessionDirMode  = 0o700
+)
+
+// FileSessionStore keeps the logged-in Supabase session in a JSON file that
+// only the current user can read.
+type FileSessionStore struct {
+	path string
+}
+
+// NewFileSessionStore constructs a session store at path. A blank path
+// falls back to DefaultSessionPath.
+func NewFileSessionStore(path string) (*FileSessionStore, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		defaultPath, err := DefaultSessionPath()
+		if err != nil {
+			return nil, err
+		}
+		path = defaultPath
+	}
+	return &FileSessionStore{path: path}, nil
+}
+
+// DefaultSessionPath is <user config dir>/supost-cli/session.json. Without a
+// user config dir it fails rather than put tokens in a shared temp dir.
+func DefaultSessionPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating the session file (set AUTH_SESSION_FILE): %w", err)
+	}
+	return filepath.Join(configDir, "supost-cli", "session.json"), nil
+}
+
+// Path returns the session file location.
+func (s *FileSessionStore) Path() string {
+	return s.path
+}
+
+// LoadSession reads the stored session. It returns domain.ErrNotFound when
+// nobody is logged in, and refuses a file other users can read.
+func (s *FileSessionStore) LoadSession() (domain.AuthSession, error) {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return domain.AuthSession{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("reading session file: %w", err)
+	}
+	if err := checkSessionFileMode(s.path, info); err != nil {
+		return domain.AuthSession{}, err
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("reading session file: %w", err)
+	}
+	var session domain.AuthSession
+	if err := json.Unmarshal(content, &session); err != nil {
+		return domain.AuthSession{}, fmt.Errorf("parsing session file %s: %w", s.path, err)
+	}
+	return session, nil
+}
+
+// SaveSession writes the session with 0600 permissions via a temp file and
+// rename, so a concurrent reader never sees a partial file.
+func (s *FileSessionStore) SaveSession(session domain.AuthSession) error {
+	content, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding session: %w", err)
+	}
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, sessionDirMode); err != nil {
+		return fmt.Errorf("creating session directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".session-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating session temp file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	// CreateTemp already uses 0600; Chmod keeps that true regardless of umask quirks.
+	if err := tmp.Chmod(sessionFileMode); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("securing session file: %w", err)
+	}
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing session file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing session file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("saving session file: %w", err)
+	}
+	return nil
+}
+
+// DeleteSession removes the stored session. A missing file is not an error.
+func (s *FileSessionStore) DeleteSession() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing session file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package adapters
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestFileSessionStore_SaveLoadDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "supost-cli", "session.json")
+	store, err := NewFileSessionStore(path)
+	if err != nil {
+		t.Fatalf("unexpected store error: %v", err)
+	}
+
+	if _, err := store.LoadSession(); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before login, got %v", err)
+	}
+
+	session := domain.AuthSession{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		ExpiresAt:    time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC),
+		UserID:       "user-1",
+		Email:        "user@stanford.edu",
+	}
+	if err := store.SaveSession(session); err != nil {
+		t.Fatalf("unexpected save error: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil {
+		t.Fatalf("stat session file: %v", err)
+	} else if perm := info.Mode().Perm(); runtime.GOOS != "windows" && perm != 0o600 {
+		t.Fatalf("expected 0600 session file, got %04o", perm)
+	}
+
+	loaded, err := store.LoadSession()
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	if loaded.AccessToken != "access-1" || loaded.RefreshToken != "refresh-1" || !loaded.ExpiresAt.Equal(session.ExpiresAt) {
+		t.Fatalf("unexpected loaded session %+v", loaded)
+	}
+
+	if err := store.DeleteSession(); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if err := store.DeleteSession(); err != nil {
+		t.Fatalf("expected deleting a missing session to succeed, got %v", err)
+	}
+	if _, err := store.LoadSession(); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package adapters
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// checkSessionFileMode refuses a session file that group or others can
+// access.
+func checkSessionFileMode(path string, info fs.FileInfo) error {
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Errorf("session file %s has permissions %04o; run `chmod 600 %s` or log in again", path, perm, path)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package adapters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSessionStore_RefusesReadableSessionFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := os.WriteFile(path, []byte(`{"access_token":"access-1"}`), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+
+	store, err := NewFileSessionStore(path)
+	if err != nil {
+		t.Fatalf("unexpected store error: %v", err)
+	}
+	if _, err := store.LoadSession(); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+		t.Fatalf("expected a permissions error, got %v", err)
+	}
+}
+
+func TestFileSessionStore_DefaultPathNeedsConfigDir(t *testing.T) {
+	t.Setenv("HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	if path, err := DefaultSessionPath(); err == nil {
+		t.Fatalf("expected an error without a user config dir, got %q", path)
+	}
+	if _, err := NewFileSessionStore(""); err == nil || !strings.Contains(err.Error(), "AUTH_SESSION_FILE") {
+		t.Fatalf("expected the store to refuse a temp-dir fallback, got %v", err)
+	}
+}
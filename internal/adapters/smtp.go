@@ -0,0 +1,297 @@
+package adapters
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	SMTPSecurityNone     = "none"
+	SMTPSecurityStartTLS = "starttls"
+	SMTPSecurityTLS      = "tls"
+
+	SMTPAuthNone  = "none"
+	SMTPAuthPlain = "plain"
+	SMTPAuthLogin = "login"
+
+	defaultSMTPPort    = 587
+	defaultSMTPTimeout = 10 * time.Second
+)
+
+// SMTPSender sends publish and response emails through any SMTP server
+// (local catchers such as MailHog, or a relay like Mailgun/SES SMTP).
+type SMTPSender struct {
+	host        string
+	port        int
+	username    string
+	password    string
+	security    string
+	auth        string
+	defaultFrom string
+	timeout     time.Duration
+	tlsConfig   *tls.Config
+}
+
+// NewSMTPSender constructs an SMTP adapter.
+// security is one of none, starttls, tls; when blank it is inferred from the port.
+// authMechanism is one of none, plain, login; when blank it is plain if a username is set.
+func NewSMTPSender(
+	host string,
+	port int,
+	username string,
+	password string,
+	security string,
+	authMechanism string,
+	defaultFrom string,
+	timeout time.Duration,
+) (*SMTPSender, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return nil, fmt.Errorf("smtp host is required")
+	}
+	if port <= 0 {
+		port = defaultSMTPPort
+	}
+
+	security = strings.ToLower(strings.TrimSpace(security))
+	if security == "" {
+		security = defaultSMTPSecurityForPort(port)
+	}
+	switch security {
+	case SMTPSecurityNone, SMTPSecurityStartTLS, SMTPSecurityTLS:
+	default:
+		return nil, fmt.Errorf("unsupported smtp security %q (expected none, starttls, or tls)", security)
+	}
+
+	username = strings.TrimSpace(username)
+	authMechanism = strings.ToLower(strings.TrimSpace(authMechanism))
+	if authMechanism == "" {
+		authMechanism = SMTPAuthNone
+		if username != "" {
+			authMechanism = SMTPAuthPlain
+		}
+	}
+	switch authMechanism {
+	case SMTPAuthNone:
+	case SMTPAuthPlain, SMTPAuthLogin:
+		if username == "" {
+			return nil, fmt.Errorf("smtp username is required for %s auth", authMechanism)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported smtp auth %q (expected none, plain, or login)", authMechanism)
+	}
+
+	if strings.TrimSpace(defaultFrom) == "" {
+		defaultFrom = "response@mg.supost.com"
+	}
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+
+	return &SMTPSender{
+		host:        host,
+		port:        port,
+		username:    username,
+		password:    password,
+		security:    security,
+		auth:        authMechanism,
+		defaultFrom: strings.TrimSpace(defaultFrom),
+		timeout:     timeout,
+	}, nil
+}
+
+// SendPublishEmail sends one publish message (multipart when HTML is present).
+func (s *SMTPSender) SendPublishEmail(ctx context.Context, msg domain.PublishEmailMessage) error {
+	_, err := s.sendTextEmail(ctx, msg.From, msg.To, "", msg.Subject, msg.Text, msg.HTML)
+	return err
+}
+
+// SendResponseEmail sends one response message with Reply-To (multipart when
+// HTML is present) and returns its Message-ID.
+func (s *SMTPSender) SendResponseEmail(ctx context.Context, msg domain.ResponseEmailMessage) (string, error) {
+	return s.sendTextEmail(ctx, msg.From, msg.To, msg.ReplyTo, msg.Subject, msg.Text, msg.HTML)
+}
+
+func (s *SMTPSender) sendTextEmail(ctx context.Context, fromRaw, toRaw, replyToRaw, subjectRaw, textRaw, htmlRaw string) (string, error) {
+	from := strings.TrimSpace(fromRaw)
+	if from == "" {
+		from = s.defaultFrom
+	}
+	email := outgoingEmail{
+		From:      from,
+		To:        strings.TrimSpace(toRaw),
+		ReplyTo:   strings.TrimSpace(replyToRaw),
+		Subject:   strings.TrimSpace(subjectRaw),
+		Text:      strings.TrimSpace(textRaw),
+		HTML:      strings.TrimSpace(htmlRaw),
+		Date:      time.Now(),
+		MessageID: newMessageID(from),
+	}
+	if err := email.validate(); err != nil {
+		return "", fmt.Errorf("smtp message: %w", err)
+	}
+
+	envelopeFrom, err := envelopeAddress(email.From)
+	if err != nil {
+		return "", err
+	}
+	envelopeTo, err := envelopeAddress(email.To)
+	if err != nil {
+		return "", err
+	}
+	raw, err := email.bytes()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.deliver(ctx, envelopeFrom, envelopeTo, raw); err != nil {
+		return "", err
+	}
+	return normalizeProviderMessageID(email.MessageID), nil
+}
+
+func (s *SMTPSender) deliver(ctx context.Context, envelopeFrom, envelopeTo string, raw []byte) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	dialer := &net.Dialer{Timeout: s.timeout}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.security == SMTPSecurityTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.clientTLSConfig()}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("connecting to smtp server %s: %w", addr, err)
+	}
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("starting smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if s.security == SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", addr)
+		}
+		if err := client.StartTLS(s.clientTLSConfig()); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+
+	if auth := s.smtpAuth(); auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server %s does not support AUTH", addr)
+		}
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(envelopeFrom); err != nil {
+		return fmt.Errorf("smtp MAIL FROM: %w", err)
+	}
+	if err := client.Rcpt(envelopeTo); err != nil {
+		return fmt.Errorf("smtp RCPT TO: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA: %w", err)
+	}
+	if _, err := w.Write(raw); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("writing smtp message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp send failed: %w", err)
+	}
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("smtp QUIT: %w", err)
+	}
+	return nil
+}
+
+func (s *SMTPSender) clientTLSConfig() *tls.Config {
+	if s.tlsConfig != nil {
+		return s.tlsConfig.Clone()
+	}
+	return &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}
+}
+
+func (s *SMTPSender) smtpAuth() smtp.Auth {
+	switch s.auth {
+	case SMTPAuthPlain:
+		return smtp.PlainAuth("", s.username, s.password, s.host)
+	case SMTPAuthLogin:
+		return &smtpLoginAuth{host: s.host, username: s.username, password: s.password}
+	default:
+		return nil
+	}
+}
+
+func defaultSMTPSecurityForPort(port int) string {
+	switch port {
+	case 465:
+		return SMTPSecurityTLS
+	case 587:
+		return SMTPSecurityStartTLS
+	default:
+		return SMTPSecurityNone
+	}
+}
+
+// smtpLoginAuth implements the AUTH LOGIN mechanism, which net/smtp omits.
+type smtpLoginAuth struct {
+	host     string
+	username string
+	password string
+}
+
+func (a *smtpLoginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// Same guard as smtp.PlainAuth: never send credentials in clear text to a remote host.
+	if !server.TLS && !isLocalSMTPHost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *smtpLoginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(string(fromServer))) {
+	case "username:", "username":
+		return []byte(a.username), nil
+	case "password:", "password":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected smtp login challenge %q", string(fromServer))
+	}
+}
+
+func isLocalSMTPHost(host string) bool {
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
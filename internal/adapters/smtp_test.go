@@ -0,0 +1,372 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// fakeSMTPServer is a minimal in-process SMTP server for adapter tests.
+type fakeSMTPServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	startTLS  bool
+	username  string
+	password  string
+
+	mu       sync.Mutex
+	mailFrom string
+	rcptTo   []string
+	data     string
+	authUsed string
+	tlsUsed  bool
+}
+
+func newFakeSMTPServer(t *testing.T, implicitTLS bool, startTLS bool, username, password string) *fakeSMTPServer {
+	t.Helper()
+	srv := &fakeSMTPServer{
+		tlsConfig: selfSignedTLSConfig(t),
+		startTLS:  startTLS,
+		username:  username,
+		password:  password,
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	if implicitTLS {
+		listener = tls.NewListener(listener, srv.tlsConfig)
+		srv.tlsUsed = true
+	}
+	srv.listener = listener
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go srv.handle(conn)
+		}
+	}()
+	return srv
+}
+
+func (s *fakeSMTPServer) port(t *testing.T) int {
+	t.Helper()
+	_, rawPort, err := net.SplitHostPort(s.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("splitting listener addr: %v", err)
+	}
+	port, err := strconv.Atoi(rawPort)
+	if err != nil {
+		t.Fatalf("parsing listener port: %v", err)
+	}
+	return port
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	readLine := func() (string, bool) {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", false
+		}
+		return strings.TrimRight(line, "\r\n"), true
+	}
+
+	write("220 127.0.0.1 ESMTP fake")
+	for {
+		line, ok := readLine()
+		if !ok {
+			return
+		}
+		verb := strings.ToUpper(strings.Fields(line + " ")[0])
+		switch verb {
+		case "EHLO", "HELO":
+			write("250-127.0.0.1")
+			if s.startTLS {
+				write("250-STARTTLS")
+			}
+			if s.username != "" {
+				write("250-AUTH PLAIN LOGIN")
+			}
+			write("250 8BITMIME")
+		case "STARTTLS":
+			write("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+			s.mu.Lock()
+			s.tlsUsed = true
+			s.mu.Unlock()
+		case "AUTH":
+			fields := strings.Fields(line)
+			mechanism := strings.ToUpper(fields[1])
+			var user, pass string
+			switch mechanism {
+			case "PLAIN":
+				decoded, _ := base64.StdEncoding.DecodeString(fields[2])
+				parts := strings.Split(string(decoded), "\x00")
+				if len(parts) == 3 {
+					user, pass = parts[1], parts[2]
+				}
+			case "LOGIN":
+				write("334 " + base64.StdEncoding.EncodeToString([]byte("Username:")))
+				rawUser, _ := readLine()
+				decodedUser, _ := base64.StdEncoding.DecodeString(rawUser)
+				write("334 " + base64.StdEncoding.EncodeToString([]byte("Password:")))
+				rawPass, _ := readLine()
+				decodedPass, _ := base64.StdEncoding.DecodeString(rawPass)
+				user, pass = string(decodedUser), string(decodedPass)
+			}
+			if user != s.username || pass != s.password {
+				write("535 authentication failed")
+				continue
+			}
+			s.mu.Lock()
+			s.authUsed = mechanism
+			s.mu.Unlock()
+			write("235 authenticated")
+		case "MAIL":
+			s.mu.Lock()
+			s.mailFrom = smtpPathAddress(line)
+			s.mu.Unlock()
+			write("250 ok")
+		case "RCPT":
+			s.mu.Lock()
+			s.rcptTo = append(s.rcptTo, smtpPathAddress(line))
+			s.mu.Unlock()
+			write("250 ok")
+		case "DATA":
+			write("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, ok := readLine()
+				if !ok {
+					return
+				}
+				if dataLine == "." {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+				data.WriteString("\r\n")
+			}
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+			write("250 queued")
+		case "QUIT":
+			write("221 bye")
+			return
+		default:
+			write("250 ok")
+		}
+	}
+}
+
+func smtpPathAddress(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+func (s *fakeSMTPServer) snapshot() (mailFrom string, rcptTo []string, data string, authUsed string, tlsUsed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mailFrom, append([]string(nil), s.rcptTo...), s.data, s.authUsed, s.tlsUsed
+}
+
+func selfSignedTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+}
+
+func trustFakeSMTPServer(t *testing.T, sender *SMTPSender, srv *fakeSMTPServer) {
+	t.Helper()
+	cert, err := x509.ParseCertificate(srv.tlsConfig.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	sender.tlsConfig = &tls.Config{RootCAs: pool, ServerName: "127.0.0.1"}
+}
+
+func TestSMTPSender_SendPublishEmail_PlainNoAuth(t *testing.T) {
+	srv := newFakeSMTPServer(t, false, false, "", "")
+	sender, err := NewSMTPSender("127.0.0.1", srv.port(t), "", "", SMTPSecurityNone, "", "response@mg.supost.com", 2*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	err = sender.SendPublishEmail(context.Background(), domain.PublishEmailMessage{
+		To:      "wientjes@alumni.stanford.edu",
+		Subject: "SUpost - Publish your post! Test",
+		Text:    "Publish your post by pressing:\n\nhttps://supost.com/post/publish/token",
+	})
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	mailFrom, rcptTo, data, authUsed, _ := srv.snapshot()
+	if mailFrom != "response@mg.supost.com" {
+		t.Fatalf("unexpected MAIL FROM %q", mailFrom)
+	}
+	if len(rcptTo) != 1 || rcptTo[0] != "wientjes@alumni.stanford.edu" {
+		t.Fatalf("unexpected RCPT TO %v", rcptTo)
+	}
+	if authUsed != "" {
+		t.Fatalf("expected no auth, got %q", authUsed)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("parsing delivered message: %v", err)
+	}
+	if msg.Header.Get("Subject") != "SUpost - Publish your post! Test" {
+		t.Fatalf("unexpected subject %q", msg.Header.Get("Subject"))
+	}
+	if msg.Header.Get("Date") == "" || msg.Header.Get("Message-ID") == "" {
+		t.Fatalf("expected Date and Message-ID headers")
+	}
+	if !strings.Contains(data, "https://supost.com/post/publish/token") {
+		t.Fatalf("expected publish URL in delivered body: %q", data)
+	}
+}
+
+func TestSMTPSender_SendResponseEmail_StartTLSPlainAuth(t *testing.T) {
+	srv := newFakeSMTPServer(t, false, true, "mailer", "secret")
+	sender, err := NewSMTPSender("127.0.0.1", srv.port(t), "mailer", "secret", SMTPSecurityStartTLS, SMTPAuthPlain, "response@mg.supost.com", 2*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	trustFakeSMTPServer(t, sender, srv)
+
+	_, err = sender.SendResponseEmail(context.Background(), domain.ResponseEmailMessage{
+		To:      "owner@stanford.edu",
+		ReplyTo: "gwientjes@gmail.com",
+		Subject: "SUpost - gwientjes@gmail.com response: Looking for a buddy",
+		Text:    "Reply to: gwientjes@gmail.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	_, _, data, authUsed, tlsUsed := srv.snapshot()
+	if !tlsUsed {
+		t.Fatalf("expected STARTTLS upgrade")
+	}
+	if authUsed != "PLAIN" {
+		t.Fatalf("expected AUTH PLAIN, got %q", authUsed)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("parsing delivered message: %v", err)
+	}
+	if msg.Header.Get("Reply-To") != "gwientjes@gmail.com" {
+		t.Fatalf("missing Reply-To header, got %q", msg.Header.Get("Reply-To"))
+	}
+}
+
+func TestSMTPSender_SendPublishEmail_ImplicitTLSLoginAuth(t *testing.T) {
+	srv := newFakeSMTPServer(t, true, false, "mailer", "secret")
+	sender, err := NewSMTPSender("127.0.0.1", srv.port(t), "mailer", "secret", SMTPSecurityTLS, SMTPAuthLogin, "response@mg.supost.com", 2*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	trustFakeSMTPServer(t, sender, srv)
+
+	err = sender.SendPublishEmail(context.Background(), domain.PublishEmailMessage{
+		To:      "wientjes@alumni.stanford.edu",
+		Subject: "subject",
+		Text:    "body",
+	})
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+	if _, _, _, authUsed, _ := srv.snapshot(); authUsed != "LOGIN" {
+		t.Fatalf("expected AUTH LOGIN, got %q", authUsed)
+	}
+}
+
+func TestSMTPSender_SendPublishEmail_AuthFailure(t *testing.T) {
+	srv := newFakeSMTPServer(t, false, false, "mailer", "secret")
+	sender, err := NewSMTPSender("127.0.0.1", srv.port(t), "mailer", "wrong", SMTPSecurityNone, SMTPAuthPlain, "response@mg.supost.com", 2*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	err = sender.SendPublishEmail(context.Background(), domain.PublishEmailMessage{
+		To:      "wientjes@alumni.stanford.edu",
+		Subject: "subject",
+		Text:    "body",
+	})
+	if err == nil || !strings.Contains(err.Error(), "smtp auth") {
+		t.Fatalf("expected smtp auth error, got %v", err)
+	}
+}
+
+func TestNewSMTPSender_Validation(t *testing.T) {
+	if _, err := NewSMTPSender("", 25, "", "", "", "", "", 0); err == nil {
+		t.Fatalf("expected host validation error")
+	}
+	if _, err := NewSMTPSender("localhost", 25, "", "", "ssl3", "", "", 0); err == nil {
+		t.Fatalf("expected security validation error")
+	}
+	if _, err := NewSMTPSender("localhost", 25, "", "", "", SMTPAuthLogin, "", 0); err == nil {
+		t.Fatalf("expected username requirement for login auth")
+	}
+
+	sender, err := NewSMTPSender("localhost", 465, "", "", "", "", "", 0)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	if sender.security != SMTPSecurityTLS {
+		t.Fatalf("expected implicit TLS for port 465, got %q", sender.security)
+	}
+}
@@ -0,0 +1,283 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// SupabaseAuthSessionClient signs users in and manages their sessions
+// through the Supabase Auth token endpoints.
+type SupabaseAuthSessionClient struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+	now     func() time.Time
+}
+
+type supabaseTokenResponse struct {
+	AccessToken  string              `json:"access_token"`
+	TokenType    string              `json:"token_type"`
+	ExpiresIn    int64               `json:"expires_in"`
+	ExpiresAt    int64               `json:"expires_at"`
+	RefreshToken string              `json:"refresh_token"`
+	User         *supabaseSignupUser `json:"user"`
+}
+
+// supabaseAuthError covers the error shapes returned by GoTrue versions.
+type supabaseAuthError struct {
+	Msg              string `json:"msg"`
+	Message          string `json:"message"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+	ErrorCode        string `json:"error_code"`
+}
+
+// NewSupabaseAuthSessionClient builds a session client for Supabase Auth.
+func NewSupabaseAuthSessionClient(baseURL string, apiKey string) (*SupabaseAuthSessionClient, error) {
+	trimmedBaseURL := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if trimmedBaseURL == "" {
+		return nil, fmt.Errorf("supabase_url is required")
+	}
+	trimmedAPIKey := strings.TrimSpace(apiKey)
+	if trimmedAPIKey == "" {
+		return nil, fmt.Errorf("supabase publishable/anon key is required")
+	}
+	return &SupabaseAuthSessionClient{
+		baseURL: trimmedBaseURL,
+		apiKey:  trimmedAPIKey,
+		client:  &http.Client{Timeout: defaultSupabaseAuthTimeout},
+		now:     time.Now,
+	}, nil
+}
+
+// SignInWithPassword exchanges an email and password for a session through
+// POST /auth/v1/token?grant_type=password.
+func (c *SupabaseAuthSessionClient) SignInWithPassword(ctx context.Context, email string, password string) (domain.AuthSession, error) {
+	body, err := json.Marshal(map[string]string{"email": email, "password": password})
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("encoding password grant: %w", err)
+	}
+	return c.requestToken(ctx, "password", body)
+}
+
+// RefreshSession exchanges a refresh token for a new session through
+// POST /auth/v1/token?grant_type=refresh_token. Supabase rotates the
+// refresh token, so the returned session replaces the stored one.
+func (c *SupabaseAuthSessionClient) RefreshSession(ctx context.Context, refreshToken string) (domain.AuthSession, error) {
+	body, err := json.Marshal(map[string]string{"refresh_token": refreshToken})
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("encoding refresh grant: %w", err)
+	}
+	return c.requestToken(ctx, "refresh_token", body)
+}
+
+// SignOut revokes the session's refresh tokens through POST /auth/v1/logout.
+func (c *SupabaseAuthSessionClient) SignOut(ctx context.Context, accessToken string) error {
+	raw, statusCode, err := c.post(ctx, "/auth/v1/logout", accessToken, nil)
+	if err != nil {
+		return fmt.Errorf("sending logout request: %w", err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return supabaseAuthStatusError("logout", statusCode, raw)
+	}
+	return nil
+}
+
+// SendOTP sends a one-time code or magic link through POST /auth/v1/otp.
+// Login never creates accounts, so create_user is always false. Phone
+// codes go out over SMS.
+func (c *SupabaseAuthSessionClient) SendOTP(ctx context.Context, request domain.AuthOTPRequest) error {
+	payload := map[string]any{"create_user": false}
+	if request.Phone != "" {
+		payload["phone"] = request.Phone
+		payload["channel"] = "sms"
+	} else {
+		payload["email"] = request.Email
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding otp request: %w", err)
+	}
+
+	path := "/auth/v1/otp"
+	if request.RedirectTo != "" {
+		path += "?redirect_to=" + url.QueryEscape(request.RedirectTo)
+	}
+	raw, statusCode, err := c.post(ctx, path, "", body)
+	if err != nil {
+		return fmt.Errorf("sending otp request: %w", err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return supabaseAuthStatusError("otp", statusCode, raw)
+	}
+	return nil
+}
+
+// VerifyOTP exchanges a code or magic-link token hash for a session through
+// POST /auth/v1/verify.
+func (c *SupabaseAuthSessionClient) VerifyOTP(ctx context.Context, verification domain.AuthOTPVerification) (domain.AuthSession, error) {
+	body, err := json.Marshal(verification)
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("encoding otp verification: %w", err)
+	}
+	raw, statusCode, err := c.post(ctx, "/auth/v1/verify", "", body)
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("sending otp verification: %w", err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return domain.AuthSession{}, supabaseAuthStatusError("otp verification", statusCode, raw)
+	}
+	return c.parseTokenResponse(raw)
+}
+
+// GetUser returns the user an access token belongs to through
+// GET /auth/v1/user. Supabase checks the token's signature and expiry, so a
+// forged or revoked token is rejected with domain.ErrUnauthorized.
+func (c *SupabaseAuthSessionClient) GetUser(ctx context.Context, accessToken string) (domain.AuthIdentity, error) {
+	if strings.TrimSpace(accessToken) == "" {
+		return domain.AuthIdentity{}, fmt.Errorf("%w: access token is required", domain.ErrUnauthorized)
+	}
+	raw, statusCode, err := c.send(ctx, http.MethodGet, "/auth/v1/user", accessToken, nil)
+	if err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("sending user request: %w", err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return domain.AuthIdentity{}, supabaseAuthStatusError("user lookup", statusCode, raw)
+	}
+
+	var user struct {
+		supabaseSignupUser
+		Role             string         `json:"role"`
+		AppMetadata      map[string]any `json:"app_metadata"`
+		EmailConfirmedAt *time.Time     `json:"email_confirmed_at"`
+	}
+	if err := json.Unmarshal(raw, &user); err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("decoding user response: %w", err)
+	}
+	if strings.TrimSpace(user.ID) == "" {
+		return domain.AuthIdentity{}, fmt.Errorf("supabase user response has no id")
+	}
+	identity := domain.AuthIdentity{
+		UserID: strings.TrimSpace(user.ID),
+		Email:  strings.ToLower(strings.TrimSpace(user.Email)),
+		Phone:  strings.TrimSpace(user.Phone),
+		Role:   user.Role,
+	}
+	if user.EmailConfirmedAt != nil {
+		identity.EmailConfirmedAt = user.EmailConfirmedAt.UTC()
+	}
+	if role, ok := user.AppMetadata["role"].(string); ok {
+		identity.Admin = strings.EqualFold(strings.TrimSpace(role), "admin")
+	}
+	if name, ok := user.UserMetadata["display_name"].(string); ok {
+		identity.DisplayName = strings.TrimSpace(name)
+	}
+	if phone, ok := user.UserMetadata["phone"].(string); ok && identity.Phone == "" {
+		identity.Phone = strings.TrimSpace(phone)
+	}
+	return identity, nil
+}
+
+func (c *SupabaseAuthSessionClient) requestToken(ctx context.Context, grantType string, body []byte) (domain.AuthSession, error) {
+	raw, statusCode, err := c.post(ctx, "/auth/v1/token?grant_type="+grantType, "", body)
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("sending %s grant: %w", grantType, err)
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		return domain.AuthSession{}, supabaseAuthStatusError(grantType+" grant", statusCode, raw)
+	}
+	return c.parseTokenResponse(raw)
+}
+
+func (c *SupabaseAuthSessionClient) parseTokenResponse(raw []byte) (domain.AuthSession, error) {
+	var decoded supabaseTokenResponse
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return domain.AuthSession{}, fmt.Errorf("decoding token response: %w", err)
+	}
+	if strings.TrimSpace(decoded.AccessToken) == "" || strings.TrimSpace(decoded.RefreshToken) == "" {
+		return domain.AuthSession{}, fmt.Errorf("supabase token response has no access/refresh token")
+	}
+
+	expiresAt := time.Unix(decoded.ExpiresAt, 0)
+	if decoded.ExpiresAt == 0 {
+		expiresAt = c.now().Add(time.Duration(decoded.ExpiresIn) * time.Second)
+	}
+	session := domain.AuthSession{
+		AccessToken:  decoded.AccessToken,
+		RefreshToken: decoded.RefreshToken,
+		TokenType:    decoded.TokenType,
+		ExpiresAt:    expiresAt.UTC(),
+	}
+	if decoded.User != nil {
+		session.UserID = strings.TrimSpace(decoded.User.ID)
+		session.Email = strings.TrimSpace(decoded.User.Email)
+		session.Phone = strings.TrimSpace(decoded.User.Phone)
+	}
+	return session, nil
+}
+
+// post sends a JSON POST. bearer defaults to the API key; user-scoped calls
+// such as logout pass the session's access token instead.
+func (c *SupabaseAuthSessionClient) post(ctx context.Context, path string, bearer string, body []byte) ([]byte, int, error) {
+	return c.send(ctx, http.MethodPost, path, bearer, body)
+}
+
+func (c *SupabaseAuthSessionClient) send(ctx context.Context, method string, path string, bearer string, body []byte) ([]byte, int, error) {
+	if bearer == "" {
+		bearer = c.apiKey
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("apikey", c.apiKey)
+	req.Header.Set("Authorization", "Bearer "+bearer)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	raw, readErr := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if readErr != nil {
+		return nil, resp.StatusCode, fmt.Errorf("reading response body: %w", readErr)
+	}
+	return raw, resp.StatusCode, nil
+}
+
+// supabaseAuthStatusError turns a non-2xx Auth response into an error.
+// Rejected credentials and dead refresh tokens wrap domain.ErrUnauthorized.
+func supabaseAuthStatusError(action string, statusCode int, raw []byte) error {
+	var decoded supabaseAuthError
+	_ = json.Unmarshal(raw, &decoded)
+	reason := ""
+	for _, candidate := range []string{decoded.Msg, decoded.ErrorDescription, decoded.Message, decoded.Error} {
+		if reason = strings.TrimSpace(candidate); reason != "" {
+			break
+		}
+	}
+	if reason == "" {
+		reason = strings.TrimSpace(string(raw))
+	}
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return fmt.Errorf("supabase %s rejected: %w: %s", action, domain.ErrUnauthorized, reason)
+	}
+	return fmt.Errorf("supabase %s failed: status %d: %s", action, statusCode, reason)
+}
@@ -0,0 +1,205 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestSupabaseAuthSessionClient_PasswordAndRefreshGrants(t *testing.T) {
+	var grants []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/auth/v1/token" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("apikey") != "sb_publishable_test" {
+			http.Error(w, "missing apikey", http.StatusUnauthorized)
+			return
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		grant := r.URL.Query().Get("grant_type")
+		grants = append(grants, grant)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case grant == "password" && body["email"] == "user@stanford.edu" && body["password"] == "supost123!":
+			_, _ = w.Write([]byte(`{"access_token":"access-1","token_type":"bearer","expires_in":3600,"expires_at":1792406400,"refresh_token":"refresh-1","user":{"id":"user-1","email":"user@stanford.edu","phone":"16505551234"}}`))
+		case grant == "refresh_token" && body["refresh_token"] == "refresh-1":
+			_, _ = w.Write([]byte(`{"access_token":"access-2","token_type":"bearer","expires_in":3600,"refresh_token":"refresh-2"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid login credentials"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewSupabaseAuthSessionClient(server.URL, "sb_publishable_test")
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	fixedNow := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return fixedNow }
+
+	session, err := client.SignInWithPassword(context.Background(), "user@stanford.edu", "supost123!")
+	if err != nil {
+		t.Fatalf("unexpected password grant error: %v", err)
+	}
+	if session.AccessToken != "access-1" || session.RefreshToken != "refresh-1" || session.UserID != "user-1" || session.Phone != "16505551234" {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	if !session.ExpiresAt.Equal(time.Unix(1792406400, 0)) {
+		t.Fatalf("expected expires_at from the response, got %s", session.ExpiresAt)
+	}
+
+	refreshed, err := client.RefreshSession(context.Background(), "refresh-1")
+	if err != nil {
+		t.Fatalf("unexpected refresh grant error: %v", err)
+	}
+	if refreshed.AccessToken != "access-2" || refreshed.RefreshToken != "refresh-2" || !refreshed.ExpiresAt.Equal(fixedNow.Add(time.Hour)) {
+		t.Fatalf("expected rotated tokens expiring from expires_in, got %+v", refreshed)
+	}
+
+	_, err = client.SignInWithPassword(context.Background(), "user@stanford.edu", "wrong")
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected rejected credentials to wrap ErrUnauthorized, got %v", err)
+	}
+	if got := len(grants); got != 3 || grants[0] != "password" || grants[1] != "refresh_token" {
+		t.Fatalf("unexpected grants %v", grants)
+	}
+}
+
+func TestSupabaseAuthSessionClient_SignOutUsesAccessToken(t *testing.T) {
+	var gotPath, gotAuth, gotAPIKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotAPIKey = r.Header.Get("apikey")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewSupabaseAuthSessionClient(server.URL, "sb_publishable_test")
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	if err := client.SignOut(context.Background(), "access-1"); err != nil {
+		t.Fatalf("unexpected logout error: %v", err)
+	}
+	if gotPath != "/auth/v1/logout" || gotAuth != "Bearer access-1" || gotAPIKey != "sb_publishable_test" {
+		t.Fatalf("unexpected logout request path=%q auth=%q apikey=%q", gotPath, gotAuth, gotAPIKey)
+	}
+}
+
+func TestSupabaseAuthSessionClient_GetUserChecksAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/auth/v1/user" || r.Header.Get("apikey") != "sb_publishable_test" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") == "Bearer admin-1" {
+			_, _ = w.Write([]byte(`{"id":"user-2","email":"ops@stanford.edu","role":"authenticated","app_metadata":{"provider":"email","role":"admin"}}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":401,"error_code":"bad_jwt","msg":"invalid JWT: unable to parse or verify signature"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"user-1","email":"User@Stanford.edu","phone":"","role":"authenticated","email_confirmed_at":"2026-10-01T02:00:00-07:00","user_metadata":{"display_name":"Greg","phone":"+16505551234"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewSupabaseAuthSessionClient(server.URL, "sb_publishable_test")
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	identity, err := client.GetUser(context.Background(), "access-1")
+	if err != nil {
+		t.Fatalf("unexpected user lookup error: %v", err)
+	}
+	if identity.UserID != "user-1" || identity.Email != "user@stanford.edu" || identity.Role != "authenticated" ||
+		identity.DisplayName != "Greg" || identity.Phone != "+16505551234" || identity.Admin ||
+		!identity.EmailConfirmedAt.Equal(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+	admin, err := client.GetUser(context.Background(), "admin-1")
+	if err != nil || !admin.Admin || admin.Role != "authenticated" || !admin.EmailConfirmedAt.IsZero() {
+		t.Fatalf("expected app_metadata role admin to mark an admin, got %+v (%v)", admin, err)
+	}
+
+	if _, err := client.GetUser(context.Background(), "forged"); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected a rejected token to wrap ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestSupabaseAuthSessionClient_SendAndVerifyOTP(t *testing.T) {
+	type request struct {
+		path       string
+		redirectTo string
+		body       map[string]any
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding request body: %v", err)
+		}
+		requests = append(requests, request{path: r.URL.Path, redirectTo: r.URL.Query().Get("redirect_to"), body: body})
+		switch r.URL.Path {
+		case "/auth/v1/otp":
+			_, _ = w.Write([]byte(`{}`))
+		case "/auth/v1/verify":
+			if body["token"] != "123456" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"code":403,"error_code":"otp_expired","msg":"Token has expired or is invalid"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"access-1","token_type":"bearer","expires_in":3600,"refresh_token":"refresh-1","user":{"id":"user-1","phone":"16505551234"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewSupabaseAuthSessionClient(server.URL, "sb_publishable_test")
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+	if err := client.SendOTP(context.Background(), domain.AuthOTPRequest{Email: "user@stanford.edu", RedirectTo: "http://127.0.0.1:4000/auth/callback"}); err != nil {
+		t.Fatalf("unexpected email otp error: %v", err)
+	}
+	if err := client.SendOTP(context.Background(), domain.AuthOTPRequest{Phone: "+16505551234"}); err != nil {
+		t.Fatalf("unexpected sms otp error: %v", err)
+	}
+	session, err := client.VerifyOTP(context.Background(), domain.AuthOTPVerification{Type: domain.AuthOTPTypeSMS, Phone: "+16505551234", Token: "123456"})
+	if err != nil {
+		t.Fatalf("unexpected verify error: %v", err)
+	}
+	if session.AccessToken != "access-1" || session.UserID != "user-1" {
+		t.Fatalf("unexpected verified session %+v", session)
+	}
+	_, err = client.VerifyOTP(context.Background(), domain.AuthOTPVerification{Type: domain.AuthOTPTypeEmail, Email: "user@stanford.edu", Token: "000000"})
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected an expired code to wrap ErrUnauthorized, got %v", err)
+	}
+
+	emailOTP, smsOTP, verify := requests[0], requests[1], requests[2]
+	if emailOTP.path != "/auth/v1/otp" || emailOTP.redirectTo != "http://127.0.0.1:4000/auth/callback" || emailOTP.body["email"] != "user@stanford.edu" || emailOTP.body["create_user"] != false {
+		t.Fatalf("unexpected email otp request %+v", emailOTP)
+	}
+	if smsOTP.body["phone"] != "+16505551234" || smsOTP.body["channel"] != "sms" || smsOTP.body["email"] != nil {
+		t.Fatalf("unexpected sms otp request %+v", smsOTP)
+	}
+	if verify.path != "/auth/v1/verify" || verify.body["type"] != "sms" || verify.body["phone"] != "+16505551234" {
+		t.Fatalf("unexpected verify request %+v", verify)
+	}
+}
@@ -25,6 +25,7 @@ type SupabaseAuthSignupClient struct {
 
 type supabaseSignupRequest struct {
 	Email    string            `json:"email"`
+	Phone    string            `json:"phone,omitempty"`
 	Password string            `json:"password"`
 	Data     map[string]string `json:"data,omitempty"`
 }
@@ -56,6 +57,7 @@ type supabaseSignupUser struct {
 
 type supabaseAdminCreateUserRequest struct {
 	Email        string            `json:"email"`
+	Phone        string            `json:"phone,omitempty"`
 	Password     string            `json:"password"`
 	EmailConfirm bool              `json:"email_confirm"`
 	UserMetadata map[string]string `json:"user_metadata,omitempty"`
@@ -83,6 +85,7 @@ func NewSupabaseAuthSignupClient(baseURL string, apiKey string, adminKey string)
 func (c *SupabaseAuthSignupClient) SignUp(ctx context.Context, submission domain.UserSignupSubmission) (domain.UserSignupResult, error) {
 	payload := supabaseSignupRequest{
 		Email:    submission.Email,
+		Phone:    submission.Phone,
 		Password: submission.Password,
 		Data: map[string]string{
 			"display_name": submission.DisplayName,
@@ -117,6 +120,7 @@ func (c *SupabaseAuthSignupClient) SignUp(ctx context.Context, submission domain
 func (c *SupabaseAuthSignupClient) createUserViaAdmin(ctx context.Context, submission domain.UserSignupSubmission) (domain.UserSignupResult, error) {
 	payload := supabaseAdminCreateUserRequest{
 		Email:        submission.Email,
+		Phone:        submission.Phone,
 		Password:     submission.Password,
 		EmailConfirm: true,
 		UserMetadata: map[string]string{
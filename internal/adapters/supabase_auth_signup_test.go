@@ -60,6 +60,9 @@ func TestSupabaseAuthSignupClient_SignUp_SendsExpectedPayload(t *testing.T) {
 	if gotBody.Data["display_name"] != "Greg" || gotBody.Data["phone"] != "+16505551234" {
 		t.Fatalf("unexpected metadata payload: %+v", gotBody.Data)
 	}
+	if gotBody.Email != "user@example.com" || gotBody.Phone != "+16505551234" {
+		t.Fatalf("expected email and phone on the signup user, got %+v", gotBody)
+	}
 	if gotBody.Password != "password123" {
 		t.Fatalf("expected provided password in signup payload, got %q", gotBody.Password)
 	}
@@ -202,7 +205,7 @@ func TestSupabaseAuthSignupClient_SignUp_FallsBackToAdminCreateUserOnEmailRateLi
 	if adminAuth != "Bearer sb_secret_test" {
 		t.Fatalf("unexpected admin auth header: %q", adminAuth)
 	}
-	if adminBody.Email != "user@example.com" || adminBody.Password != "password123" || !adminBody.EmailConfirm {
+	if adminBody.Email != "user@example.com" || adminBody.Phone != "+16505551234" || adminBody.Password != "password123" || !adminBody.EmailConfirm {
 		t.Fatalf("unexpected admin payload: %+v", adminBody)
 	}
 	if adminBody.UserMetadata["display_name"] != "Greg" || adminBody.UserMetadata["phone"] != "+16505551234" {
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuthSession is a Supabase Auth session as stored between CLI runs.
+type AuthSession struct {
+	AccessToken  string    `json:"access_token" db:"-"`
+	RefreshToken string    `json:"refresh_token" db:"-"`
+	TokenType    string    `json:"token_type" db:"-"`
+	ExpiresAt    time.Time `json:"expires_at" db:"-"`
+	UserID       string    `json:"user_id" db:"-"`
+	Email        string    `json:"email" db:"-"`
+	Phone        string    `json:"phone,omitempty" db:"-"`
+}
+
+// AuthIdentity is the user decoded from a session's access token claims.
+type AuthIdentity struct {
+	UserID      string    `json:"user_id" db:"-"`
+	Email       string    `json:"email" db:"-"`
+	Phone       string    `json:"phone,omitempty" db:"-"`
+	Role        string    `json:"role" db:"-"`
+	DisplayName string    `json:"display_name,omitempty" db:"-"`
+	SessionID   string    `json:"session_id,omitempty" db:"-"`
+	IssuedAt    time.Time `json:"issued_at" db:"-"`
+	ExpiresAt   time.Time `json:"expires_at" db:"-"`
+	Refreshed   bool      `json:"refreshed" db:"-"`
+	// EmailConfirmedAt is set only from a Supabase user lookup; zero means
+	// the address was never confirmed.
+	EmailConfirmedAt time.Time `json:"email_confirmed_at,omitzero" db:"-"`
+	// Admin is set only from a Supabase user lookup whose app_metadata
+	// role is "admin"; users cannot edit app_metadata themselves.
+	Admin bool `json:"admin,omitempty" db:"-"`
+}
+
+// DatabaseClaims is who row level security sees: the Postgres role a
+// statement runs as and the JWT payload placed in request.jwt.claims.
+type DatabaseClaims struct {
+	Role   string          `json:"role" db:"-"`
+	UserID string          `json:"user_id,omitempty" db:"-"`
+	Claims json.RawMessage `json:"claims" db:"-"`
+}
+
+// AuthLoginResult is the command output for a completed login. Tokens are
+// deliberately left out.
+type AuthLoginResult struct {
+	UserID      string    `json:"user_id" db:"-"`
+	Email       string    `json:"email" db:"-"`
+	Phone       string    `json:"phone,omitempty" db:"-"`
+	ExpiresAt   time.Time `json:"expires_at" db:"-"`
+	SessionFile string    `json:"session_file" db:"-"`
+	// ProfileCreated is set when this login created the user's profile row.
+	ProfileCreated bool `json:"profile_created,omitempty" db:"-"`
+}
+
+// AuthLogoutResult is the command output for logout. RevokeError is set
+// when the local session was removed but Supabase could not revoke it.
+type AuthLogoutResult struct {
+	LoggedOut   bool   `json:"logged_out" db:"-"`
+	Email       string `json:"email,omitempty" db:"-"`
+	Revoked     bool   `json:"revoked" db:"-"`
+	RevokeError string `json:"revoke_error,omitempty" db:"-"`
+}
+
+// OTP verification types accepted by Supabase Auth /verify.
+const (
+	AuthOTPTypeEmail     = "email"
+	AuthOTPTypeSMS       = "sms"
+	AuthOTPTypeMagicLink = "magiclink"
+)
+
+// AuthOTPRequest asks Supabase Auth to send a one-time code or magic link
+// to exactly one of Email or Phone. RedirectTo is where a magic link lands.
+type AuthOTPRequest struct {
+	Email      string `json:"email,omitempty" db:"-"`
+	Phone      string `json:"phone,omitempty" db:"-"`
+	RedirectTo string `json:"redirect_to,omitempty" db:"-"`
+}
+
+// AuthOTPVerification completes an OTP login. Token is the code the user
+// typed (with Email or Phone); TokenHash comes from a magic link instead.
+type AuthOTPVerification struct {
+	Type      string `json:"type" db:"-"`
+	Email     string `json:"email,omitempty" db:"-"`
+	Phone     string `json:"phone,omitempty" db:"-"`
+	Token     string `json:"token,omitempty" db:"-"`
+	TokenHash string `json:"token_hash,omitempty" db:"-"`
+}
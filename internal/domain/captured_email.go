@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// CapturedEmail is one outgoing message written to the local file mail sink.
+type CapturedEmail struct {
+	ID        string    `json:"id" db:"-"`
+	MessageID string    `json:"message_id" db:"-"`
+	From      string    `json:"from" db:"-"`
+	To        string    `json:"to" db:"-"`
+	ReplyTo   string    `json:"reply_to" db:"-"`
+	Subject   string    `json:"subject" db:"-"`
+	Date      time.Time `json:"date" db:"-"`
+	Text      string    `json:"text" db:"-"`
+	HTML      string    `json:"html,omitempty" db:"-"`
+	Path      string    `json:"path" db:"-"`
+}
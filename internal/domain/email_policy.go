@@ -0,0 +1,42 @@
+package domain
+
+// EmailDomainRules lists the email domains a college accepts. Allowed
+// domains match exactly, allowed suffixes match any subdomain, and blocked
+// domains match the domain and its subdomains and win over both.
+type EmailDomainRules struct {
+	Name            string   `json:"name,omitempty" db:"-"`
+	Hint            string   `json:"hint,omitempty" db:"-"`
+	AllowedDomains  []string `json:"allowed_domains" db:"-"`
+	AllowedSuffixes []string `json:"allowed_suffixes" db:"-"`
+	BlockedDomains  []string `json:"blocked_domains" db:"-"`
+}
+
+// EmailDomainPolicy configures affiliation checks for signup, post create,
+// and email login. Rules extend the built-in Stanford rules; a Colleges
+// entry for College replaces each list it sets.
+type EmailDomainPolicy struct {
+	College  string                      `json:"college" db:"-"`
+	Rules    EmailDomainRules            `json:"rules" db:"-"`
+	Colleges map[string]EmailDomainRules `json:"colleges,omitempty" db:"-"`
+}
+
+// Email policy rule names reported by EmailPolicyDecision.
+const (
+	EmailPolicyRuleInvalid       = "invalid"
+	EmailPolicyRuleBlockedDomain = "blocked_domain"
+	EmailPolicyRuleAllowedDomain = "allowed_domain"
+	EmailPolicyRuleAllowedSuffix = "allowed_suffix"
+	EmailPolicyRuleNoMatch       = "no_match"
+)
+
+// EmailPolicyDecision is the outcome of checking one address. Match is the
+// list entry that decided it.
+type EmailPolicyDecision struct {
+	Email   string `json:"email" db:"-"`
+	Domain  string `json:"domain" db:"-"`
+	College string `json:"college" db:"-"`
+	Allowed bool   `json:"allowed" db:"-"`
+	Rule    string `json:"rule" db:"-"`
+	Match   string `json:"match,omitempty" db:"-"`
+	Reason  string `json:"reason" db:"-"`
+}
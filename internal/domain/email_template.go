@@ -0,0 +1,32 @@
+package domain
+
+// PublishEmailData is the template input for publish-link emails.
+type PublishEmailData struct {
+	PostName   string `json:"post_name" db:"-"`
+	PublishURL string `json:"publish_url" db:"-"`
+	PostedAt   string `json:"posted_at" db:"-"`
+	SafetyURL  string `json:"safety_url" db:"-"`
+}
+
+// ResponseEmailData is the template input for post response emails.
+type ResponseEmailData struct {
+	ReplyTo      string `json:"reply_to" db:"-"`
+	Message      string `json:"message" db:"-"`
+	PostName     string `json:"post_name" db:"-"`
+	PostedAt     string `json:"posted_at" db:"-"`
+	PublishURL   string `json:"publish_url" db:"-"`
+	PostURL      string `json:"post_url" db:"-"`
+	SafetyURL    string `json:"safety_url" db:"-"`
+	ContactEmail string `json:"contact_email" db:"-"`
+}
+
+// EmailPreview is one rendered email (subject + text + HTML) for `mail preview`.
+type EmailPreview struct {
+	Kind    string `json:"kind" db:"-"`
+	PostID  int64  `json:"post_id" db:"-"`
+	To      string `json:"to" db:"-"`
+	ReplyTo string `json:"reply_to,omitempty" db:"-"`
+	Subject string `json:"subject" db:"-"`
+	Text    string `json:"text" db:"-"`
+	HTML    string `json:"html" db:"-"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// Message delivery statuses stored in app_private.message.status.
+const (
+	MessageStatusQueued       = "queued"
+	MessageStatusDelivered    = "delivered"
+	MessageStatusFailed       = "failed"
+	MessageStatusComplained   = "complained"
+	MessageStatusUnsubscribed = "unsubscribed"
+)
+
+// MailEvent is one delivery event reported by Mailgun's events webhook.
+type MailEvent struct {
+	Event      string    `json:"event" db:"-"`
+	Severity   string    `json:"severity,omitempty" db:"-"`
+	MessageID  string    `json:"message_id" db:"-"`
+	Recipient  string    `json:"recipient" db:"-"`
+	OccurredAt time.Time `json:"occurred_at" db:"-"`
+}
+
+// MailEventResult is the outcome of applying one delivery event.
+type MailEventResult struct {
+	Event     string `json:"event" db:"-"`
+	MessageID int64  `json:"message_id,omitempty" db:"-"`
+	Status    string `json:"status,omitempty" db:"-"`
+	Applied   bool   `json:"applied" db:"-"`
+	Reason    string `json:"reason,omitempty" db:"-"`
+}
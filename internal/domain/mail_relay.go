@@ -0,0 +1,33 @@
+package domain
+
+// RelayDirection says which party a relay address delivers to.
+type RelayDirection string
+
+const (
+	// RelayToPoster delivers to the post owner (address handed to the responder).
+	RelayToPoster RelayDirection = "p"
+	// RelayToResponder delivers to the responder (address handed to the post owner).
+	RelayToResponder RelayDirection = "r"
+)
+
+// InboundEmail is one message received on a relay address (Mailgun inbound route).
+type InboundEmail struct {
+	Recipient string `json:"recipient" db:"-"`
+	Sender    string `json:"sender" db:"-"`
+	From      string `json:"from" db:"-"`
+	Subject   string `json:"subject" db:"-"`
+	Text      string `json:"text" db:"-"`
+	HTML      string `json:"html" db:"-"`
+}
+
+// InboundRelayResult is the outcome of forwarding one inbound reply.
+type InboundRelayResult struct {
+	MessageID   int64          `json:"message_id" db:"-"`
+	PostID      int64          `json:"post_id" db:"-"`
+	Direction   RelayDirection `json:"direction" db:"-"`
+	ForwardedTo string         `json:"forwarded_to" db:"-"`
+	ReplyTo     string         `json:"reply_to" db:"-"`
+	// SenderMatched is whether From or Sender named the participant the
+	// relay address was issued to. Headers can be forged: informational only.
+	SenderMatched bool `json:"sender_matched" db:"-"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// InboxMessage is a response as its post's owner sees it. The responder's
+// IP and user agent stay out of the owner's view.
+type InboxMessage struct {
+	ID        int64     `json:"id" db:"id"`
+	PostID    int64     `json:"post_id" db:"post_id"`
+	ReplyTo   string    `json:"reply_to" db:"email"`
+	Message   string    `json:"message" db:"message"`
+	Status    string    `json:"status" db:"status"`
+	Scammed   bool      `json:"scammed" db:"scammed"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// MessageThread is one post and the responses it received, oldest first.
+type MessageThread struct {
+	PostID     int64          `json:"post_id" db:"-"`
+	PostName   string         `json:"post_name" db:"-"`
+	PostStatus string         `json:"post_status" db:"-"`
+	Messages   []InboxMessage `json:"messages" db:"-"`
+}
+
+// MyMessagesResult is the inbox for `supost me messages`. PostID is set
+// when the inbox was narrowed to one post.
+type MyMessagesResult struct {
+	Email        string          `json:"email" db:"-"`
+	PostID       int64           `json:"post_id,omitempty" db:"-"`
+	MessageCount int             `json:"message_count" db:"-"`
+	Threads      []MessageThread `json:"threads" db:"-"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+// MyPostsResult lists the posts owned by the logged-in user.
+type MyPostsResult struct {
+	Email string `json:"email" db:"-"`
+	Posts []Post `json:"posts" db:"-"`
+}
+
+// PostEdit holds the fields an owner changes with `me posts edit`. Empty
+// Name or Body keeps the current value; Price applies only when
+// PriceProvided is set.
+type PostEdit struct {
+	Name          string  `json:"name,omitempty" db:"name"`
+	Body          string  `json:"body,omitempty" db:"body"`
+	Price         float64 `json:"price,omitempty" db:"price"`
+	PriceProvided bool    `json:"price_provided,omitempty" db:"-"`
+}
+
+// MyPostActionResult is the outcome of publish, edit, or delete on an owned post.
+type MyPostActionResult struct {
+	Action   string  `json:"action" db:"-"`
+	PostID   int64   `json:"post_id" db:"-"`
+	PostName string  `json:"post_name" db:"-"`
+	Status   string  `json:"status" db:"-"`
+	Price    float64 `json:"price,omitempty" db:"-"`
+	HasPrice bool    `json:"has_price" db:"-"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+// PasswordPolicy configures signup password checks. Zero values use the
+// service defaults (8 characters, 3 character classes, 16-character
+// passphrases exempt from classes, 28 bits of estimated entropy).
+type PasswordPolicy struct {
+	MinLength           int     `json:"min_length" db:"-"`
+	MinCharacterClasses int     `json:"min_character_classes" db:"-"`
+	PassphraseLength    int     `json:"passphrase_length" db:"-"`
+	MinEntropyBits      float64 `json:"min_entropy_bits" db:"-"`
+}
+
+// Password rule names reported by PasswordProblem.
+const (
+	PasswordRuleLength        = "length"
+	PasswordRuleClasses       = "character_classes"
+	PasswordRuleCommon        = "common_password"
+	PasswordRuleEmail         = "contains_email"
+	PasswordRuleDisplayName   = "contains_display_name"
+	PasswordRuleEntropy       = "entropy"
+	PasswordRuleCommonVariant = "common_variant"
+)
+
+// PasswordProblem is one failed password rule and its message.
+type PasswordProblem struct {
+	Rule    string `json:"rule" db:"-"`
+	Message string `json:"message" db:"-"`
+}
+
+// PasswordCheckResult is the outcome of checking a password. EntropyBits is
+// log2 of the estimated guesses an attacker needs.
+type PasswordCheckResult struct {
+	EntropyBits   float64           `json:"entropy_bits" db:"-"`
+	CommonVariant bool              `json:"common_variant" db:"-"`
+	Problems      []PasswordProblem `json:"problems" db:"-"`
+}
+
+// OK reports whether the password passed every rule.
+func (r PasswordCheckResult) OK() bool {
+	return len(r.Problems) == 0
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// LegacyPhotoPost is a post that still carries legacy photo columns
+// (image_source1..4 / photo1..4_file_name), with the number of public.photo
+// rows it already has.
+type LegacyPhotoPost struct {
+	PostID         int64     `json:"post_id" db:"id"`
+	ImageSources   [4]string `json:"image_sources" db:"-"`
+	PhotoFileNames [4]string `json:"photo_file_names" db:"-"`
+	PhotoRowCount  int       `json:"photo_row_count" db:"photo_row_count"`
+}
+
+// PhotoBackfillCheckpoint records how far a backfill job has progressed.
+// Posts with id <= LastPostID have been processed.
+type PhotoBackfillCheckpoint struct {
+	Job            string    `json:"job" db:"job_name"`
+	LastPostID     int64     `json:"last_post_id" db:"last_post_id"`
+	ProcessedPosts int64     `json:"processed_posts" db:"processed_posts"`
+	InsertedPhotos int64     `json:"inserted_photos" db:"-"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// PhotoBackfillOptions configures one `admin photos backfill` run.
+// MaxBatches 0 runs until no legacy posts remain past the checkpoint.
+type PhotoBackfillOptions struct {
+	Job        string `json:"job" db:"-"`
+	BatchSize  int    `json:"batch_size" db:"-"`
+	MaxBatches int    `json:"max_batches" db:"-"`
+	DryRun     bool   `json:"dry_run" db:"-"`
+	Verify     bool   `json:"verify" db:"-"`
+}
+
+// PhotoBackfillBatch is the progress of a single batch.
+type PhotoBackfillBatch struct {
+	Batch             int   `json:"batch" db:"-"`
+	FirstPostID       int64 `json:"first_post_id" db:"-"`
+	LastPostID        int64 `json:"last_post_id" db:"-"`
+	SelectedPosts     int   `json:"selected_posts" db:"-"`
+	AlreadyBackfilled int   `json:"already_backfilled" db:"-"`
+	PreparedPhotos    int   `json:"prepared_photos" db:"-"`
+	InsertedPhotos    int   `json:"inserted_photos" db:"-"`
+	MissingObjects    int   `json:"missing_objects" db:"-"`
+}
+
+// PhotoBackfillReport summarizes a backfill run. Done is true once a batch
+// selected no posts, i.e. the job has caught up.
+type PhotoBackfillReport struct {
+	Job               string                  `json:"job" db:"-"`
+	DryRun            bool                    `json:"dry_run" db:"-"`
+	Verified          bool                    `json:"verified" db:"-"`
+	Batches           int                     `json:"batches" db:"-"`
+	SelectedPosts     int                     `json:"selected_posts" db:"-"`
+	AlreadyBackfilled int                     `json:"already_backfilled" db:"-"`
+	PreparedPhotos    int                     `json:"prepared_photos" db:"-"`
+	InsertedPhotos    int                     `json:"inserted_photos" db:"-"`
+	MissingObjects    []string                `json:"missing_objects" db:"-"`
+	Checkpoint        PhotoBackfillCheckpoint `json:"checkpoint" db:"-"`
+	Done              bool                    `json:"done" db:"-"`
+}
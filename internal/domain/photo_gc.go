@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// PhotoObject is one stored object under the photo key prefix.
+type PhotoObject struct {
+	Key          string    `json:"key" db:"-"`
+	Size         int64     `json:"size" db:"-"`
+	LastModified time.Time `json:"last_modified" db:"-"`
+}
+
+// PhotoObjectPage is one page of a photo storage listing. A blank
+// NextPageToken means the listing is complete.
+type PhotoObjectPage struct {
+	Objects       []PhotoObject `json:"objects" db:"-"`
+	NextPageToken string        `json:"next_page_token,omitempty" db:"-"`
+}
+
+// PhotoGCOptions configures one garbage collection pass over photo storage.
+type PhotoGCOptions struct {
+	DryRun      bool          `json:"dry_run" db:"-"`
+	GracePeriod time.Duration `json:"grace_period" db:"-"`
+	PageSize    int           `json:"page_size" db:"-"`
+}
+
+// PhotoGCReport summarizes a garbage collection pass. Orphans lists every
+// unreferenced object past the grace period; with DryRun nothing is deleted.
+type PhotoGCReport struct {
+	DryRun       bool          `json:"dry_run" db:"-"`
+	Prefix       string        `json:"prefix" db:"-"`
+	GracePeriod  time.Duration `json:"grace_period" db:"-"`
+	Pages        int           `json:"pages" db:"-"`
+	Scanned      int           `json:"scanned" db:"-"`
+	Referenced   int           `json:"referenced" db:"-"`
+	TooRecent    int           `json:"too_recent" db:"-"`
+	Orphans      []PhotoObject `json:"orphans" db:"-"`
+	OrphanBytes  int64         `json:"orphan_bytes" db:"-"`
+	Deleted      int           `json:"deleted" db:"-"`
+	DeleteErrors []string      `json:"delete_errors,omitempty" db:"-"`
+	StartedAt    time.Time     `json:"started_at" db:"-"`
+	FinishedAt   time.Time     `json:"finished_at" db:"-"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// PhotoLimits configures submit-time photo validation. Zero values fall back
+// to the service defaults; a negative DuplicateWindow disables the check
+// against the poster's recent posts.
+type PhotoLimits struct {
+	MaxBytes        int64         `json:"max_bytes" db:"-"`
+	MinWidth        int           `json:"min_width" db:"-"`
+	MinHeight       int           `json:"min_height" db:"-"`
+	MaxWidth        int           `json:"max_width" db:"-"`
+	MaxHeight       int           `json:"max_height" db:"-"`
+	MaxPixels       int64         `json:"max_pixels" db:"-"`      // width × height, bounds decode memory
+	AllowedFormats  []string      `json:"allowed_formats" db:"-"` // jpeg, png, gif, webp
+	DuplicateWindow time.Duration `json:"duplicate_window" db:"-"`
+}
+
+// PhotoFingerprint is a stored photo's perceptual hash, used to spot the
+// same picture re-uploaded across posts.
+type PhotoFingerprint struct {
+	PostID         int64  `json:"post_id" db:"post_id"`
+	Position       int    `json:"position" db:"position"`
+	PerceptualHash uint64 `json:"perceptual_hash" db:"perceptual_hash"`
+}
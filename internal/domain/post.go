@@ -1,12 +1,39 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 const (
+	// PostStatusPending matches public.post.status = 0 (created, not yet published).
+	PostStatusPending = 0
 	// PostStatusActive matches public.post.status = 1.
 	PostStatusActive = 1
+	// PostStatusExpired matches public.post.status = 2 (no longer listed,
+	// still visible to its owner).
+	PostStatusExpired = 2
+	// PostStatusDeleted matches public.post.status = -1 (deleted by its
+	// owner; the row stays for messages that reference it).
+	PostStatusDeleted = -1
 )
 
+// PostStatusLabel names a post status for output.
+func PostStatusLabel(status int) string {
+	switch status {
+	case PostStatusPending:
+		return "pending"
+	case PostStatusActive:
+		return "active"
+	case PostStatusExpired:
+		return "expired"
+	case PostStatusDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("status %d", status)
+	}
+}
+
 // Post maps to the Supabase public.post table.
 type Post struct {
 	ID             int64     `json:"id" db:"id"`
@@ -23,14 +23,17 @@ type PostCreatePhotoUpload struct {
 	ContentType string `json:"content_type" db:"-"`
 	Content     []byte `json:"content" db:"-"`
 	Position    int    `json:"position" db:"position"`
+	// PerceptualHash is computed during validation (64-bit dHash).
+	PerceptualHash uint64 `json:"perceptual_hash,omitempty" db:"-"`
 }
 
 // PostCreateSavedPhoto is the persisted photo row payload for public.photo.
 type PostCreateSavedPhoto struct {
-	PostID      int64  `json:"post_id" db:"post_id"`
-	S3Key       string `json:"s3_key" db:"s3_key"`
-	TickerS3Key string `json:"ticker_s3_key" db:"ticker_s3_key"`
-	Position    int    `json:"position" db:"position"`
+	PostID         int64  `json:"post_id" db:"post_id"`
+	S3Key          string `json:"s3_key" db:"s3_key"`
+	TickerS3Key    string `json:"ticker_s3_key" db:"ticker_s3_key"`
+	Position       int    `json:"position" db:"position"`
+	PerceptualHash uint64 `json:"perceptual_hash,omitempty" db:"perceptual_hash"`
 }
 
 // PostCreatePersisted is the DB return payload after insert.
@@ -46,6 +49,7 @@ type PublishEmailMessage struct {
 	To      string `json:"to" db:"-"`
 	Subject string `json:"subject" db:"-"`
 	Text    string `json:"text" db:"-"`
+	HTML    string `json:"html,omitempty" db:"-"`
 }
 
 // PostCreateSubmitResult is the command output for submission mode.
@@ -61,4 +65,5 @@ type PostCreateSubmitResult struct {
 	PhotoS3Keys []string  `json:"photo_s3_keys" db:"-"`
 	Subject     string    `json:"subject" db:"-"`
 	Body        string    `json:"body" db:"-"`
+	HTMLBody    string    `json:"html_body,omitempty" db:"-"`
 }
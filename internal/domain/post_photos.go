@@ -0,0 +1,17 @@
+package domain
+
+// MaxPostPhotos is the number of photo slots on a post (positions 0..3).
+const MaxPostPhotos = 4
+
+// PostPhotosResult is the photo list of one post after a `post photos`
+// action. Photos are ordered by position, which is always contiguous from 0.
+type PostPhotosResult struct {
+	Action   string                 `json:"action" db:"-"`
+	PostID   int64                  `json:"post_id" db:"post_id"`
+	PostName string                 `json:"post_name" db:"-"`
+	HasImage bool                   `json:"has_image" db:"has_image"`
+	Photos   []PostCreateSavedPhoto `json:"photos" db:"-"`
+	// Removed lists photos dropped by remove/replace; their storage objects
+	// have been deleted.
+	Removed []PostCreateSavedPhoto `json:"removed,omitempty" db:"-"`
+}
@@ -18,18 +18,22 @@ type ResponseEmailMessage struct {
 	ReplyTo string `json:"reply_to" db:"-"`
 	Subject string `json:"subject" db:"-"`
 	Text    string `json:"text" db:"-"`
+	HTML    string `json:"html,omitempty" db:"-"`
 }
 
 // PostRespondResult is the command output for post response sends.
 type PostRespondResult struct {
-	DryRun       bool      `json:"dry_run" db:"-"`
-	PostID       int64     `json:"post_id" db:"-"`
-	PostEmail    string    `json:"post_email" db:"-"`
-	ReplyTo      string    `json:"reply_to" db:"-"`
-	MessageID    int64     `json:"message_id" db:"-"`
-	MessageSaved bool      `json:"message_saved" db:"-"`
-	EmailSent    bool      `json:"email_sent" db:"-"`
-	Subject      string    `json:"subject" db:"-"`
-	Body         string    `json:"body" db:"-"`
-	SentAt       time.Time `json:"sent_at" db:"-"`
+	DryRun           bool      `json:"dry_run" db:"-"`
+	PostID           int64     `json:"post_id" db:"-"`
+	PostEmail        string    `json:"post_email" db:"-"`
+	ReplyTo          string    `json:"reply_to" db:"-"`
+	ReplyRelay       string    `json:"reply_relay,omitempty" db:"-"`
+	MessageID        int64     `json:"message_id" db:"-"`
+	MailgunMessageID string    `json:"mailgun_message_id,omitempty" db:"-"`
+	MessageSaved     bool      `json:"message_saved" db:"-"`
+	EmailSent        bool      `json:"email_sent" db:"-"`
+	Subject          string    `json:"subject" db:"-"`
+	Body             string    `json:"body" db:"-"`
+	HTMLBody         string    `json:"html_body,omitempty" db:"-"`
+	SentAt           time.Time `json:"sent_at" db:"-"`
 }
@@ -2,13 +2,23 @@ package domain
 
 import "time"
 
-// User maps to the Supabase "profiles" table.
+// User maps to the Supabase "profiles" table, one row per Auth user.
 // TypeScript equivalent: interface User { id: string; email: string; ... }
 // Keep types plain — string, int, time.Time, []string — for TypeScript portability.
 type User struct {
-	ID        string    `json:"id"         db:"id"`
-	Email     string    `json:"email"      db:"email"`
-	Name      string    `json:"name"       db:"name"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID          string    `json:"id"           db:"id"`
+	Email       string    `json:"email"        db:"email"`
+	DisplayName string    `json:"display_name" db:"display_name"`
+	Phone       string    `json:"phone"        db:"phone"`
+	CreatedAt   time.Time `json:"created_at"   db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"   db:"updated_at"`
+}
+
+// ProfileUpdate is an edit from `supost me profile update`. Only fields
+// marked provided change; an empty provided Phone clears it.
+type ProfileUpdate struct {
+	DisplayName         string `json:"display_name,omitempty" db:"-"`
+	DisplayNameProvided bool   `json:"-" db:"-"`
+	Phone               string `json:"phone,omitempty" db:"-"`
+	PhoneProvided       bool   `json:"-" db:"-"`
 }
@@ -0,0 +1,689 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// conformanceStore is the surface every data backend serves to home, post,
+// categories, search, post create, and post respond.
+type conformanceStore interface {
+	HomePostStore
+	PostStore
+	CategoryStore
+	SearchActivePosts(ctx context.Context, query string, categoryID, subcategoryID int64, page, perPage int) ([]domain.Post, bool, error)
+	CreatePendingPost(ctx context.Context, submission domain.PostCreateSubmission) (domain.PostCreatePersisted, error)
+	SavePostPhotos(ctx context.Context, photos []domain.PostCreateSavedPhoto) error
+	DeletePendingPost(ctx context.Context, postID int64) error
+	ListRecentPhotoFingerprints(ctx context.Context, email string, since time.Time) ([]domain.PhotoFingerprint, error)
+	CreateResponseMessage(ctx context.Context, postID int64, replyToEmail, message, ip, userAgent string) (domain.Message, error)
+	SetMessageMailgunID(ctx context.Context, messageID int64, mailgunMessageID string) error
+}
+
+// conformanceWriter is what seeding fixtures and reading them back needs
+// beyond conformanceStore.
+type conformanceWriter interface {
+	PublishOwnedPost(ctx context.Context, postID int64, email string, at time.Time) error
+	ListPostPhotos(ctx context.Context, postID int64) ([]domain.PostCreateSavedPhoto, error)
+	GetMessageByID(ctx context.Context, messageID int64) (domain.Message, error)
+	GetMessageByMailgunID(ctx context.Context, mailgunMessageID string) (domain.Message, error)
+	UpdateMessageStatus(ctx context.Context, messageID int64, status string) (domain.Message, error)
+	ListMessagesForPosts(ctx context.Context, postIDs []int64) ([]domain.Message, error)
+}
+
+var (
+	_ conformanceStore  = (*InMemory)(nil)
+	_ conformanceStore  = (*Postgres)(nil)
+	_ conformanceStore  = (*SupabaseREST)(nil)
+	_ conformanceWriter = (*InMemory)(nil)
+	_ conformanceWriter = (*Postgres)(nil)
+)
+
+// conformanceBackend is one adapter under the conformance suite.
+type conformanceBackend struct {
+	store conformanceStore
+	// writer publishes fixture posts and reads back what store wrote. It is
+	// the store itself except for REST, where Postgres on the same project
+	// stands in for what the publishable key cannot do.
+	writer conformanceWriter
+	// removePost deletes a fixture post and its messages; nil when the
+	// store is thrown away after the test.
+	removePost func(t *testing.T, postID int64)
+	// anonymous stores run without a signed-in user, so owner-scoped
+	// lookups (recent photo fingerprints) come back empty.
+	anonymous bool
+}
+
+// runConformance runs the same behavioral cases against one adapter. The
+// invariants hold for whatever data the backend already has; the fixture
+// cases seed their own posts, timed in the future and tagged with a word
+// unique to the run, so they stay the newest posts and are the only search
+// matches even in a shared database.
+func runConformance(t *testing.T, backend conformanceBackend) {
+	t.Run("invariants", func(t *testing.T) {
+		conformanceInvariants(t, backend.store)
+	})
+	t.Run("create and photos", func(t *testing.T) {
+		conformanceCreateAndPhotos(t, backend)
+	})
+
+	fixtures := seedConformanceFixtures(t, backend)
+	t.Run("ordering", func(t *testing.T) {
+		conformanceOrdering(t, backend.store, fixtures)
+	})
+	t.Run("pagination", func(t *testing.T) {
+		conformancePagination(t, backend.store, fixtures)
+	})
+	t.Run("category filters", func(t *testing.T) {
+		conformanceCategoryFilters(t, backend.store, fixtures)
+	})
+	t.Run("full text search", func(t *testing.T) {
+		conformanceFullTextSearch(t, backend.store, fixtures)
+	})
+	t.Run("photo positions", func(t *testing.T) {
+		conformancePhotoPositions(t, backend, fixtures)
+	})
+	t.Run("message persistence", func(t *testing.T) {
+		conformanceMessages(t, backend, fixtures)
+	})
+}
+
+func conformanceInvariants(t *testing.T, store conformanceStore) {
+	ctx := t.Context()
+
+	categories, err := store.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("listing categories: %v", err)
+	}
+	for i := 1; i < len(categories); i++ {
+		if categories[i-1].ID >= categories[i].ID {
+			t.Fatalf("expected categories ordered by id, got %d before %d", categories[i-1].ID, categories[i].ID)
+		}
+	}
+	subcategories, err := store.ListSubcategories(ctx)
+	if err != nil {
+		t.Fatalf("listing subcategories: %v", err)
+	}
+	for i := 1; i < len(subcategories); i++ {
+		prev, cur := subcategories[i-1], subcategories[i]
+		if prev.CategoryID > cur.CategoryID || (prev.CategoryID == cur.CategoryID && prev.ID >= cur.ID) {
+			t.Fatalf("expected subcategories ordered by category then id, got %+v before %+v", prev, cur)
+		}
+	}
+
+	posts, err := store.ListRecentActivePosts(ctx, 5)
+	if err != nil {
+		t.Fatalf("listing recent posts: %v", err)
+	}
+	if len(posts) > 5 {
+		t.Fatalf("expected at most 5 posts, got %d", len(posts))
+	}
+	requireActiveNewestFirst(t, "recent posts", posts)
+
+	if _, err := store.GetPostByID(ctx, -1); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing post, got %v", err)
+	}
+
+	sections, err := store.ListHomeCategorySections(ctx)
+	if err != nil {
+		t.Fatalf("listing home sections: %v", err)
+	}
+	sectionFor := make(map[int64]bool, len(sections))
+	for _, section := range sections {
+		sectionFor[section.CategoryID] = true
+	}
+
+	if len(posts) == 0 {
+		return
+	}
+	newest := posts[0]
+	post, err := store.GetPostByID(ctx, newest.ID)
+	if err != nil || post.ID != newest.ID || post.Name != newest.Name || post.CategoryID != newest.CategoryID {
+		t.Fatalf("expected post %d by id to match the listing, got %+v, %v", newest.ID, post, err)
+	}
+	if !sectionFor[newest.CategoryID] {
+		t.Fatalf("expected a home section for category %d of the newest post", newest.CategoryID)
+	}
+
+	byCategory, err := store.ListRecentActivePostsByCategory(ctx, newest.CategoryID, 5)
+	if err != nil {
+		t.Fatalf("listing posts by category: %v", err)
+	}
+	requireActiveNewestFirst(t, "posts by category", byCategory)
+	if len(byCategory) == 0 || byCategory[0].ID != newest.ID {
+		t.Fatalf("expected the newest post to lead its category, got %+v", byCategory)
+	}
+	for _, p := range byCategory {
+		if p.CategoryID != newest.CategoryID {
+			t.Fatalf("expected only category %d, got post %d in %d", newest.CategoryID, p.ID, p.CategoryID)
+		}
+	}
+
+	first, hasMore, err := store.SearchActivePosts(ctx, "", 0, 0, 1, 1)
+	if err != nil || len(first) != 1 || first[0].ID != newest.ID {
+		t.Fatalf("expected search page 1 to start with post %d, got %+v, %v", newest.ID, first, err)
+	}
+	if hasMore != (len(posts) > 1) {
+		t.Fatalf("expected hasMore %v with %d listed posts", len(posts) > 1, len(posts))
+	}
+	if len(posts) > 1 {
+		second, _, err := store.SearchActivePosts(ctx, "", 0, 0, 2, 1)
+		if err != nil || len(second) != 1 || second[0].ID != posts[1].ID {
+			t.Fatalf("expected search page 2 to hold post %d, got %+v, %v", posts[1].ID, second, err)
+		}
+	}
+}
+
+func requireActiveNewestFirst(t *testing.T, what string, posts []domain.Post) {
+	t.Helper()
+	for i, post := range posts {
+		if post.Status != domain.PostStatusActive {
+			t.Fatalf("%s: expected only active posts, got %d with status %d", what, post.ID, post.Status)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := posts[i-1]
+		if prev.TimePosted < post.TimePosted || (prev.TimePosted == post.TimePosted && prev.ID < post.ID) {
+			t.Fatalf("%s: expected newest first, got %d (%d) before %d (%d)", what, prev.ID, prev.TimePosted, post.ID, post.TimePosted)
+		}
+	}
+}
+
+// conformanceSubmission builds a pending post in the first subcategory, so
+// the Postgres foreign keys hold.
+func conformanceSubmission(t *testing.T, store conformanceStore, name string) domain.PostCreateSubmission {
+	t.Helper()
+	subcategories, err := store.ListSubcategories(t.Context())
+	if err != nil {
+		t.Fatalf("listing subcategories: %v", err)
+	}
+	if len(subcategories) == 0 {
+		t.Skip("backend has no subcategories to post in")
+	}
+	stamp := time.Now().UnixNano()
+	return domain.PostCreateSubmission{
+		CategoryID:    subcategories[0].CategoryID,
+		SubcategoryID: subcategories[0].ID,
+		Email:         fmt.Sprintf("conformance+%d@stanford.edu", stamp),
+		Name:          fmt.Sprintf("%s %d", name, stamp),
+		Body:          "Created by the repository conformance tests.",
+		AccessToken:   fmt.Sprintf("conformance-%d", stamp),
+		PostedAt:      time.Now().Truncate(time.Second),
+		Price:         25,
+		PriceProvided: true,
+	}
+}
+
+func conformanceCreateAndPhotos(t *testing.T, backend conformanceBackend) {
+	ctx := t.Context()
+	store := backend.store
+	submission := conformanceSubmission(t, store, "conformance create")
+
+	persisted, err := store.CreatePendingPost(ctx, submission)
+	if err != nil {
+		t.Fatalf("creating pending post: %v", err)
+	}
+	if persisted.PostID <= 0 || persisted.AccessToken != submission.AccessToken || !persisted.PostedAt.Equal(submission.PostedAt) {
+		t.Fatalf("expected the persisted id, token, and time, got %+v", persisted)
+	}
+	removed := false
+	t.Cleanup(func() {
+		if !removed {
+			_ = store.DeletePendingPost(context.Background(), persisted.PostID)
+		}
+	})
+
+	post, err := store.GetPostByID(ctx, persisted.PostID)
+	if err != nil {
+		t.Fatalf("reading the pending post: %v", err)
+	}
+	if post.Status != domain.PostStatusPending || post.Name != submission.Name || post.Body != submission.Body ||
+		!post.HasPrice || post.Price != 25 || post.HasImage {
+		t.Fatalf("expected the pending post as submitted, got %+v", post)
+	}
+	recent, err := store.ListRecentActivePosts(ctx, maxRecentActivePosts)
+	if err != nil {
+		t.Fatalf("listing recent posts: %v", err)
+	}
+	for _, p := range recent {
+		if p.ID == persisted.PostID {
+			t.Fatalf("expected the pending post left out of the active listing")
+		}
+	}
+
+	photos := []domain.PostCreateSavedPhoto{
+		{PostID: persisted.PostID, S3Key: "conformance/second.jpg", Position: 1, PerceptualHash: 1<<63 | 7},
+		{PostID: persisted.PostID, S3Key: "conformance/first.jpg", TickerS3Key: "conformance/first_ticker.jpg", Position: 0, PerceptualHash: 42},
+	}
+	if err := store.SavePostPhotos(ctx, photos); err != nil {
+		t.Fatalf("saving photos: %v", err)
+	}
+	if err := store.SavePostPhotos(ctx, []domain.PostCreateSavedPhoto{
+		{PostID: persisted.PostID, S3Key: "conformance/first-replaced.jpg", Position: 0, PerceptualHash: 43},
+	}); err != nil {
+		t.Fatalf("replacing a photo position: %v", err)
+	}
+	post, err = store.GetPostByID(ctx, persisted.PostID)
+	if err != nil {
+		t.Fatalf("reading the post with photos: %v", err)
+	}
+	if !post.HasImage || post.ImageSource1 != "conformance/first-replaced.jpg" || post.ImageSource2 != "conformance/second.jpg" {
+		t.Fatalf("expected photos by position, got %q, %q (has image %v)", post.ImageSource1, post.ImageSource2, post.HasImage)
+	}
+
+	fingerprints, err := store.ListRecentPhotoFingerprints(ctx, strings.ToUpper(submission.Email), submission.PostedAt.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("listing fingerprints: %v", err)
+	}
+	hashes := make(map[int]uint64)
+	for _, fingerprint := range fingerprints {
+		if fingerprint.PostID == persisted.PostID {
+			hashes[fingerprint.Position] = fingerprint.PerceptualHash
+		}
+	}
+	if backend.anonymous {
+		if len(fingerprints) != 0 {
+			t.Fatalf("expected no fingerprints without a signed-in user, got %+v", fingerprints)
+		}
+	} else if hashes[0] != 43 || hashes[1] != 1<<63|7 {
+		t.Fatalf("expected both fingerprints, case-insensitive on email, got %v", hashes)
+	}
+
+	if err := store.DeletePendingPost(ctx, persisted.PostID); err != nil {
+		t.Fatalf("deleting the pending post: %v", err)
+	}
+	removed = true
+	if _, err := store.GetPostByID(ctx, persisted.PostID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected the deleted post gone, got %v", err)
+	}
+	if err := store.DeletePendingPost(ctx, persisted.PostID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected a second delete to report ErrNotFound, got %v", err)
+	}
+}
+
+// conformanceFixtures are the posts the fixture cases seed. Every name
+// starts with word. In posting order:
+//
+//	a1  category A  "<word> oak desk"     base
+//	a2  category A  "<word> desk lamp"    base+1m
+//	a3  category A  "<word> bookshelf"    base+1m (ties a2, higher id)
+//	b1  category B  "<word> road bike"    base+2m, "lamp" only in the body
+//	pending in category A, "<word> pending desk", never published
+type conformanceFixtures struct {
+	word       string
+	subA, subB domain.Subcategory
+	base       time.Time
+
+	a1, a2, a3, b1, pending int64
+}
+
+func seedConformanceFixtures(t *testing.T, backend conformanceBackend) conformanceFixtures {
+	t.Helper()
+	ctx := t.Context()
+
+	subcategories, err := backend.store.ListSubcategories(ctx)
+	if err != nil {
+		t.Fatalf("listing subcategories: %v", err)
+	}
+	var fixtures conformanceFixtures
+	if len(subcategories) == 0 {
+		t.Skip("backend has no subcategories to post in")
+	}
+	fixtures.subA = subcategories[0]
+	for _, sub := range subcategories {
+		if sub.CategoryID != fixtures.subA.CategoryID {
+			fixtures.subB = sub
+			break
+		}
+	}
+	if fixtures.subB.ID == 0 {
+		t.Skip("backend needs subcategories in two categories")
+	}
+
+	stamp := time.Now().UnixNano()
+	fixtures.word = conformanceWord(stamp)
+	fixtures.base = time.Now().Truncate(time.Second).Add(time.Hour)
+	email := fmt.Sprintf("conformance+%d@stanford.edu", stamp)
+
+	seeded := 0
+	seed := func(sub domain.Subcategory, name, body string, at time.Time, publish bool) int64 {
+		t.Helper()
+		seeded++
+		persisted, err := backend.store.CreatePendingPost(ctx, domain.PostCreateSubmission{
+			CategoryID:    sub.CategoryID,
+			SubcategoryID: sub.ID,
+			Email:         email,
+			Name:          fixtures.word + " " + name,
+			Body:          body,
+			AccessToken:   fmt.Sprintf("conformance-%d-%d", stamp, seeded),
+			PostedAt:      at,
+		})
+		if err != nil {
+			t.Fatalf("seeding %q: %v", name, err)
+		}
+		if backend.removePost != nil {
+			t.Cleanup(func() {
+				backend.removePost(t, persisted.PostID)
+			})
+		}
+		if publish {
+			if err := backend.writer.PublishOwnedPost(ctx, persisted.PostID, email, at); err != nil {
+				t.Fatalf("publishing %q: %v", name, err)
+			}
+		}
+		return persisted.PostID
+	}
+
+	fixtures.a1 = seed(fixtures.subA, "oak desk", "Solid oak writing desk.", fixtures.base, true)
+	fixtures.a2 = seed(fixtures.subA, "desk lamp", "Brass lamp, works great.", fixtures.base.Add(time.Minute), true)
+	fixtures.a3 = seed(fixtures.subA, "bookshelf", "Five shelves, easy to move.", fixtures.base.Add(time.Minute), true)
+	fixtures.b1 = seed(fixtures.subB, "road bike", "Comes with a front lamp.", fixtures.base.Add(2*time.Minute), true)
+	fixtures.pending = seed(fixtures.subA, "pending desk", "Never published.", fixtures.base.Add(3*time.Minute), false)
+	return fixtures
+}
+
+// conformanceWord spells n in letters, so full-text parsers keep it as one
+// word no other post contains.
+func conformanceWord(n int64) string {
+	word := []byte("zq")
+	for ; n > 0; n /= 26 {
+		word = append(word, byte('a'+n%26))
+	}
+	return string(word)
+}
+
+func postIDs(posts []domain.Post) []int64 {
+	ids := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	return ids
+}
+
+func requirePostIDs(t *testing.T, what string, posts []domain.Post, want ...int64) {
+	t.Helper()
+	if got := postIDs(posts); !slices.Equal(got, want) {
+		t.Fatalf("%s: expected posts %v, got %v", what, want, got)
+	}
+}
+
+func conformanceOrdering(t *testing.T, store conformanceStore, f conformanceFixtures) {
+	ctx := t.Context()
+
+	recent, err := store.ListRecentActivePosts(ctx, 4)
+	if err != nil {
+		t.Fatalf("listing recent posts: %v", err)
+	}
+	requirePostIDs(t, "recent posts, newest first with ties by id", recent, f.b1, f.a3, f.a2, f.a1)
+
+	byCategory, err := store.ListRecentActivePostsByCategory(ctx, f.subA.CategoryID, 3)
+	if err != nil {
+		t.Fatalf("listing posts by category: %v", err)
+	}
+	requirePostIDs(t, "category A posts", byCategory, f.a3, f.a2, f.a1)
+
+	browse, _, err := store.SearchActivePosts(ctx, "", f.subA.CategoryID, 0, 1, 3)
+	if err != nil {
+		t.Fatalf("browsing category A: %v", err)
+	}
+	requirePostIDs(t, "browsing category A", browse, f.a3, f.a2, f.a1)
+
+	post, err := store.GetPostByID(ctx, f.a3)
+	if err != nil {
+		t.Fatalf("reading a3: %v", err)
+	}
+	if post.TimePosted != f.base.Add(time.Minute).Unix() || post.TimePostedAt.Unix() != post.TimePosted {
+		t.Fatalf("expected a3 posted at %d, got %d / %v", f.base.Add(time.Minute).Unix(), post.TimePosted, post.TimePostedAt)
+	}
+
+	sections, err := store.ListHomeCategorySections(ctx)
+	if err != nil {
+		t.Fatalf("listing home sections: %v", err)
+	}
+	lastPosted := make(map[int64]int64, len(sections))
+	for _, section := range sections {
+		lastPosted[section.CategoryID] = section.LastPostedAt.Unix()
+	}
+	if lastPosted[f.subA.CategoryID] != f.base.Add(time.Minute).Unix() || lastPosted[f.subB.CategoryID] != f.base.Add(2*time.Minute).Unix() {
+		t.Fatalf("expected home sections to carry the newest active post time, got %v", lastPosted)
+	}
+}
+
+// Every fixture has the word once in its name, so ranked adapters tie on
+// rank and fall back to newest first like the others.
+func conformancePagination(t *testing.T, store conformanceStore, f conformanceFixtures) {
+	ctx := t.Context()
+	tests := []struct {
+		page, perPage int
+		want          []int64
+		wantMore      bool
+	}{
+		{page: 1, perPage: 3, want: []int64{f.b1, f.a3, f.a2}, wantMore: true},
+		{page: 2, perPage: 3, want: []int64{f.a1}, wantMore: false},
+		{page: 3, perPage: 3, want: []int64{}, wantMore: false},
+		{page: 1, perPage: 4, want: []int64{f.b1, f.a3, f.a2, f.a1}, wantMore: false},
+		{page: 2, perPage: 2, want: []int64{f.a2, f.a1}, wantMore: false},
+	}
+	for _, tt := range tests {
+		posts, hasMore, err := store.SearchActivePosts(ctx, f.word, 0, 0, tt.page, tt.perPage)
+		if err != nil {
+			t.Fatalf("searching page %d of %d: %v", tt.page, tt.perPage, err)
+		}
+		what := fmt.Sprintf("page %d of %d", tt.page, tt.perPage)
+		requirePostIDs(t, what, posts, tt.want...)
+		if hasMore != tt.wantMore {
+			t.Fatalf("%s: expected hasMore %v, got %v", what, tt.wantMore, hasMore)
+		}
+	}
+}
+
+func conformanceCategoryFilters(t *testing.T, store conformanceStore, f conformanceFixtures) {
+	ctx := t.Context()
+	tests := []struct {
+		name                      string
+		categoryID, subcategoryID int64
+		want                      []int64
+	}{
+		{name: "category A", categoryID: f.subA.CategoryID, want: []int64{f.a3, f.a2, f.a1}},
+		{name: "category B", categoryID: f.subB.CategoryID, want: []int64{f.b1}},
+		{name: "subcategory A", subcategoryID: f.subA.ID, want: []int64{f.a3, f.a2, f.a1}},
+		{name: "category A with subcategory B", categoryID: f.subA.CategoryID, subcategoryID: f.subB.ID, want: []int64{}},
+	}
+	for _, tt := range tests {
+		posts, hasMore, err := store.SearchActivePosts(ctx, f.word, tt.categoryID, tt.subcategoryID, 1, 10)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		requirePostIDs(t, tt.name, posts, tt.want...)
+		if hasMore {
+			t.Fatalf("%s: expected no more pages", tt.name)
+		}
+	}
+
+	byCategory, err := store.ListRecentActivePostsByCategory(t.Context(), f.subB.CategoryID, 1)
+	if err != nil {
+		t.Fatalf("listing category B: %v", err)
+	}
+	requirePostIDs(t, "category B listing", byCategory, f.b1)
+}
+
+// Matching is compared as sets: ranked adapters may order multi-word
+// matches by relevance. The cases stay within what substring and english
+// stemming agree on.
+func conformanceFullTextSearch(t *testing.T, store conformanceStore, f conformanceFixtures) {
+	tests := []struct {
+		name  string
+		query string
+		want  []int64
+	}{
+		{name: "every word must match", query: f.word + " desk", want: []int64{f.a1, f.a2}},
+		{name: "name or body", query: f.word + " lamp", want: []int64{f.a2, f.b1}},
+		{name: "case-insensitive", query: strings.ToUpper(f.word) + " OAK", want: []int64{f.a1}},
+		{name: "body words", query: f.word + " shelves", want: []int64{f.a3}},
+		{name: "no match", query: f.word + " spaceship", want: []int64{}},
+	}
+	for _, tt := range tests {
+		posts, hasMore, err := store.SearchActivePosts(t.Context(), tt.query, 0, 0, 1, 10)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := postIDs(posts)
+		slices.Sort(got)
+		want := slices.Clone(tt.want)
+		slices.Sort(want)
+		if !slices.Equal(got, want) || hasMore {
+			t.Fatalf("%s: expected %v for %q, got %v (hasMore %v)", tt.name, want, tt.query, got, hasMore)
+		}
+	}
+}
+
+func conformancePhotoPositions(t *testing.T, backend conformanceBackend, f conformanceFixtures) {
+	ctx := t.Context()
+	err := backend.store.SavePostPhotos(ctx, []domain.PostCreateSavedPhoto{
+		{PostID: f.a1, S3Key: "conformance/a1-third.jpg", Position: 2, PerceptualHash: 3},
+		{PostID: f.a1, S3Key: "conformance/a1-first.jpg", Position: 0, PerceptualHash: 1},
+	})
+	if err != nil {
+		t.Fatalf("saving photos: %v", err)
+	}
+
+	photos, err := backend.writer.ListPostPhotos(ctx, f.a1)
+	if err != nil {
+		t.Fatalf("listing photos: %v", err)
+	}
+	if len(photos) != 2 || photos[0].Position != 0 || photos[0].S3Key != "conformance/a1-first.jpg" ||
+		photos[1].Position != 2 || photos[1].S3Key != "conformance/a1-third.jpg" {
+		t.Fatalf("expected photos ordered by position, got %+v", photos)
+	}
+
+	post, err := backend.store.GetPostByID(ctx, f.a1)
+	if err != nil {
+		t.Fatalf("reading a1: %v", err)
+	}
+	if !post.HasImage || post.ImageSource1 != "conformance/a1-first.jpg" || post.ImageSource2 != "" ||
+		post.ImageSource3 != "conformance/a1-third.jpg" || post.ImageSource4 != "" {
+		t.Fatalf("expected image sources by position, got %q, %q, %q, %q",
+			post.ImageSource1, post.ImageSource2, post.ImageSource3, post.ImageSource4)
+	}
+
+	recent, err := backend.store.ListRecentActivePosts(ctx, 4)
+	if err != nil {
+		t.Fatalf("listing recent posts: %v", err)
+	}
+	for _, p := range recent {
+		if wantImage := p.ID == f.a1; p.HasImage != wantImage {
+			t.Fatalf("expected has_image %v on post %d in the listing", wantImage, p.ID)
+		}
+	}
+}
+
+func conformanceMessages(t *testing.T, backend conformanceBackend, f conformanceFixtures) {
+	ctx := t.Context()
+	store, writer := backend.store, backend.writer
+
+	first, err := store.CreateResponseMessage(ctx, f.b1, "buyer@stanford.edu", "Is the bike still available?", "203.0.113.9", "conformance-test")
+	if err != nil {
+		t.Fatalf("creating the first message: %v", err)
+	}
+	if first.ID <= 0 || first.PostID != f.b1 || first.Message != "Is the bike still available?" ||
+		first.Email != "buyer@stanford.edu" || first.RawEmail != "buyer@stanford.edu" ||
+		first.Source != "cli" || first.Status != "queued" || first.UserAgent != "conformance-test" ||
+		!strings.HasPrefix(first.IP, "203.0.113.9") || first.CreatedAt.IsZero() {
+		t.Fatalf("expected the queued message as stored, got %+v", first)
+	}
+	second, err := store.CreateResponseMessage(ctx, f.b1, "other@stanford.edu", "Would you take $80?", "", "")
+	if err != nil {
+		t.Fatalf("creating the second message: %v", err)
+	}
+
+	stored, err := writer.GetMessageByID(ctx, first.ID)
+	if err != nil || stored.ID != first.ID || stored.PostID != f.b1 || stored.Message != first.Message || stored.Status != "queued" {
+		t.Fatalf("expected message %d read back, got %+v, %v", first.ID, stored, err)
+	}
+
+	mailgunID := "<" + f.word + "@mailgun>"
+	if err := store.SetMessageMailgunID(ctx, first.ID, mailgunID); err != nil {
+		t.Fatalf("setting the mailgun id: %v", err)
+	}
+	byMailgun, err := writer.GetMessageByMailgunID(ctx, mailgunID)
+	if err != nil || byMailgun.ID != first.ID || byMailgun.MailgunMessageID != mailgunID {
+		t.Fatalf("expected message %d by mailgun id, got %+v, %v", first.ID, byMailgun, err)
+	}
+	delivered, err := writer.UpdateMessageStatus(ctx, first.ID, "delivered")
+	if err != nil || delivered.Status != "delivered" || delivered.MailgunMessageID != mailgunID {
+		t.Fatalf("expected the delivered status kept with the mailgun id, got %+v, %v", delivered, err)
+	}
+
+	messages, err := writer.ListMessagesForPosts(ctx, []int64{f.a1, f.b1})
+	if err != nil {
+		t.Fatalf("listing messages: %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != first.ID || messages[1].ID != second.ID ||
+		messages[0].Status != "delivered" || messages[1].Status != "queued" {
+		t.Fatalf("expected both messages oldest first, got %+v", messages)
+	}
+
+	missing := second.ID + 1_000_000_000
+	if _, err := writer.GetMessageByID(ctx, missing); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound reading a missing message, got %v", err)
+	}
+	if err := store.SetMessageMailgunID(ctx, missing, "<missing@mailgun>"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound setting a missing mailgun id, got %v", err)
+	}
+	if _, err := writer.UpdateMessageStatus(ctx, missing, "failed"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound updating a missing message, got %v", err)
+	}
+}
+
+func TestInMemory_Conformance(t *testing.T) {
+	repo := NewInMemory()
+	runConformance(t, conformanceBackend{store: repo, writer: repo})
+}
+
+// TestPostgres_Conformance needs TEST_DATABASE_URL. The suite runs in a
+// fresh database with supabase/migrations applied, dropped afterwards.
+func TestPostgres_Conformance(t *testing.T) {
+	pg := openMigratedTestPostgres(t)
+	runConformance(t, conformanceBackend{store: pg, writer: pg})
+}
+
+// TestSupabaseREST_Conformance runs the suite against a project's REST API
+// with the publishable key, for example the one `supabase start` serves
+// after `supabase db reset`. TEST_DATABASE_URL must reach the same
+// database: Postgres publishes the fixture posts, reads back the messages
+// the API cannot expose, and removes the fixtures afterwards.
+func TestSupabaseREST_Conformance(t *testing.T) {
+	baseURL := strings.TrimSpace(os.Getenv("TEST_SUPABASE_URL"))
+	apiKey := strings.TrimSpace(os.Getenv("TEST_SUPABASE_KEY"))
+	if baseURL == "" || apiKey == "" {
+		t.Skip("TEST_SUPABASE_URL and TEST_SUPABASE_KEY not set")
+	}
+	pg := openTestPostgres(t)
+	rest, err := NewSupabaseREST(baseURL, apiKey)
+	if err != nil {
+		t.Fatalf("configuring supabase rest: %v", err)
+	}
+	runConformance(t, conformanceBackend{
+		store:     rest,
+		writer:    pg,
+		anonymous: true,
+		removePost: func(t *testing.T, postID int64) {
+			ctx := context.Background()
+			if _, err := pg.db.pool.ExecContext(ctx, `DELETE FROM app_private.message WHERE post_id = $1`, postID); err != nil {
+				t.Errorf("removing conformance messages: %v", err)
+			}
+			if _, err := pg.db.pool.ExecContext(ctx, `DELETE FROM public.post WHERE id = $1`, postID); err != nil {
+				t.Errorf("removing conformance post: %v", err)
+			}
+		},
+	})
+}
@@ -14,7 +14,8 @@ import (
 	"github.com/Capmus-Team/supost-cli/internal/domain"
 )
 
-// InMemory implements in-memory data access for posts, categories, and messages.
+// InMemory implements in-memory data access for posts, categories, messages,
+// and profiles.
 // Perfect for prototyping and testing — zero external dependencies.
 // Swap to Postgres when ready. See AGENTS.md §6.5.
 type InMemory struct {
@@ -24,6 +25,7 @@ type InMemory struct {
 	messages      []domain.Message
 	categories    []domain.Category
 	subcategories []domain.Subcategory
+	profiles      map[string]domain.User
 }
 
 // NewInMemory creates a new in-memory repository pre-loaded with seed data.
@@ -34,6 +36,7 @@ func NewInMemory() *InMemory {
 		messages:      make([]domain.Message, 0),
 		categories:    make([]domain.Category, 0),
 		subcategories: make([]domain.Subcategory, 0),
+		profiles:      make(map[string]domain.User),
 	}
 	repo.loadPostSeedData()
 	repo.loadCategorySeedData()
@@ -46,13 +49,27 @@ func (r *InMemory) GetPostByID(_ context.Context, postID int64) (domain.Post, er
 
 	for _, post := range r.posts {
 		if post.ID == postID {
-			return post, nil
+			return r.withPhotoSourcesLocked(post), nil
 		}
 	}
 
 	return domain.Post{}, domain.ErrNotFound
 }
 
+// withPhotoSourcesLocked fills image sources from photo rows by position,
+// as the Postgres read does, keeping the legacy value for empty slots.
+func (r *InMemory) withPhotoSourcesLocked(post domain.Post) domain.Post {
+	sources := [4]*string{&post.ImageSource1, &post.ImageSource2, &post.ImageSource3, &post.ImageSource4}
+	for _, photo := range r.photos {
+		if photo.PostID != post.ID || photo.Position < 0 || photo.Position >= len(sources) {
+			continue
+		}
+		*sources[photo.Position] = photo.S3Key
+		post.HasImage = true
+	}
+	return post
+}
+
 func (r *InMemory) ListRecentActivePosts(_ context.Context, limit int) ([]domain.Post, error) {
 	return r.listRecentActivePosts(limit, nil), nil
 }
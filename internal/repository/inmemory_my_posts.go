@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// ListPostsByEmail returns the pending, active, and expired posts whose
+// email matches case-insensitively, newest first.
+func (r *InMemory) ListPostsByEmail(_ context.Context, email string) ([]domain.Post, error) {
+	email = strings.TrimSpace(email)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]domain.Post, 0)
+	for _, post := range r.posts {
+		if email != "" && strings.EqualFold(post.Email, email) && isOwnerVisibleStatus(post.Status) {
+			posts = append(posts, post)
+		}
+	}
+	sort.SliceStable(posts, func(i, j int) bool {
+		if posts[i].TimePosted != posts[j].TimePosted {
+			return posts[i].TimePosted > posts[j].TimePosted
+		}
+		return posts[i].ID > posts[j].ID
+	})
+	return posts, nil
+}
+
+// PublishOwnedPost makes a pending post active and stamps it as posted at.
+func (r *InMemory) PublishOwnedPost(_ context.Context, postID int64, email string, at time.Time) error {
+	return r.updateOwnedPost(postID, email, func(post *domain.Post) bool {
+		if post.Status != domain.PostStatusPending {
+			return false
+		}
+		post.Status = domain.PostStatusActive
+		post.TimePosted = at.Unix()
+		post.TimePostedAt = at
+		stampPostModified(post, at)
+		return true
+	})
+}
+
+// UpdateOwnedPost writes an owner's edit of name, body, and price.
+func (r *InMemory) UpdateOwnedPost(_ context.Context, postID int64, email string, edit domain.PostEdit, at time.Time) error {
+	return r.updateOwnedPost(postID, email, func(post *domain.Post) bool {
+		if !isOwnerVisibleStatus(post.Status) {
+			return false
+		}
+		post.Name = edit.Name
+		post.Body = edit.Body
+		post.Price = edit.Price
+		post.HasPrice = edit.PriceProvided
+		stampPostModified(post, at)
+		return true
+	})
+}
+
+// DeleteOwnedPost marks an owner's post deleted.
+func (r *InMemory) DeleteOwnedPost(_ context.Context, postID int64, email string, at time.Time) error {
+	return r.updateOwnedPost(postID, email, func(post *domain.Post) bool {
+		if !isOwnerVisibleStatus(post.Status) {
+			return false
+		}
+		post.Status = domain.PostStatusDeleted
+		stampPostModified(post, at)
+		return true
+	})
+}
+
+// updateOwnedPost applies change to the post with postID and email. It
+// returns domain.ErrNotFound when no such post exists or change declines.
+func (r *InMemory) updateOwnedPost(postID int64, email string, change func(post *domain.Post) bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx := range r.posts {
+		post := &r.posts[idx]
+		if post.ID != postID || !strings.EqualFold(post.Email, strings.TrimSpace(email)) {
+			continue
+		}
+		if !change(post) {
+			return domain.ErrNotFound
+		}
+		return nil
+	}
+	return domain.ErrNotFound
+}
+
+func stampPostModified(post *domain.Post, at time.Time) {
+	post.TimeModified = at.Unix()
+	post.TimeModifiedAt = at
+	post.UpdatedAt = at
+}
+
+func isOwnerVisibleStatus(status int) bool {
+	return status == domain.PostStatusPending || status == domain.PostStatusActive || status == domain.PostStatusExpired
+}
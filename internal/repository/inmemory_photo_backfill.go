@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// ListLegacyPhotoPosts returns up to limit posts after afterPostID that
+// still have a legacy photo column set, ordered by id.
+func (r *InMemory) ListLegacyPhotoPosts(_ context.Context, afterPostID int64, limit int) ([]domain.LegacyPhotoPost, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rowCounts := make(map[int64]int)
+	for _, photo := range r.photos {
+		rowCounts[photo.PostID]++
+	}
+
+	out := make([]domain.LegacyPhotoPost, 0)
+	for _, post := range r.posts {
+		if post.ID <= afterPostID || !hasLegacyPhotoFields(post) {
+			continue
+		}
+		out = append(out, domain.LegacyPhotoPost{
+			PostID:         post.ID,
+			ImageSources:   [4]string{post.ImageSource1, post.ImageSource2, post.ImageSource3, post.ImageSource4},
+			PhotoFileNames: [4]string{post.Photo1File, post.Photo2File, post.Photo3File, post.Photo4File},
+			PhotoRowCount:  rowCounts[post.ID],
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PostID < out[j].PostID })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// InsertBackfilledPhotos appends photo rows, skipping any that would collide
+// with an existing (post_id, s3_key) or (post_id, position).
+func (r *InMemory) InsertBackfilledPhotos(_ context.Context, photos []domain.PostCreateSavedPhoto) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	type postKey struct {
+		postID int64
+		key    string
+	}
+	type postPosition struct {
+		postID   int64
+		position int
+	}
+	keys := make(map[postKey]bool, len(r.photos))
+	positions := make(map[postPosition]bool, len(r.photos))
+	for _, photo := range r.photos {
+		keys[postKey{photo.PostID, photo.S3Key}] = true
+		positions[postPosition{photo.PostID, photo.Position}] = true
+	}
+
+	inserted := 0
+	for _, photo := range photos {
+		key := strings.TrimSpace(photo.S3Key)
+		if photo.PostID <= 0 || key == "" || photo.Position < 0 {
+			return inserted, fmt.Errorf("invalid backfill photo %+v", photo)
+		}
+		byKey := postKey{photo.PostID, key}
+		byPosition := postPosition{photo.PostID, photo.Position}
+		if keys[byKey] || positions[byPosition] {
+			continue
+		}
+		keys[byKey], positions[byPosition] = true, true
+		r.photos = append(r.photos, domain.PostCreateSavedPhoto{
+			PostID:      photo.PostID,
+			S3Key:       key,
+			TickerS3Key: strings.TrimSpace(photo.TickerS3Key),
+			Position:    photo.Position,
+		})
+		inserted++
+	}
+	return inserted, nil
+}
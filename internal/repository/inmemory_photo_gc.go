@@ -0,0 +1,25 @@
+package repository
+
+import "context"
+
+// ListReferencedPhotoKeys returns the subset of keys used by a photo row as
+// its s3_key or ticker_s3_key.
+func (r *InMemory) ListReferencedPhotoKeys(_ context.Context, keys []string) (map[string]bool, error) {
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[key] = true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	referenced := make(map[string]bool, len(keys))
+	for _, photo := range r.photos {
+		for _, key := range []string{photo.S3Key, photo.TickerS3Key} {
+			if key != "" && wanted[key] {
+				referenced[key] = true
+			}
+		}
+	}
+	return referenced, nil
+}
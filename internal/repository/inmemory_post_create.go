@@ -27,7 +27,7 @@ func (r *InMemory) CreatePendingPost(_ context.Context, submission domain.PostCr
 		IP:             submission.IP,
 		Name:           submission.Name,
 		Body:           submission.Body,
-		Status:         0,
+		Status:         domain.PostStatusPending,
 		AccessToken:    submission.AccessToken,
 		TimePosted:     now.Unix(),
 		TimeModified:   now.Unix(),
@@ -62,12 +62,25 @@ func (r *InMemory) SavePostPhotos(_ context.Context, photos []domain.PostCreateS
 		if s3Key == "" {
 			return fmt.Errorf("s3_key is required")
 		}
-		r.photos = append(r.photos, domain.PostCreateSavedPhoto{
-			PostID:      photo.PostID,
-			S3Key:       s3Key,
-			TickerS3Key: strings.TrimSpace(photo.TickerS3Key),
-			Position:    photo.Position,
-		})
+		saved := domain.PostCreateSavedPhoto{
+			PostID:         photo.PostID,
+			S3Key:          s3Key,
+			TickerS3Key:    strings.TrimSpace(photo.TickerS3Key),
+			Position:       photo.Position,
+			PerceptualHash: photo.PerceptualHash,
+		}
+		// Same (post_id, position) upsert as the Postgres ON CONFLICT.
+		replaced := false
+		for idx, existing := range r.photos {
+			if existing.PostID == saved.PostID && existing.Position == saved.Position {
+				r.photos[idx] = saved
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			r.photos = append(r.photos, saved)
+		}
 
 		for idx, post := range r.posts {
 			if post.ID != photo.PostID {
@@ -81,6 +94,70 @@ func (r *InMemory) SavePostPhotos(_ context.Context, photos []domain.PostCreateS
 	return nil
 }
 
+// DeletePendingPost removes a still-pending post and its photo rows. Used to
+// roll back a submission whose photo uploads failed.
+func (r *InMemory) DeletePendingPost(_ context.Context, postID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx := -1
+	for i, post := range r.posts {
+		if post.ID == postID && post.Status == domain.PostStatusPending {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return domain.ErrNotFound
+	}
+	r.posts = append(r.posts[:idx], r.posts[idx+1:]...)
+
+	photos := r.photos[:0]
+	for _, photo := range r.photos {
+		if photo.PostID != postID {
+			photos = append(photos, photo)
+		}
+	}
+	r.photos = photos
+	return nil
+}
+
+// ListRecentPhotoFingerprints returns hashed photos on posts by email
+// (case-insensitive) posted at or after since.
+func (r *InMemory) ListRecentPhotoFingerprints(_ context.Context, email string, since time.Time) ([]domain.PhotoFingerprint, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return []domain.PhotoFingerprint{}, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	recentPosts := make(map[int64]struct{})
+	for _, post := range r.posts {
+		if strings.ToLower(strings.TrimSpace(post.Email)) != email {
+			continue
+		}
+		if post.TimePosted < since.Unix() {
+			continue
+		}
+		recentPosts[post.ID] = struct{}{}
+	}
+
+	fingerprints := make([]domain.PhotoFingerprint, 0)
+	for _, photo := range r.photos {
+		if _, ok := recentPosts[photo.PostID]; !ok || photo.PerceptualHash == 0 {
+			continue
+		}
+		fingerprints = append(fingerprints, domain.PhotoFingerprint{
+			PostID:         photo.PostID,
+			Position:       photo.Position,
+			PerceptualHash: photo.PerceptualHash,
+		})
+	}
+	return fingerprints, nil
+}
+
 func (r *InMemory) nextPostIDLocked() int64 {
 	var maxID int64
 	for _, post := range r.posts {
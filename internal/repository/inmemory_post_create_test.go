@@ -54,3 +54,188 @@ func TestInMemorySavePostPhotos_RejectsBlankS3Key(t *testing.T) {
 		t.Fatalf("expected validation error")
 	}
 }
+
+func TestInMemoryListRecentPhotoFingerprints_FiltersByEmailAndWindow(t *testing.T) {
+	repo := NewInMemory()
+	ctx := context.Background()
+	now := time.Now()
+
+	create := func(email string, postedAt time.Time) int64 {
+		t.Helper()
+		persisted, err := repo.CreatePendingPost(ctx, domain.PostCreateSubmission{
+			CategoryID: 5, SubcategoryID: 14, Email: email, Name: "Bike", Body: "Body", PostedAt: postedAt,
+		})
+		if err != nil {
+			t.Fatalf("creating pending post: %v", err)
+		}
+		return persisted.PostID
+	}
+	recent := create("poster@stanford.edu", now.Add(-24*time.Hour))
+	old := create("poster@stanford.edu", now.Add(-60*24*time.Hour))
+	other := create("someone@stanford.edu", now)
+
+	err := repo.SavePostPhotos(ctx, []domain.PostCreateSavedPhoto{
+		{PostID: recent, S3Key: "v2/posts/r/a.jpg", Position: 0, PerceptualHash: 0xA1},
+		{PostID: recent, S3Key: "v2/posts/r/b.jpg", Position: 1},
+		{PostID: old, S3Key: "v2/posts/o/a.jpg", Position: 0, PerceptualHash: 0xB2},
+		{PostID: other, S3Key: "v2/posts/x/a.jpg", Position: 0, PerceptualHash: 0xC3},
+	})
+	if err != nil {
+		t.Fatalf("saving photos: %v", err)
+	}
+
+	fingerprints, err := repo.ListRecentPhotoFingerprints(ctx, "Poster@Stanford.edu", now.Add(-30*24*time.Hour))
+	if err != nil {
+		t.Fatalf("listing fingerprints: %v", err)
+	}
+	if len(fingerprints) != 1 || fingerprints[0].PostID != recent || fingerprints[0].PerceptualHash != 0xA1 {
+		t.Fatalf("expected only the recent hashed photo, got %+v", fingerprints)
+	}
+}
+
+func TestInMemoryDeletePendingPost_RemovesPostAndPhotos(t *testing.T) {
+	repo := NewInMemory()
+	ctx := context.Background()
+
+	persisted, err := repo.CreatePendingPost(ctx, domain.PostCreateSubmission{
+		CategoryID:    5,
+		SubcategoryID: 14,
+		Email:         "wientjes@alumni.stanford.edu",
+		Name:          "Desk",
+		Body:          "Body",
+	})
+	if err != nil {
+		t.Fatalf("creating pending post: %v", err)
+	}
+	if err := repo.SavePostPhotos(ctx, []domain.PostCreateSavedPhoto{
+		{PostID: persisted.PostID, S3Key: "v2/posts/1/a.jpg", Position: 0},
+	}); err != nil {
+		t.Fatalf("saving photos: %v", err)
+	}
+	photosBefore := len(repo.photos)
+
+	if err := repo.DeletePendingPost(ctx, persisted.PostID); err != nil {
+		t.Fatalf("deleting pending post: %v", err)
+	}
+	if _, err := repo.GetPostByID(ctx, persisted.PostID); err == nil {
+		t.Fatalf("expected pending post to be gone")
+	}
+	if len(repo.photos) != photosBefore-1 {
+		t.Fatalf("expected the post's photo rows removed, got %d of %d", len(repo.photos), photosBefore)
+	}
+	if err := repo.DeletePendingPost(ctx, persisted.PostID); err != domain.ErrNotFound {
+		t.Fatalf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestInMemoryDeletePendingPost_LeavesActivePostsAlone(t *testing.T) {
+	repo := NewInMemory()
+	ctx := context.Background()
+	active := repo.posts[0]
+
+	if err := repo.DeletePendingPost(ctx, active.ID); err != domain.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for a non-pending post, got %v", err)
+	}
+	if _, err := repo.GetPostByID(ctx, active.ID); err != nil {
+		t.Fatalf("expected active post to remain: %v", err)
+	}
+}
+
+func TestInMemoryReplacePostPhotos_RewritesRowsAndHasImage(t *testing.T) {
+	repo := NewInMemory()
+	ctx := context.Background()
+
+	persisted, err := repo.CreatePendingPost(ctx, domain.PostCreateSubmission{
+		CategoryID:    5,
+		SubcategoryID: 14,
+		Email:         "wientjes@alumni.stanford.edu",
+		Name:          "Desk",
+		Body:          "Body",
+		AccessToken:   "photo-token",
+	})
+	if err != nil {
+		t.Fatalf("creating pending post: %v", err)
+	}
+	if err := repo.SavePostPhotos(ctx, []domain.PostCreateSavedPhoto{
+		{PostID: persisted.PostID, S3Key: "a.jpg", Position: 0},
+		{PostID: persisted.PostID, S3Key: "b.jpg", Position: 1},
+	}); err != nil {
+		t.Fatalf("saving photos: %v", err)
+	}
+
+	post, err := repo.GetPostByAccessToken(ctx, "photo-token")
+	if err != nil || post.ID != persisted.PostID {
+		t.Fatalf("expected post by access token, got %+v / %v", post, err)
+	}
+
+	if err := repo.ReplacePostPhotos(ctx, post.ID, []domain.PostCreateSavedPhoto{
+		{S3Key: "b.jpg", Position: 0},
+		{S3Key: "a.jpg", Position: 0},
+	}); err == nil {
+		t.Fatalf("expected duplicate position to be rejected")
+	}
+	if err := repo.ReplacePostPhotos(ctx, post.ID, []domain.PostCreateSavedPhoto{{S3Key: "b.jpg", Position: 0}}); err != nil {
+		t.Fatalf("replacing photos: %v", err)
+	}
+	photos, err := repo.ListPostPhotos(ctx, post.ID)
+	if err != nil || len(photos) != 1 || photos[0].S3Key != "b.jpg" || photos[0].Position != 0 {
+		t.Fatalf("expected only b.jpg at 0, got %+v / %v", photos, err)
+	}
+
+	if err := repo.ReplacePostPhotos(ctx, post.ID, nil); err != nil {
+		t.Fatalf("clearing photos: %v", err)
+	}
+	post, _ = repo.GetPostByID(ctx, post.ID)
+	if post.HasImage {
+		t.Fatalf("expected has_image false once the last photo is removed")
+	}
+	if _, err := repo.GetPostByAccessToken(ctx, "missing"); err != domain.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestInMemoryListReferencedPhotoKeys_MatchesPhotoAndTickerKeys(t *testing.T) {
+	repo := NewInMemory()
+	ctx := context.Background()
+
+	err := repo.SavePostPhotos(ctx, []domain.PostCreateSavedPhoto{
+		{PostID: 9, S3Key: "v2/posts/9/a.jpg", TickerS3Key: "v2/posts/9/ticker_a.jpg", Position: 0},
+	})
+	if err != nil {
+		t.Fatalf("saving photos: %v", err)
+	}
+
+	referenced, err := repo.ListReferencedPhotoKeys(ctx, []string{"v2/posts/9/a.jpg", "v2/posts/9/ticker_a.jpg", "v2/posts/9/orphan.jpg"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(referenced) != 2 || !referenced["v2/posts/9/a.jpg"] || !referenced["v2/posts/9/ticker_a.jpg"] {
+		t.Fatalf("expected photo and ticker keys referenced, got %v", referenced)
+	}
+}
+
+func TestInMemoryInsertBackfilledPhotos_SkipsConflictingRows(t *testing.T) {
+	repo := NewInMemory()
+	ctx := context.Background()
+	rows := []domain.PostCreateSavedPhoto{
+		{PostID: 130031900, S3Key: "posts/130031900/post_130031900a.jpg", TickerS3Key: "posts/130031900/ticker_130031900a.jpg", Position: 0},
+		{PostID: 130031900, S3Key: "posts/130031900/post_130031900b.jpg", Position: 1},
+	}
+
+	inserted, err := repo.InsertBackfilledPhotos(ctx, rows)
+	if err != nil || inserted != 2 {
+		t.Fatalf("expected 2 inserted, got %d, %v", inserted, err)
+	}
+	inserted, err = repo.InsertBackfilledPhotos(ctx, append(rows, domain.PostCreateSavedPhoto{PostID: 130031900, S3Key: "posts/130031900/other.jpg", Position: 1}))
+	if err != nil || inserted != 0 {
+		t.Fatalf("expected key and position conflicts skipped, got %d, %v", inserted, err)
+	}
+
+	legacy, err := repo.ListLegacyPhotoPosts(ctx, 130031899, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(legacy) != 1 || legacy[0].PostID != 130031900 || legacy[0].PhotoRowCount != 2 || legacy[0].PhotoFileNames[1] != "post_130031900b.jpg" {
+		t.Fatalf("unexpected legacy posts after 130031899: %+v", legacy)
+	}
+}
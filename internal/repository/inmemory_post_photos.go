@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// GetPostByAccessToken returns the post whose access token matches exactly.
+func (r *InMemory) GetPostByAccessToken(_ context.Context, accessToken string) (domain.Post, error) {
+	accessToken = strings.TrimSpace(accessToken)
+	if accessToken == "" {
+		return domain.Post{}, domain.ErrNotFound
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, post := range r.posts {
+		if post.AccessToken == accessToken {
+			return post, nil
+		}
+	}
+	return domain.Post{}, domain.ErrNotFound
+}
+
+// ListPostPhotos returns a post's photo rows ordered by position.
+func (r *InMemory) ListPostPhotos(_ context.Context, postID int64) ([]domain.PostCreateSavedPhoto, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	photos := make([]domain.PostCreateSavedPhoto, 0)
+	for _, photo := range r.photos {
+		if photo.PostID == postID {
+			photos = append(photos, photo)
+		}
+	}
+	sort.SliceStable(photos, func(i, j int) bool { return photos[i].Position < photos[j].Position })
+	return photos, nil
+}
+
+// ReplacePostPhotos swaps a post's photo rows for photos and recomputes
+// has_image from the remaining rows and legacy photo fields.
+func (r *InMemory) ReplacePostPhotos(_ context.Context, postID int64, photos []domain.PostCreateSavedPhoto) error {
+	if err := validateReplacementPhotos(postID, photos); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	postIdx := -1
+	for idx, post := range r.posts {
+		if post.ID == postID {
+			postIdx = idx
+			break
+		}
+	}
+	if postIdx < 0 {
+		return domain.ErrNotFound
+	}
+
+	kept := r.photos[:0]
+	for _, photo := range r.photos {
+		if photo.PostID != postID {
+			kept = append(kept, photo)
+		}
+	}
+	for _, photo := range photos {
+		photo.PostID = postID
+		photo.S3Key = strings.TrimSpace(photo.S3Key)
+		photo.TickerS3Key = strings.TrimSpace(photo.TickerS3Key)
+		kept = append(kept, photo)
+	}
+	r.photos = kept
+
+	now := time.Now()
+	post := r.posts[postIdx]
+	post.HasImage = len(photos) > 0 || hasLegacyPhotoFields(post)
+	post.TimeModified = now.Unix()
+	post.TimeModifiedAt = now
+	r.posts[postIdx] = post
+	return nil
+}
+
+// validateReplacementPhotos enforces the public.photo constraints: non-blank
+// keys, unique keys, and unique non-negative positions per post.
+func validateReplacementPhotos(postID int64, photos []domain.PostCreateSavedPhoto) error {
+	if postID <= 0 {
+		return fmt.Errorf("post_id must be positive")
+	}
+	positions := make(map[int]struct{}, len(photos))
+	keys := make(map[string]struct{}, len(photos))
+	for _, photo := range photos {
+		key := strings.TrimSpace(photo.S3Key)
+		if key == "" {
+			return fmt.Errorf("s3_key is required")
+		}
+		if photo.Position < 0 {
+			return fmt.Errorf("position must be non-negative")
+		}
+		if _, dup := positions[photo.Position]; dup {
+			return fmt.Errorf("duplicate photo position %d for post %d", photo.Position, postID)
+		}
+		if _, dup := keys[key]; dup {
+			return fmt.Errorf("duplicate photo s3_key %q for post %d", key, postID)
+		}
+		positions[photo.Position] = struct{}{}
+		keys[key] = struct{}{}
+	}
+	return nil
+}
+
+func hasLegacyPhotoFields(post domain.Post) bool {
+	for _, value := range []string{
+		post.Photo1File, post.Photo2File, post.Photo3File, post.Photo4File,
+		post.ImageSource1, post.ImageSource2, post.ImageSource3, post.ImageSource4,
+	} {
+		if strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+	return false
+}
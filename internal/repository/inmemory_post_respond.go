@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/Capmus-Team/supost-cli/internal/domain"
@@ -21,7 +22,7 @@ func (r *InMemory) CreateResponseMessage(_ context.Context, postID int64, replyT
 		Email:     replyToEmail,
 		RawEmail:  replyToEmail,
 		Source:    "cli",
-		Status:    "queued",
+		Status:    domain.MessageStatusQueued,
 		UserAgent: userAgent,
 		Scammed:   false,
 		CreatedAt: now,
@@ -31,6 +32,58 @@ func (r *InMemory) CreateResponseMessage(_ context.Context, postID int64, replyT
 	return record, nil
 }
 
+func (r *InMemory) GetMessageByID(_ context.Context, messageID int64) (domain.Message, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, message := range r.messages {
+		if message.ID == messageID {
+			return message, nil
+		}
+	}
+	return domain.Message{}, domain.ErrNotFound
+}
+
+func (r *InMemory) SetMessageMailgunID(_ context.Context, messageID int64, mailgunMessageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx := range r.messages {
+		if r.messages[idx].ID == messageID {
+			r.messages[idx].MailgunMessageID = mailgunMessageID
+			r.messages[idx].UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (r *InMemory) GetMessageByMailgunID(_ context.Context, mailgunMessageID string) (domain.Message, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for idx := len(r.messages) - 1; idx >= 0; idx-- {
+		if r.messages[idx].MailgunMessageID == mailgunMessageID {
+			return r.messages[idx], nil
+		}
+	}
+	return domain.Message{}, domain.ErrNotFound
+}
+
+func (r *InMemory) UpdateMessageStatus(_ context.Context, messageID int64, status string) (domain.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx := range r.messages {
+		if r.messages[idx].ID == messageID {
+			r.messages[idx].Status = status
+			r.messages[idx].UpdatedAt = time.Now()
+			return r.messages[idx], nil
+		}
+	}
+	return domain.Message{}, domain.ErrNotFound
+}
+
 func (r *InMemory) nextMessageIDLocked() int64 {
 	var maxID int64
 	for _, message := range r.messages {
@@ -43,3 +96,32 @@ func (r *InMemory) nextMessageIDLocked() int64 {
 	}
 	return maxID + 1
 }
+
+// ListMessagesForPosts returns the messages on postIDs ordered by post, then
+// oldest first.
+func (r *InMemory) ListMessagesForPosts(_ context.Context, postIDs []int64) ([]domain.Message, error) {
+	wanted := make(map[int64]bool, len(postIDs))
+	for _, postID := range postIDs {
+		wanted[postID] = true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	messages := make([]domain.Message, 0)
+	for _, message := range r.messages {
+		if wanted[message.PostID] {
+			messages = append(messages, message)
+		}
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		if messages[i].PostID != messages[j].PostID {
+			return messages[i].PostID < messages[j].PostID
+		}
+		if !messages[i].CreatedAt.Equal(messages[j].CreatedAt) {
+			return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+		}
+		return messages[i].ID < messages[j].ID
+	})
+	return messages, nil
+}
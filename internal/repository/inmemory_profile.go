@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func (r *InMemory) GetProfile(_ context.Context, userID string) (domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, ok := r.profiles[strings.TrimSpace(userID)]
+	if !ok {
+		return domain.User{}, domain.ErrNotFound
+	}
+	return profile, nil
+}
+
+// CreateProfileIfMissing inserts profile unless a row for its id exists, and
+// returns the stored row. The bool reports whether it was created.
+func (r *InMemory) CreateProfileIfMissing(_ context.Context, profile domain.User) (domain.User, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	profile.ID = strings.TrimSpace(profile.ID)
+	if existing, ok := r.profiles[profile.ID]; ok {
+		return existing, false, nil
+	}
+	now := time.Now()
+	profile.CreatedAt, profile.UpdatedAt = now, now
+	r.profiles[profile.ID] = profile
+	return profile, true, nil
+}
+
+// UpdateProfile writes the display name and phone of an existing profile.
+func (r *InMemory) UpdateProfile(_ context.Context, profile domain.User, at time.Time) (domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.profiles[strings.TrimSpace(profile.ID)]
+	if !ok {
+		return domain.User{}, domain.ErrNotFound
+	}
+	existing.DisplayName = profile.DisplayName
+	existing.Phone = profile.Phone
+	existing.UpdatedAt = at
+	r.profiles[existing.ID] = existing
+	return existing, nil
+}
@@ -17,7 +17,7 @@ const maxRecentActivePosts = 50
 
 // Postgres implements repository methods backed by PostgreSQL.
 type Postgres struct {
-	db *sql.DB
+	db *pgDB
 }
 
 // NewPostgres initializes the PostgreSQL adapter.
@@ -37,7 +37,7 @@ func NewPostgres(databaseURL string) (*Postgres, error) {
 	db.SetMaxIdleConns(4)
 	db.SetConnMaxLifetime(5 * time.Minute)
 
-	return &Postgres{db: db}, nil
+	return &Postgres{db: &pgDB{pool: db}}, nil
 }
 
 // Close closes the DB pool.
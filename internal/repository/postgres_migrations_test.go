@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testMigrationsDir is supabase/migrations relative to this package; go test
+// runs in the package directory.
+var testMigrationsDir = filepath.Join("..", "..", "supabase", "migrations")
+
+// migrationBootstrapSQL stands in for what a Supabase database has before
+// the first migration: the API roles, the extensions schema, and the parts
+// of auth the policies call. Roles are cluster-wide, so they are only
+// created when missing.
+const migrationBootstrapSQL = `
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'anon') THEN
+    CREATE ROLE anon NOLOGIN NOINHERIT;
+  END IF;
+  IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'authenticated') THEN
+    CREATE ROLE authenticated NOLOGIN NOINHERIT;
+  END IF;
+  IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'service_role') THEN
+    CREATE ROLE service_role NOLOGIN NOINHERIT BYPASSRLS;
+  END IF;
+END
+$$;
+CREATE SCHEMA IF NOT EXISTS extensions;
+CREATE SCHEMA IF NOT EXISTS auth;
+CREATE TABLE IF NOT EXISTS auth.users (id uuid PRIMARY KEY, email text);
+CREATE OR REPLACE FUNCTION auth.uid() RETURNS uuid
+LANGUAGE sql STABLE
+AS $$
+  SELECT coalesce(
+    nullif(current_setting('request.jwt.claim.sub', true), ''),
+    nullif(current_setting('request.jwt.claims', true), '')::jsonb ->> 'sub'
+  )::uuid
+$$;
+GRANT USAGE ON SCHEMA extensions, auth TO anon, authenticated, service_role;
+GRANT EXECUTE ON FUNCTION auth.uid() TO anon, authenticated, service_role;
+`
+
+// migrationSeedSQL gives the fixture cases subcategories in two categories.
+const migrationSeedSQL = `
+INSERT INTO public.category (id, name, short_name) VALUES
+  (3, 'for sale', 'sale'),
+  (5, 'housing', 'housing');
+INSERT INTO public.subcategory (id, category_id, name) VALUES
+  (14, 3, 'furniture'),
+  (59, 5, 'rooms & shares');
+`
+
+// openMigratedTestPostgres creates a throwaway database next to the one
+// TEST_DATABASE_URL names, applies supabase/migrations to it in order, and
+// drops it when the test ends. Nothing in the shared database is touched.
+func openMigratedTestPostgres(t *testing.T) *Postgres {
+	t.Helper()
+	admin := openTestPostgres(t)
+	ctx := t.Context()
+
+	name := fmt.Sprintf("supost_test_%d", time.Now().UnixNano())
+	if _, err := admin.db.pool.ExecContext(ctx, `CREATE DATABASE `+name); err != nil {
+		t.Fatalf("creating test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.db.pool.ExecContext(context.Background(), `DROP DATABASE IF EXISTS `+name+` WITH (FORCE)`); err != nil {
+			t.Errorf("dropping test database %s: %v", name, err)
+		}
+	})
+
+	pg, err := NewPostgres(withDatabaseName(t, os.Getenv("TEST_DATABASE_URL"), name))
+	if err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = pg.Close()
+	})
+
+	if _, err := pg.db.pool.ExecContext(ctx, migrationBootstrapSQL); err != nil {
+		t.Fatalf("bootstrapping test database: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join(testMigrationsDir, "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("finding migrations in %s: %v", testMigrationsDir, err)
+	}
+	slices.Sort(files)
+	for _, file := range files {
+		migration, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("reading migration: %v", err)
+		}
+		if _, err := pg.db.pool.ExecContext(ctx, string(migration)); err != nil {
+			t.Fatalf("applying %s: %v", filepath.Base(file), err)
+		}
+	}
+	if _, err := pg.db.pool.ExecContext(ctx, migrationSeedSQL); err != nil {
+		t.Fatalf("seeding categories: %v", err)
+	}
+	return pg
+}
+
+var dsnDatabaseName = regexp.MustCompile(`(^|\s)dbname=\S*`)
+
+// withDatabaseName points a URL or key/value connection string at database
+// name.
+func withDatabaseName(t *testing.T, connString string, name string) string {
+	t.Helper()
+	connString = strings.TrimSpace(connString)
+	if strings.HasPrefix(connString, "postgres://") || strings.HasPrefix(connString, "postgresql://") {
+		parsed, err := url.Parse(connString)
+		if err != nil {
+			t.Fatalf("parsing TEST_DATABASE_URL: %v", err)
+		}
+		parsed.Path = "/" + name
+		return parsed.String()
+	}
+	if dsnDatabaseName.MatchString(connString) {
+		return dsnDatabaseName.ReplaceAllString(connString, "${1}dbname="+name)
+	}
+	return connString + " dbname=" + name
+}
+
+func TestWithDatabaseName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"postgresql://u:p@localhost:5432/postgres?sslmode=disable", "postgresql://u:p@localhost:5432/scratch?sslmode=disable"},
+		{"host=localhost dbname=postgres user=u", "host=localhost dbname=scratch user=u"},
+		{"host=localhost user=u", "host=localhost user=u dbname=scratch"},
+	}
+	for _, tt := range tests {
+		if got := withDatabaseName(t, tt.input, "scratch"); got != tt.want {
+			t.Fatalf("withDatabaseName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
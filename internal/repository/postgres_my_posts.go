@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// ListPostsByEmail returns the pending, active, and expired posts whose
+// email matches (public.post.email is citext), newest first.
+func (r *Postgres) ListPostsByEmail(ctx context.Context, email string) ([]domain.Post, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return []domain.Post{}, nil
+	}
+
+	const query = `
+SELECT
+	id,
+	COALESCE(category_id, 0) AS category_id,
+	COALESCE(subcategory_id, 0) AS subcategory_id,
+	COALESCE(email, '') AS email,
+	COALESCE(name, '') AS name,
+	COALESCE(status, 0) AS status,
+	COALESCE(time_posted, 0) AS time_posted,
+	COALESCE(time_posted_at, to_timestamp(0)) AS time_posted_at,
+	COALESCE(time_modified_at, to_timestamp(0)) AS time_modified_at,
+	COALESCE(price::float8, 0) AS price,
+	(price IS NOT NULL) AS has_price,
+	(
+		COALESCE(photo1_file_name, '') <> '' OR
+		COALESCE(photo2_file_name, '') <> '' OR
+		COALESCE(photo3_file_name, '') <> '' OR
+		COALESCE(photo4_file_name, '') <> '' OR
+		COALESCE(image_source1, '') <> '' OR
+		COALESCE(image_source2, '') <> '' OR
+		COALESCE(image_source3, '') <> '' OR
+		COALESCE(image_source4, '') <> '' OR
+		EXISTS (SELECT 1 FROM public.photo ph WHERE ph.post_id = public.post.id)
+	) AS has_image
+FROM public.post
+WHERE email = $1
+	AND status IN (0, 1, 2)
+ORDER BY COALESCE(time_posted, 0) DESC, id DESC
+`
+
+	rows, err := r.db.QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, fmt.Errorf("querying posts by email: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]domain.Post, 0)
+	for rows.Next() {
+		var post domain.Post
+		if err := rows.Scan(
+			&post.ID,
+			&post.CategoryID,
+			&post.SubcategoryID,
+			&post.Email,
+			&post.Name,
+			&post.Status,
+			&post.TimePosted,
+			&post.TimePostedAt,
+			&post.TimeModifiedAt,
+			&post.Price,
+			&post.HasPrice,
+			&post.HasImage,
+		); err != nil {
+			return nil, fmt.Errorf("scanning post by email: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating posts by email: %w", err)
+	}
+	return posts, nil
+}
+
+// PublishOwnedPost makes a pending post active and stamps it as posted at.
+func (r *Postgres) PublishOwnedPost(ctx context.Context, postID int64, email string, at time.Time) error {
+	const query = `
+UPDATE public.post
+SET status = 1,
+	time_posted = $3,
+	time_posted_at = to_timestamp($3),
+	time_modified = $3,
+	time_modified_at = to_timestamp($3),
+	updated_at = now()
+WHERE id = $1
+	AND email = $2
+	AND status = 0
+`
+	return r.execOwnedPostUpdate(ctx, "publishing", postID, query, postID, strings.TrimSpace(email), at.Unix())
+}
+
+// UpdateOwnedPost writes an owner's edit of name, body, and price.
+func (r *Postgres) UpdateOwnedPost(ctx context.Context, postID int64, email string, edit domain.PostEdit, at time.Time) error {
+	var priceValue any
+	if edit.PriceProvided {
+		priceValue = edit.Price
+	}
+
+	const query = `
+UPDATE public.post
+SET name = $3,
+	body = $4,
+	price = $5,
+	time_modified = $6,
+	time_modified_at = to_timestamp($6),
+	updated_at = now()
+WHERE id = $1
+	AND email = $2
+	AND status IN (0, 1, 2)
+`
+	return r.execOwnedPostUpdate(ctx, "updating", postID, query, postID, strings.TrimSpace(email), edit.Name, edit.Body, priceValue, at.Unix())
+}
+
+// DeleteOwnedPost marks an owner's post deleted (status -1). The row stays
+// so messages about it keep their post.
+func (r *Postgres) DeleteOwnedPost(ctx context.Context, postID int64, email string, at time.Time) error {
+	const query = `
+UPDATE public.post
+SET status = -1,
+	time_modified = $3,
+	time_modified_at = to_timestamp($3),
+	updated_at = now()
+WHERE id = $1
+	AND email = $2
+	AND status IN (0, 1, 2)
+`
+	return r.execOwnedPostUpdate(ctx, "deleting", postID, query, postID, strings.TrimSpace(email), at.Unix())
+}
+
+// execOwnedPostUpdate runs an owner-scoped UPDATE and maps zero affected
+// rows to domain.ErrNotFound.
+func (r *Postgres) execOwnedPostUpdate(ctx context.Context, verb string, postID int64, query string, args ...any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s post %d: %w", verb, postID, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s post %d: %w", verb, postID, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// ListLegacyPhotoPosts returns up to limit posts after afterPostID that
+// still have a legacy photo column set, ordered by id, with their current
+// public.photo row count.
+func (r *Postgres) ListLegacyPhotoPosts(ctx context.Context, afterPostID int64, limit int) ([]domain.LegacyPhotoPost, error) {
+	const query = `
+SELECT
+	p.id,
+	COALESCE(trim(p.image_source1), '') AS image_source1,
+	COALESCE(trim(p.image_source2), '') AS image_source2,
+	COALESCE(trim(p.image_source3), '') AS image_source3,
+	COALESCE(trim(p.image_source4), '') AS image_source4,
+	COALESCE(trim(p.photo1_file_name), '') AS photo1_file_name,
+	COALESCE(trim(p.photo2_file_name), '') AS photo2_file_name,
+	COALESCE(trim(p.photo3_file_name), '') AS photo3_file_name,
+	COALESCE(trim(p.photo4_file_name), '') AS photo4_file_name,
+	(SELECT count(*) FROM public.photo ph WHERE ph.post_id = p.id) AS photo_row_count
+FROM public.post p
+WHERE p.id > $1
+  AND (
+	COALESCE(trim(p.image_source1), '') <> '' OR
+	COALESCE(trim(p.image_source2), '') <> '' OR
+	COALESCE(trim(p.image_source3), '') <> '' OR
+	COALESCE(trim(p.image_source4), '') <> '' OR
+	COALESCE(trim(p.photo1_file_name), '') <> '' OR
+	COALESCE(trim(p.photo2_file_name), '') <> '' OR
+	COALESCE(trim(p.photo3_file_name), '') <> '' OR
+	COALESCE(trim(p.photo4_file_name), '') <> ''
+  )
+ORDER BY p.id ASC
+LIMIT $2
+`
+
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+	rows, err := r.db.QueryContext(ctx, query, afterPostID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying legacy photo posts: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]domain.LegacyPhotoPost, 0, limit)
+	for rows.Next() {
+		var post domain.LegacyPhotoPost
+		if err := rows.Scan(
+			&post.PostID,
+			&post.ImageSources[0],
+			&post.ImageSources[1],
+			&post.ImageSources[2],
+			&post.ImageSources[3],
+			&post.PhotoFileNames[0],
+			&post.PhotoFileNames[1],
+			&post.PhotoFileNames[2],
+			&post.PhotoFileNames[3],
+			&post.PhotoRowCount,
+		); err != nil {
+			return nil, fmt.Errorf("scanning legacy photo post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating legacy photo posts: %w", err)
+	}
+	return posts, nil
+}
+
+// InsertBackfilledPhotos inserts photo rows in one transaction. Rows that
+// conflict with either unique constraint are left alone, which keeps a
+// re-run of the same batch a no-op.
+func (r *Postgres) InsertBackfilledPhotos(ctx context.Context, photos []domain.PostCreateSavedPhoto) (int, error) {
+	if len(photos) == 0 {
+		return 0, nil
+	}
+
+	const query = `
+INSERT INTO public.photo (post_id, s3_key, ticker_s3_key, position, created_at, updated_at)
+VALUES ($1, $2, NULLIF($3, ''), $4, now(), now())
+ON CONFLICT DO NOTHING
+`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("starting backfill transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	inserted := 0
+	for _, photo := range photos {
+		result, err := tx.ExecContext(ctx, query, photo.PostID, strings.TrimSpace(photo.S3Key), strings.TrimSpace(photo.TickerS3Key), photo.Position)
+		if err != nil {
+			return 0, fmt.Errorf("inserting backfilled photo %q: %w", photo.S3Key, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("reading backfill insert result: %w", err)
+		}
+		inserted += int(affected)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing backfill transaction: %w", err)
+	}
+	return inserted, nil
+}
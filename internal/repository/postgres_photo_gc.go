@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListReferencedPhotoKeys returns the subset of keys that appear in
+// public.photo as s3_key or ticker_s3_key.
+func (r *Postgres) ListReferencedPhotoKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	const query = `
+SELECT s3_key FROM public.photo WHERE s3_key = ANY($1)
+UNION
+SELECT ticker_s3_key FROM public.photo WHERE ticker_s3_key = ANY($1)
+`
+
+	referenced := make(map[string]bool, len(keys))
+	if len(keys) == 0 {
+		return referenced, nil
+	}
+	rows, err := r.db.QueryContext(ctx, query, keys)
+	if err != nil {
+		return nil, fmt.Errorf("querying referenced photo keys: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("scanning referenced photo key: %w", err)
+		}
+		referenced[key] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating referenced photo keys: %w", err)
+	}
+	return referenced, nil
+}
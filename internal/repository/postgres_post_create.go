@@ -102,6 +102,7 @@ INSERT INTO public.photo (
 	s3_key,
 	ticker_s3_key,
 	position,
+	perceptual_hash,
 	created_at,
 	updated_at
 ) VALUES (
@@ -109,12 +110,14 @@ INSERT INTO public.photo (
 	$2,
 	$3,
 	$4,
+	$5,
 	now(),
 	now()
 )
 ON CONFLICT (post_id, position) DO UPDATE SET
 	s3_key = EXCLUDED.s3_key,
 	ticker_s3_key = EXCLUDED.ticker_s3_key,
+	perceptual_hash = EXCLUDED.perceptual_hash,
 	updated_at = now()
 `
 
@@ -132,6 +135,11 @@ ON CONFLICT (post_id, position) DO UPDATE SET
 		if tickerKey != "" {
 			tickerValue = tickerKey
 		}
+		var hashValue any
+		if photo.PerceptualHash != 0 {
+			// bigint is signed; the 64 hash bits round-trip through int64.
+			hashValue = int64(photo.PerceptualHash)
+		}
 		if _, err := tx.ExecContext(
 			ctx,
 			query,
@@ -139,6 +147,7 @@ ON CONFLICT (post_id, position) DO UPDATE SET
 			strings.TrimSpace(photo.S3Key),
 			tickerValue,
 			photo.Position,
+			hashValue,
 		); err != nil {
 			return fmt.Errorf("inserting photo metadata for post %d at position %d: %w", photo.PostID, photo.Position, err)
 		}
@@ -149,3 +158,74 @@ ON CONFLICT (post_id, position) DO UPDATE SET
 	}
 	return nil
 }
+
+// DeletePendingPost removes a still-pending post (status 0) and any photo
+// rows for it. Used to roll back a submission whose photo uploads failed.
+func (r *Postgres) DeletePendingPost(ctx context.Context, postID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting pending post delete: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM public.photo WHERE post_id = $1`, postID); err != nil {
+		return fmt.Errorf("deleting photos for pending post %d: %w", postID, err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM public.post WHERE id = $1 AND status = 0`, postID)
+	if err != nil {
+		return fmt.Errorf("deleting pending post %d: %w", postID, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting pending post %d: %w", postID, err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing pending post delete: %w", err)
+	}
+	return nil
+}
+
+// ListRecentPhotoFingerprints returns perceptual hashes of photos on posts by
+// email posted at or after since.
+func (r *Postgres) ListRecentPhotoFingerprints(ctx context.Context, email string, since time.Time) ([]domain.PhotoFingerprint, error) {
+	const query = `
+SELECT
+	ph.post_id,
+	ph.position,
+	ph.perceptual_hash
+FROM public.photo ph
+JOIN public.post p ON p.id = ph.post_id
+WHERE lower(p.email) = lower($1)
+	AND COALESCE(p.time_posted, 0) >= $2
+	AND ph.perceptual_hash IS NOT NULL
+ORDER BY ph.post_id DESC, ph.position ASC
+`
+
+	rows, err := r.db.QueryContext(ctx, query, strings.TrimSpace(email), since.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("querying recent photo fingerprints: %w", err)
+	}
+	defer rows.Close()
+
+	fingerprints := make([]domain.PhotoFingerprint, 0)
+	for rows.Next() {
+		var (
+			fingerprint domain.PhotoFingerprint
+			hash        int64
+		)
+		if err := rows.Scan(&fingerprint.PostID, &fingerprint.Position, &hash); err != nil {
+			return nil, fmt.Errorf("scanning photo fingerprint: %w", err)
+		}
+		fingerprint.PerceptualHash = uint64(hash)
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating photo fingerprints: %w", err)
+	}
+	return fingerprints, nil
+}
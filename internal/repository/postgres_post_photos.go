@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// photoPositionParkOffset moves a post's rows out of the 0..3 range while
+// they are renumbered, so photo_post_position_key never sees two rows at the
+// same position mid-transaction.
+const photoPositionParkOffset = 1000
+
+// GetPostByAccessToken returns the post whose access token matches exactly.
+func (r *Postgres) GetPostByAccessToken(ctx context.Context, accessToken string) (domain.Post, error) {
+	accessToken = strings.TrimSpace(accessToken)
+	if accessToken == "" {
+		return domain.Post{}, domain.ErrNotFound
+	}
+
+	var postID int64
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM public.post WHERE access_token = $1`, accessToken).Scan(&postID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Post{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.Post{}, fmt.Errorf("querying post by access token: %w", err)
+	}
+	return r.GetPostByID(ctx, postID)
+}
+
+// ListPostPhotos returns a post's public.photo rows ordered by position.
+func (r *Postgres) ListPostPhotos(ctx context.Context, postID int64) ([]domain.PostCreateSavedPhoto, error) {
+	const query = `
+SELECT
+	post_id,
+	s3_key,
+	COALESCE(ticker_s3_key, '') AS ticker_s3_key,
+	position,
+	COALESCE(perceptual_hash, 0) AS perceptual_hash
+FROM public.photo
+WHERE post_id = $1
+ORDER BY position ASC
+`
+
+	rows, err := r.db.QueryContext(ctx, query, postID)
+	if err != nil {
+		return nil, fmt.Errorf("querying post photos: %w", err)
+	}
+	defer rows.Close()
+
+	photos := make([]domain.PostCreateSavedPhoto, 0, domain.MaxPostPhotos)
+	for rows.Next() {
+		var (
+			photo domain.PostCreateSavedPhoto
+			hash  int64
+		)
+		if err := rows.Scan(&photo.PostID, &photo.S3Key, &photo.TickerS3Key, &photo.Position, &hash); err != nil {
+			return nil, fmt.Errorf("scanning post photo: %w", err)
+		}
+		photo.PerceptualHash = uint64(hash)
+		photos = append(photos, photo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating post photos: %w", err)
+	}
+	return photos, nil
+}
+
+// ReplacePostPhotos makes photos the post's complete public.photo list in one
+// transaction. Rows whose s3_key is not in photos are deleted, the rest are
+// parked above the position range and then upserted on (post_id, s3_key) at
+// their new positions. has_image is derived from public.photo, so it follows
+// automatically.
+func (r *Postgres) ReplacePostPhotos(ctx context.Context, postID int64, photos []domain.PostCreateSavedPhoto) error {
+	if err := validateReplacementPhotos(postID, photos); err != nil {
+		return err
+	}
+
+	const upsert = `
+INSERT INTO public.photo (
+	post_id,
+	s3_key,
+	ticker_s3_key,
+	position,
+	perceptual_hash,
+	created_at,
+	updated_at
+) VALUES (
+	$1,
+	$2,
+	$3,
+	$4,
+	$5,
+	now(),
+	now()
+)
+ON CONFLICT (post_id, s3_key) DO UPDATE SET
+	position = EXCLUDED.position,
+	ticker_s3_key = COALESCE(EXCLUDED.ticker_s3_key, public.photo.ticker_s3_key),
+	perceptual_hash = COALESCE(EXCLUDED.perceptual_hash, public.photo.perceptual_hash),
+	updated_at = now()
+`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting photo transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	keys := make([]string, 0, len(photos))
+	for _, photo := range photos {
+		keys = append(keys, strings.TrimSpace(photo.S3Key))
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM public.photo WHERE post_id = $1 AND NOT (s3_key = ANY($2))`, postID, keys); err != nil {
+		return fmt.Errorf("deleting removed photos for post %d: %w", postID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE public.photo SET position = position + $2 WHERE post_id = $1`, postID, photoPositionParkOffset); err != nil {
+		return fmt.Errorf("parking photo positions for post %d: %w", postID, err)
+	}
+
+	for _, photo := range photos {
+		var tickerValue any
+		if tickerKey := strings.TrimSpace(photo.TickerS3Key); tickerKey != "" {
+			tickerValue = tickerKey
+		}
+		var hashValue any
+		if photo.PerceptualHash != 0 {
+			hashValue = int64(photo.PerceptualHash)
+		}
+		if _, err := tx.ExecContext(ctx, upsert, postID, strings.TrimSpace(photo.S3Key), tickerValue, photo.Position, hashValue); err != nil {
+			return fmt.Errorf("saving photo for post %d at position %d: %w", postID, photo.Position, err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+UPDATE public.post
+SET time_modified = extract(epoch FROM now())::integer,
+	time_modified_at = now()
+WHERE id = $1
+`, postID)
+	if err != nil {
+		return fmt.Errorf("touching post %d: %w", postID, err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing photo transaction: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/Capmus-Team/supost-cli/internal/domain"
@@ -35,6 +37,134 @@ INSERT INTO app_private.message (
 	now()
 )
 RETURNING
+` + messageSelectColumns
+
+	out, err := scanMessage(r.db.QueryRowContext(ctx, query, message, postID, nullIfEmpty(ip), replyToEmail, userAgent))
+	if err != nil {
+		return domain.Message{}, fmt.Errorf("inserting response message: %w", err)
+	}
+	return out, nil
+}
+
+// GetMessageByID returns one app_private.message row.
+func (r *Postgres) GetMessageByID(ctx context.Context, messageID int64) (domain.Message, error) {
+	const query = `
+SELECT
+` + messageSelectColumns + `
+FROM app_private.message
+WHERE id = $1
+`
+
+	out, err := scanMessage(r.db.QueryRowContext(ctx, query, messageID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Message{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.Message{}, fmt.Errorf("querying message by id: %w", err)
+	}
+	return out, nil
+}
+
+// SetMessageMailgunID records the provider message id returned on send.
+func (r *Postgres) SetMessageMailgunID(ctx context.Context, messageID int64, mailgunMessageID string) error {
+	const query = `
+UPDATE app_private.message
+SET mailgun_message_id = $2,
+	updated_at = now()
+WHERE id = $1
+`
+
+	res, err := r.db.ExecContext(ctx, query, messageID, mailgunMessageID)
+	if err != nil {
+		return fmt.Errorf("updating message mailgun id: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("reading affected rows: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetMessageByMailgunID returns the message correlated with a provider message id.
+func (r *Postgres) GetMessageByMailgunID(ctx context.Context, mailgunMessageID string) (domain.Message, error) {
+	const query = `
+SELECT
+` + messageSelectColumns + `
+FROM app_private.message
+WHERE mailgun_message_id = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+	out, err := scanMessage(r.db.QueryRowContext(ctx, query, mailgunMessageID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Message{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.Message{}, fmt.Errorf("querying message by mailgun id: %w", err)
+	}
+	return out, nil
+}
+
+// UpdateMessageStatus sets the delivery status of one message.
+func (r *Postgres) UpdateMessageStatus(ctx context.Context, messageID int64, status string) (domain.Message, error) {
+	const query = `
+UPDATE app_private.message
+SET status = $2,
+	updated_at = now()
+WHERE id = $1
+RETURNING
+` + messageSelectColumns
+
+	out, err := scanMessage(r.db.QueryRowContext(ctx, query, messageID, status))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Message{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.Message{}, fmt.Errorf("updating message status: %w", err)
+	}
+	return out, nil
+}
+
+// ListMessagesForPosts returns the app_private.message rows on postIDs
+// ordered by post, then oldest first.
+func (r *Postgres) ListMessagesForPosts(ctx context.Context, postIDs []int64) ([]domain.Message, error) {
+	if len(postIDs) == 0 {
+		return []domain.Message{}, nil
+	}
+
+	const query = `
+SELECT
+` + messageSelectColumns + `
+FROM app_private.message
+WHERE post_id = ANY($1)
+ORDER BY post_id ASC, created_at ASC, id ASC
+`
+
+	rows, err := r.db.QueryContext(ctx, query, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("querying messages for posts: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]domain.Message, 0)
+	for rows.Next() {
+		out, err := scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning message: %w", err)
+		}
+		messages = append(messages, out)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating messages for posts: %w", err)
+	}
+	return messages, nil
+}
+
+const messageSelectColumns = `
 	COALESCE(id, 0) AS id,
 	COALESCE(post_id, 0) AS post_id,
 	COALESCE(message, '') AS message,
@@ -43,14 +173,17 @@ RETURNING
 	COALESCE(raw_email::text, '') AS raw_email,
 	COALESCE(source, '') AS source,
 	COALESCE(status, '') AS status,
+	COALESCE(mailgun_message_id, '') AS mailgun_message_id,
 	COALESCE(user_agent, '') AS user_agent,
 	COALESCE(scammed, false) AS scammed,
 	COALESCE(created_at, now()) AS created_at,
 	COALESCE(updated_at, created_at, now()) AS updated_at
 `
 
+// scanMessage reads messageSelectColumns from a *sql.Row or *sql.Rows.
+func scanMessage(row interface{ Scan(dest ...any) error }) (domain.Message, error) {
 	var out domain.Message
-	err := r.db.QueryRowContext(ctx, query, message, postID, nullIfEmpty(ip), replyToEmail, userAgent).Scan(
+	err := row.Scan(
 		&out.ID,
 		&out.PostID,
 		&out.Message,
@@ -59,15 +192,13 @@ RETURNING
 		&out.RawEmail,
 		&out.Source,
 		&out.Status,
+		&out.MailgunMessageID,
 		&out.UserAgent,
 		&out.Scammed,
 		&out.CreatedAt,
 		&out.UpdatedAt,
 	)
-	if err != nil {
-		return domain.Message{}, fmt.Errorf("inserting response message: %w", err)
-	}
-	return out, nil
+	return out, err
 }
 
 func nullIfEmpty(value string) any {
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const profileSelectColumns = `
+	id::text AS id,
+	COALESCE(email::text, '') AS email,
+	COALESCE(display_name, '') AS display_name,
+	COALESCE(phone, '') AS phone,
+	created_at,
+	COALESCE(updated_at, created_at) AS updated_at
+`
+
+// GetProfile reads one public.profiles row by Auth user id.
+func (r *Postgres) GetProfile(ctx context.Context, userID string) (domain.User, error) {
+	const query = `
+SELECT
+` + profileSelectColumns + `
+FROM public.profiles
+WHERE id = $1::uuid
+`
+	profile, err := scanProfile(r.db.QueryRowContext(ctx, query, strings.TrimSpace(userID)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("querying profile: %w", err)
+	}
+	return profile, nil
+}
+
+// CreateProfileIfMissing inserts profile unless a row for its id exists, and
+// returns the stored row. The bool reports whether it was created.
+func (r *Postgres) CreateProfileIfMissing(ctx context.Context, profile domain.User) (domain.User, bool, error) {
+	const insert = `
+INSERT INTO public.profiles (id, email, display_name, phone)
+VALUES ($1::uuid, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''))
+ON CONFLICT (id) DO NOTHING
+RETURNING
+` + profileSelectColumns
+
+	created, err := scanProfile(r.db.QueryRowContext(ctx, insert,
+		strings.TrimSpace(profile.ID),
+		strings.TrimSpace(profile.Email),
+		strings.TrimSpace(profile.DisplayName),
+		strings.TrimSpace(profile.Phone),
+	))
+	if err == nil {
+		return created, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, false, fmt.Errorf("inserting profile: %w", err)
+	}
+
+	existing, err := r.GetProfile(ctx, profile.ID)
+	if err != nil {
+		return domain.User{}, false, err
+	}
+	return existing, false, nil
+}
+
+// UpdateProfile writes the display name and phone of an existing profile.
+func (r *Postgres) UpdateProfile(ctx context.Context, profile domain.User, at time.Time) (domain.User, error) {
+	const query = `
+UPDATE public.profiles
+SET display_name = NULLIF($2, ''),
+	phone = NULLIF($3, ''),
+	updated_at = $4
+WHERE id = $1::uuid
+RETURNING
+` + profileSelectColumns
+
+	updated, err := scanProfile(r.db.QueryRowContext(ctx, query,
+		strings.TrimSpace(profile.ID),
+		strings.TrimSpace(profile.DisplayName),
+		strings.TrimSpace(profile.Phone),
+		at.UTC(),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.User{}, fmt.Errorf("updating profile: %w", err)
+	}
+	return updated, nil
+}
+
+func scanProfile(row interface{ Scan(dest ...any) error }) (domain.User, error) {
+	var profile domain.User
+	err := row.Scan(
+		&profile.ID,
+		&profile.Email,
+		&profile.DisplayName,
+		&profile.Phone,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	return profile, err
+}
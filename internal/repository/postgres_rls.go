@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// Postgres roles Supabase maps requests to. RLS policies name them in
+// their "to" clause.
+const (
+	RLSRoleAnon          = "anon"
+	RLSRoleAuthenticated = "authenticated"
+)
+
+// pgDB is the pool the Postgres adapter queries through. Without an RLS
+// scope every call goes straight to *sql.DB and runs as the connecting
+// role, which bypasses row level security. With a scope each statement
+// runs in its own transaction that first does SET LOCAL role and sets
+// request.jwt.claims, the same way PostgREST serves the web app.
+type pgDB struct {
+	pool  *sql.DB
+	scope *rlsScope
+}
+
+type rlsScope struct {
+	role   string
+	claims string
+}
+
+// WithRLS makes every later statement run as role with claims (the JWT
+// payload JSON) in request.jwt.claims, so auth.uid() and the table
+// policies see the logged-in user. Blank role means anon. Any role other
+// than anon or authenticated is refused: SET LOCAL role would otherwise let
+// a forged claim switch to service_role or the connecting superuser.
+func (r *Postgres) WithRLS(role string, claims []byte) (*Postgres, error) {
+	role = strings.TrimSpace(role)
+	if role == "" {
+		role = RLSRoleAnon
+	}
+	if role != RLSRoleAnon && role != RLSRoleAuthenticated {
+		return nil, fmt.Errorf("%w: role %q is not allowed for row level security", domain.ErrUnauthorized, role)
+	}
+	scopeClaims := strings.TrimSpace(string(claims))
+	if scopeClaims == "" {
+		scopeClaims = fmt.Sprintf(`{"role":%q}`, role)
+	}
+	r.db.scope = &rlsScope{role: role, claims: scopeClaims}
+	return r, nil
+}
+
+func (d *pgDB) Close() error {
+	return d.pool.Close()
+}
+
+// BeginTx starts a transaction, already switched to the RLS role when
+// the pool is scoped.
+func (d *pgDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	tx, err := d.pool.BeginTx(ctx, opts)
+	if err != nil || d.scope == nil {
+		return tx, err
+	}
+	if _, err := tx.ExecContext(ctx, "SET LOCAL role "+pgx.Identifier{d.scope.role}.Sanitize()); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("setting role %s: %w", d.scope.role, err)
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT set_config('request.jwt.claims', $1, true)`, d.scope.claims); err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("setting request.jwt.claims: %w", err)
+	}
+	return tx, nil
+}
+
+func (d *pgDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if d.scope == nil {
+		return d.pool.ExecContext(ctx, query, args...)
+	}
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// QueryContext returns rows whose Close also ends the scoped transaction,
+// so callers keep their usual defer rows.Close().
+func (d *pgDB) QueryContext(ctx context.Context, query string, args ...any) (*pgRows, error) {
+	if d.scope == nil {
+		rows, err := d.pool.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		return &pgRows{Rows: rows}, nil
+	}
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return &pgRows{Rows: rows, tx: tx}, nil
+}
+
+// QueryRowContext defers errors to Scan like *sql.Row does. Scan commits
+// the scoped transaction, so INSERT/UPDATE ... RETURNING keeps its write.
+func (d *pgDB) QueryRowContext(ctx context.Context, query string, args ...any) *pgRow {
+	if d.scope == nil {
+		return &pgRow{row: d.pool.QueryRowContext(ctx, query, args...)}
+	}
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return &pgRow{err: err}
+	}
+	return &pgRow{row: tx.QueryRowContext(ctx, query, args...), tx: tx}
+}
+
+type pgRows struct {
+	*sql.Rows
+	tx *sql.Tx
+}
+
+func (r *pgRows) Close() error {
+	err := r.Rows.Close()
+	if r.tx == nil {
+		return err
+	}
+	tx := r.tx
+	r.tx = nil
+	if err != nil || r.Rows.Err() != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type pgRow struct {
+	row *sql.Row
+	tx  *sql.Tx
+	err error
+}
+
+func (r *pgRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	err := r.row.Scan(dest...)
+	if r.tx == nil {
+		return err
+	}
+	if err != nil {
+		_ = r.tx.Rollback()
+		return err
+	}
+	return r.tx.Commit()
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// openTestPostgres connects to TEST_DATABASE_URL, a local Postgres such as
+// the one `supabase start` runs with the migrations applied.
+func openTestPostgres(t *testing.T) *Postgres {
+	t.Helper()
+	databaseURL := strings.TrimSpace(os.Getenv("TEST_DATABASE_URL"))
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	pg, err := NewPostgres(databaseURL)
+	if err != nil {
+		t.Fatalf("opening test postgres: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = pg.Close()
+	})
+	if err := pg.db.pool.PingContext(t.Context()); err != nil {
+		t.Fatalf("connecting to TEST_DATABASE_URL: %v", err)
+	}
+	return pg
+}
+
+func requireRLSRoles(t *testing.T, pg *Postgres) {
+	t.Helper()
+	var count int
+	err := pg.db.pool.QueryRowContext(t.Context(),
+		`SELECT count(*) FROM pg_roles WHERE rolname IN ('anon', 'authenticated')`).Scan(&count)
+	if err != nil {
+		t.Fatalf("checking roles: %v", err)
+	}
+	if count != 2 {
+		t.Skip("test database has no anon/authenticated roles")
+	}
+}
+
+// withTestRLS scopes pg and fails the test if the role is refused.
+func withTestRLS(t *testing.T, pg *Postgres, role string, claims []byte) *Postgres {
+	t.Helper()
+	scoped, err := pg.WithRLS(role, claims)
+	if err != nil {
+		t.Fatalf("scoping to %s: %v", role, err)
+	}
+	return scoped
+}
+
+func jwtClaims(t *testing.T, userID string) []byte {
+	t.Helper()
+	claims, err := json.Marshal(map[string]any{"sub": userID, "role": RLSRoleAuthenticated})
+	if err != nil {
+		t.Fatalf("encoding claims: %v", err)
+	}
+	return claims
+}
+
+func TestPostgresWithRLS_DefaultsToAnon(t *testing.T) {
+	pg, err := NewPostgres("postgres://u:p@localhost:5432/db")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer pg.Close()
+
+	withTestRLS(t, pg, " ", nil)
+	if pg.db.scope.role != RLSRoleAnon || pg.db.scope.claims != `{"role":"anon"}` {
+		t.Fatalf("expected the anon scope, got %+v", pg.db.scope)
+	}
+}
+
+func TestPostgresWithRLS_RejectsPrivilegedRoles(t *testing.T) {
+	pg, err := NewPostgres("postgres://u:p@localhost:5432/db")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer pg.Close()
+
+	for _, role := range []string{"service_role", "postgres", "supabase_admin", "Authenticated"} {
+		forged, err := json.Marshal(map[string]any{"sub": "00000000-0000-0000-0000-000000000001", "role": role})
+		if err != nil {
+			t.Fatalf("encoding claims: %v", err)
+		}
+		if _, err := pg.WithRLS(role, forged); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("expected role %q refused, got %v", role, err)
+		}
+		if pg.db.scope != nil {
+			t.Fatalf("expected no scope after refusing %q, got %+v", role, pg.db.scope)
+		}
+	}
+}
+
+func TestPostgresRLS_SetsLocalRoleAndClaims(t *testing.T) {
+	pg := openTestPostgres(t)
+	requireRLSRoles(t, pg)
+	withTestRLS(t, pg, RLSRoleAuthenticated, jwtClaims(t, "00000000-0000-0000-0000-000000000001"))
+
+	var role, sub string
+	err := pg.db.QueryRowContext(t.Context(),
+		`SELECT current_user::text, current_setting('request.jwt.claims', true)::json->>'sub'`).Scan(&role, &sub)
+	if err != nil {
+		t.Fatalf("unexpected query error: %v", err)
+	}
+	if role != RLSRoleAuthenticated || sub != "00000000-0000-0000-0000-000000000001" {
+		t.Fatalf("expected the scoped role and claims, got %q, %q", role, sub)
+	}
+
+	// SET LOCAL ends with the transaction; the pooled connection is clean.
+	if err := pg.db.pool.QueryRowContext(t.Context(), `SELECT current_user::text`).Scan(&role); err != nil {
+		t.Fatalf("unexpected query error: %v", err)
+	}
+	if role == RLSRoleAuthenticated {
+		t.Fatalf("expected the role reset after the transaction")
+	}
+}
+
+func TestPostgresRLS_AnonVersusAuthenticatedVisibility(t *testing.T) {
+	admin := openTestPostgres(t)
+	requireRLSRoles(t, admin)
+	ctx := t.Context()
+	var profilesTable sql.NullString
+	if err := admin.db.pool.QueryRowContext(ctx, `SELECT to_regclass('public.profiles')::text`).Scan(&profilesTable); err != nil || !profilesTable.Valid {
+		t.Skip("test database has no public.profiles; apply the supabase migrations")
+	}
+
+	newUser := func(email string) string {
+		var id string
+		err := admin.db.pool.QueryRowContext(ctx,
+			`INSERT INTO auth.users (id, email) VALUES (gen_random_uuid(), $1) RETURNING id::text`, email).Scan(&id)
+		if err != nil {
+			t.Fatalf("creating auth user: %v", err)
+		}
+		t.Cleanup(func() {
+			_, _ = admin.db.pool.ExecContext(context.Background(), `DELETE FROM auth.users WHERE id = $1::uuid`, id)
+		})
+		return id
+	}
+	owner := newUser("rls-owner@stanford.edu")
+	other := newUser("rls-other@stanford.edu")
+	if _, _, err := admin.CreateProfileIfMissing(ctx, domain.User{ID: owner, Email: "rls-owner@stanford.edu", DisplayName: "Owner"}); err != nil {
+		t.Fatalf("seeding profile: %v", err)
+	}
+
+	asAnon := withTestRLS(t, openTestPostgres(t), RLSRoleAnon, nil)
+	asOwner := withTestRLS(t, openTestPostgres(t), RLSRoleAuthenticated, jwtClaims(t, owner))
+	asOther := withTestRLS(t, openTestPostgres(t), RLSRoleAuthenticated, jwtClaims(t, other))
+
+	// Public policies: anon and authenticated see what the admin role sees.
+	allCategories, err := admin.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("listing categories: %v", err)
+	}
+	allPosts, err := admin.ListRecentActivePosts(ctx, maxRecentActivePosts)
+	if err != nil {
+		t.Fatalf("listing posts: %v", err)
+	}
+	for name, pg := range map[string]*Postgres{"anon": asAnon, "authenticated": asOwner} {
+		categories, err := pg.ListCategories(ctx)
+		if err != nil || len(categories) != len(allCategories) {
+			t.Fatalf("%s: expected %d categories via category_public_read, got %d, %v", name, len(allCategories), len(categories), err)
+		}
+		posts, err := pg.ListRecentActivePosts(ctx, maxRecentActivePosts)
+		if err != nil || len(posts) != len(allPosts) {
+			t.Fatalf("%s: expected %d posts via post_select_public, got %d, %v", name, len(allPosts), len(posts), err)
+		}
+	}
+
+	// Owner policies: only the owner reads or edits the profile.
+	if _, err := asAnon.GetProfile(ctx, owner); err == nil {
+		t.Fatalf("expected anon unable to read a profile")
+	}
+	if _, err := asOther.GetProfile(ctx, owner); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected another user's profile hidden, got %v", err)
+	}
+	if _, err := asOther.UpdateProfile(ctx, domain.User{ID: owner, DisplayName: "Mallory"}, time.Now()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected another user's profile update refused, got %v", err)
+	}
+	updated, err := asOwner.UpdateProfile(ctx, domain.User{ID: owner, DisplayName: "Owner Renamed"}, time.Now())
+	if err != nil || updated.DisplayName != "Owner Renamed" {
+		t.Fatalf("expected the owner to update their profile, got %+v, %v", updated, err)
+	}
+	if _, _, err := asOther.CreateProfileIfMissing(ctx, domain.User{ID: owner}); err == nil {
+		t.Fatalf("expected an insert for someone else's id refused")
+	}
+}
@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	defaultSupabaseRESTTimeout = 15 * time.Second
+	maxSupabaseRESTResponse    = 8 << 20
+)
+
+// restPostColumns mirrors the list queries of the Postgres adapter, minus
+// email and access_token: those are private to the poster, and anon must
+// not be granted them. The embedded photo rows stand in for its EXISTS
+// subquery on public.photo.
+const restPostColumns = "id,category_id,subcategory_id,name,status,time_posted,time_modified," +
+	"time_posted_at,time_modified_at,price," +
+	"photo1_file_name,photo2_file_name,photo3_file_name,photo4_file_name," +
+	"image_source1,image_source2,image_source3,image_source4," +
+	"created_at,updated_at,photo(position,s3_key)"
+
+// restPostDetailColumns adds the body for the single-post view.
+const restPostDetailColumns = restPostColumns + ",body"
+
+// SupabaseREST implements the home, post, category, search, post create,
+// and respond repositories over Supabase's PostgREST API (/rest/v1). It
+// needs only the project URL and publishable key, not a database password.
+// Requests carry the key as the bearer, or a user's access token after
+// WithAccessToken, so the table's RLS policies decide what is visible.
+type SupabaseREST struct {
+	baseURL     string
+	apiKey      string
+	accessToken string
+	client      *http.Client
+	now         func() time.Time
+
+	// pendingTokens remembers the access token of each post created here,
+	// which delete_pending_post needs to roll the post back.
+	mu            sync.Mutex
+	pendingTokens map[int64]string
+}
+
+// restRequest is one PostgREST call. table may also name a function as
+// rpc/<name>.
+type restRequest struct {
+	method string
+	table  string
+	query  url.Values
+	body   any
+	prefer string
+}
+
+// restError is PostgREST's error body.
+type restError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details"`
+	Hint    string `json:"hint"`
+}
+
+type restPhotoRow struct {
+	Position int    `json:"position"`
+	S3Key    string `json:"s3_key"`
+}
+
+// restPostRow decodes a post row. JSON nulls leave the embedded fields at
+// their zero values, which matches the Postgres adapter's COALESCE; price
+// stays a pointer so has_price can be derived.
+type restPostRow struct {
+	domain.Post
+	Price  *float64       `json:"price"`
+	Photos []restPhotoRow `json:"photo"`
+}
+
+// NewSupabaseREST builds a PostgREST client for the project at baseURL.
+func NewSupabaseREST(baseURL string, apiKey string) (*SupabaseREST, error) {
+	trimmedBaseURL := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if trimmedBaseURL == "" {
+		return nil, fmt.Errorf("supabase_url is required")
+	}
+	trimmedAPIKey := strings.TrimSpace(apiKey)
+	if trimmedAPIKey == "" {
+		return nil, fmt.Errorf("supabase publishable/anon key is required")
+	}
+	return &SupabaseREST{
+		baseURL: trimmedBaseURL,
+		apiKey:  trimmedAPIKey,
+		client:  &http.Client{Timeout: defaultSupabaseRESTTimeout},
+		now:     time.Now,
+
+		pendingTokens: make(map[int64]string),
+	}, nil
+}
+
+// WithAccessToken sends a logged-in user's JWT as the bearer, so requests
+// run as the authenticated role instead of anon.
+func (r *SupabaseREST) WithAccessToken(accessToken string) *SupabaseREST {
+	r.accessToken = strings.TrimSpace(accessToken)
+	return r
+}
+
+// Close is a no-op; it lets callers treat SupabaseREST like Postgres.
+func (r *SupabaseREST) Close() error {
+	return nil
+}
+
+// ListRecentActivePosts returns status=1 posts sorted by newest first.
+func (r *SupabaseREST) ListRecentActivePosts(ctx context.Context, limit int) ([]domain.Post, error) {
+	return r.listActivePosts(ctx, "recent active posts", url.Values{}, clampRecentLimit(limit))
+}
+
+// ListRecentActivePostsByCategory returns active posts in one category sorted by newest first.
+func (r *SupabaseREST) ListRecentActivePostsByCategory(ctx context.Context, categoryID int64, limit int) ([]domain.Post, error) {
+	filters := url.Values{"category_id": {"eq." + strconv.FormatInt(categoryID, 10)}}
+	return r.listActivePosts(ctx, "recent active posts by category", filters, clampRecentLimit(limit))
+}
+
+func (r *SupabaseREST) listActivePosts(ctx context.Context, what string, query url.Values, limit int) ([]domain.Post, error) {
+	query.Set("select", restPostColumns)
+	query.Set("status", "eq."+strconv.Itoa(domain.PostStatusActive))
+	query.Set("order", "time_posted.desc,id.desc")
+	query.Set("limit", strconv.Itoa(limit))
+
+	var rows []restPostRow
+	if err := r.do(ctx, restRequest{method: http.MethodGet, table: "post", query: query}, &rows); err != nil {
+		return nil, fmt.Errorf("querying %s: %w", what, err)
+	}
+	posts := make([]domain.Post, 0, len(rows))
+	for _, row := range rows {
+		posts = append(posts, row.toDomain(r.now(), false))
+	}
+	return posts, nil
+}
+
+// GetPostByID returns one post by identifier.
+func (r *SupabaseREST) GetPostByID(ctx context.Context, postID int64) (domain.Post, error) {
+	query := url.Values{
+		"select": {restPostDetailColumns},
+		"id":     {"eq." + strconv.FormatInt(postID, 10)},
+	}
+	var rows []restPostRow
+	if err := r.do(ctx, restRequest{method: http.MethodGet, table: "post", query: query}, &rows); err != nil {
+		return domain.Post{}, fmt.Errorf("querying post by id: %w", err)
+	}
+	if len(rows) == 0 {
+		return domain.Post{}, domain.ErrNotFound
+	}
+	return rows[0].toDomain(r.now(), true), nil
+}
+
+// ListCategories returns categories from public.category sorted by id.
+func (r *SupabaseREST) ListCategories(ctx context.Context) ([]domain.Category, error) {
+	query := url.Values{"select": {"id,name,short_name"}, "order": {"id.asc"}}
+	categories := make([]domain.Category, 0, 32)
+	if err := r.do(ctx, restRequest{method: http.MethodGet, table: "category", query: query}, &categories); err != nil {
+		return nil, fmt.Errorf("querying categories: %w", err)
+	}
+	return categories, nil
+}
+
+// ListSubcategories returns subcategories from public.subcategory sorted by category/id.
+func (r *SupabaseREST) ListSubcategories(ctx context.Context) ([]domain.Subcategory, error) {
+	query := url.Values{"select": {"id,category_id,name"}, "order": {"category_id.asc,id.asc"}}
+	subcategories := make([]domain.Subcategory, 0, 128)
+	if err := r.do(ctx, restRequest{method: http.MethodGet, table: "subcategory", query: query}, &subcategories); err != nil {
+		return nil, fmt.Errorf("querying subcategories: %w", err)
+	}
+	return subcategories, nil
+}
+
+// ListHomeCategorySections returns latest active post time per category.
+// The newest active post is embedded per category, like the Postgres
+// adapter's lateral join; categories without one are left out.
+func (r *SupabaseREST) ListHomeCategorySections(ctx context.Context) ([]domain.HomeCategorySection, error) {
+	query := url.Values{
+		"select":      {"id,post(time_posted,time_posted_at)"},
+		"post.status": {"eq." + strconv.Itoa(domain.PostStatusActive)},
+		"post.order":  {"time_posted.desc,id.desc"},
+		"post.limit":  {"1"},
+		"order":       {"id.asc"},
+	}
+	var rows []struct {
+		ID    int64 `json:"id"`
+		Posts []struct {
+			TimePosted   int64      `json:"time_posted"`
+			TimePostedAt *time.Time `json:"time_posted_at"`
+		} `json:"post"`
+	}
+	if err := r.do(ctx, restRequest{method: http.MethodGet, table: "category", query: query}, &rows); err != nil {
+		return nil, fmt.Errorf("querying home category sections: %w", err)
+	}
+
+	sections := make([]domain.HomeCategorySection, 0, len(rows))
+	for _, row := range rows {
+		if len(row.Posts) == 0 {
+			continue
+		}
+		section := domain.HomeCategorySection{CategoryID: row.ID}
+		latest := row.Posts[0]
+		switch {
+		case latest.TimePostedAt != nil:
+			section.LastPostedAt = *latest.TimePostedAt
+		case latest.TimePosted > 0:
+			section.LastPostedAt = time.Unix(latest.TimePosted, 0).UTC()
+		}
+		sections = append(sections, section)
+	}
+	return sections, nil
+}
+
+// toDomain fills the derived columns the Postgres adapter computes in SQL.
+// With detail set, photo rows override the legacy image sources by
+// position, as in GetPostByID.
+func (row restPostRow) toDomain(now time.Time, detail bool) domain.Post {
+	post := row.Post
+	if row.Price != nil {
+		post.Price, post.HasPrice = *row.Price, true
+	}
+	post.HasImage = len(row.Photos) > 0
+	for _, value := range []string{
+		post.Photo1File, post.Photo2File, post.Photo3File, post.Photo4File,
+		post.ImageSource1, post.ImageSource2, post.ImageSource3, post.ImageSource4,
+	} {
+		post.HasImage = post.HasImage || value != ""
+	}
+	if detail {
+		sources := []*string{&post.ImageSource1, &post.ImageSource2, &post.ImageSource3, &post.ImageSource4}
+		for _, photo := range row.Photos {
+			if photo.Position >= 0 && photo.Position < len(sources) {
+				*sources[photo.Position] = photo.S3Key
+			}
+		}
+	} else {
+		// The list queries do not select these columns.
+		post.Photo1File, post.Photo2File, post.Photo3File, post.Photo4File = "", "", "", ""
+		post.ImageSource1, post.ImageSource2, post.ImageSource3, post.ImageSource4 = "", "", "", ""
+	}
+	if post.TimePostedAt.IsZero() {
+		post.TimePostedAt = time.Unix(0, 0).UTC()
+	}
+	if post.TimeModifiedAt.IsZero() {
+		post.TimeModifiedAt = time.Unix(0, 0).UTC()
+	}
+	if post.CreatedAt.IsZero() {
+		post.CreatedAt = now
+	}
+	if post.UpdatedAt.IsZero() {
+		post.UpdatedAt = post.CreatedAt
+	}
+	return post
+}
+
+// do sends one PostgREST request and decodes the JSON response into out
+// when out is not nil.
+func (r *SupabaseREST) do(ctx context.Context, request restRequest, out any) error {
+	var body io.Reader
+	if request.body != nil {
+		encoded, err := json.Marshal(request.body)
+		if err != nil {
+			return fmt.Errorf("encoding %s request: %w", request.table, err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	endpoint := r.baseURL + "/rest/v1/" + request.table
+	if len(request.query) > 0 {
+		endpoint += "?" + request.query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, request.method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	bearer := r.apiKey
+	if r.accessToken != "" {
+		bearer = r.accessToken
+	}
+	req.Header.Set("apikey", r.apiKey)
+	req.Header.Set("Authorization", "Bearer "+bearer)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if request.prefer != "" {
+		req.Header.Set("Prefer", request.prefer)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxSupabaseRESTResponse))
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return supabaseRESTStatusError(resp.StatusCode, raw)
+	}
+	if out == nil || len(bytes.TrimSpace(raw)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+func supabaseRESTStatusError(statusCode int, raw []byte) error {
+	var decoded restError
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded.Message == "" {
+		return fmt.Errorf("supabase rest returned status %d: %s", statusCode, strings.TrimSpace(string(raw)))
+	}
+	message := decoded.Message
+	if decoded.Details != "" {
+		message += " (" + decoded.Details + ")"
+	}
+	if decoded.Hint != "" {
+		message += "; hint: " + decoded.Hint
+	}
+	if decoded.Code != "" {
+		return fmt.Errorf("supabase rest returned status %d: %s: %s", statusCode, decoded.Code, message)
+	}
+	return fmt.Errorf("supabase rest returned status %d: %s", statusCode, message)
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+type restPostInsert struct {
+	CollegeID      int       `json:"college_id"`
+	CategoryID     int64     `json:"category_id"`
+	SubcategoryID  int64     `json:"subcategory_id"`
+	Email          string    `json:"email"`
+	IP             *string   `json:"ip"`
+	Name           string    `json:"name"`
+	Body           string    `json:"body"`
+	Status         int       `json:"status"`
+	TimePosted     int64     `json:"time_posted"`
+	TimeModified   int64     `json:"time_modified"`
+	TimePostedAt   time.Time `json:"time_posted_at"`
+	TimeModifiedAt time.Time `json:"time_modified_at"`
+	AccessToken    string    `json:"access_token"`
+	Price          *float64  `json:"price"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type restPhotoUpsert struct {
+	PostID         int64     `json:"post_id"`
+	S3Key          string    `json:"s3_key"`
+	TickerS3Key    *string   `json:"ticker_s3_key"`
+	Position       int       `json:"position"`
+	PerceptualHash *int64    `json:"perceptual_hash"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// CreatePendingPost inserts a status 0 post and returns its id, access
+// token, and posted time.
+func (r *SupabaseREST) CreatePendingPost(ctx context.Context, submission domain.PostCreateSubmission) (domain.PostCreatePersisted, error) {
+	postedAt := submission.PostedAt
+	if postedAt.IsZero() {
+		postedAt = r.now()
+	}
+	postedAt = time.Unix(postedAt.Unix(), 0).UTC()
+
+	insert := restPostInsert{
+		CollegeID:      1,
+		CategoryID:     submission.CategoryID,
+		SubcategoryID:  submission.SubcategoryID,
+		Email:          submission.Email,
+		Name:           submission.Name,
+		Body:           submission.Body,
+		TimePosted:     postedAt.Unix(),
+		TimeModified:   postedAt.Unix(),
+		TimePostedAt:   postedAt,
+		TimeModifiedAt: postedAt,
+		AccessToken:    submission.AccessToken,
+		UpdatedAt:      r.now().UTC(),
+	}
+	if submission.IP != "" {
+		insert.IP = &submission.IP
+	}
+	if submission.PriceProvided {
+		insert.Price = &submission.Price
+	}
+
+	// access_token is not read back: the caller already has it, and reading
+	// it would need a column grant anon must not have.
+	var rows []struct {
+		ID           int64      `json:"id"`
+		TimePostedAt *time.Time `json:"time_posted_at"`
+	}
+	err := r.do(ctx, restRequest{
+		method: http.MethodPost,
+		table:  "post",
+		query:  url.Values{"select": {"id,time_posted_at"}},
+		body:   insert,
+		prefer: "return=representation",
+	}, &rows)
+	if err != nil {
+		return domain.PostCreatePersisted{}, fmt.Errorf("inserting post: %w", err)
+	}
+	if len(rows) == 0 {
+		return domain.PostCreatePersisted{}, fmt.Errorf("inserting post: no row returned (check the insert policy on public.post)")
+	}
+
+	r.mu.Lock()
+	r.pendingTokens[rows[0].ID] = submission.AccessToken
+	r.mu.Unlock()
+
+	persisted := domain.PostCreatePersisted{PostID: rows[0].ID, AccessToken: submission.AccessToken, PostedAt: postedAt}
+	if rows[0].TimePostedAt != nil {
+		persisted.PostedAt = *rows[0].TimePostedAt
+	}
+	return persisted, nil
+}
+
+// SavePostPhotos upserts uploaded photo metadata into public.photo in one
+// request, so the rows land together or not at all.
+func (r *SupabaseREST) SavePostPhotos(ctx context.Context, photos []domain.PostCreateSavedPhoto) error {
+	if len(photos) == 0 {
+		return nil
+	}
+
+	now := r.now().UTC()
+	rows := make([]restPhotoUpsert, 0, len(photos))
+	for _, photo := range photos {
+		row := restPhotoUpsert{
+			PostID:    photo.PostID,
+			S3Key:     strings.TrimSpace(photo.S3Key),
+			Position:  photo.Position,
+			UpdatedAt: now,
+		}
+		if tickerKey := strings.TrimSpace(photo.TickerS3Key); tickerKey != "" {
+			row.TickerS3Key = &tickerKey
+		}
+		if photo.PerceptualHash != 0 {
+			// bigint is signed; the 64 hash bits round-trip through int64.
+			hash := int64(photo.PerceptualHash)
+			row.PerceptualHash = &hash
+		}
+		rows = append(rows, row)
+	}
+
+	err := r.do(ctx, restRequest{
+		method: http.MethodPost,
+		table:  "photo",
+		query:  url.Values{"on_conflict": {"post_id,position"}},
+		body:   rows,
+		prefer: "resolution=merge-duplicates,return=minimal",
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("inserting photo metadata for post %d: %w", photos[0].PostID, err)
+	}
+	return nil
+}
+
+// DeletePendingPost removes a still-pending post (status 0) that this
+// client created, through the delete_pending_post function with the post's
+// access token; anon has no delete policy. Its photo rows go with it through
+// the ON DELETE CASCADE foreign key.
+func (r *SupabaseREST) DeletePendingPost(ctx context.Context, postID int64) error {
+	r.mu.Lock()
+	accessToken, ok := r.pendingTokens[postID]
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: pending post %d was not created by this client", domain.ErrNotFound, postID)
+	}
+
+	var deleted bool
+	err := r.do(ctx, restRequest{
+		method: http.MethodPost,
+		table:  "rpc/delete_pending_post",
+		body: map[string]any{
+			"p_post_id":      postID,
+			"p_access_token": accessToken,
+		},
+	}, &deleted)
+	if err != nil {
+		return fmt.Errorf("deleting pending post %d: %w", postID, err)
+	}
+	if !deleted {
+		return domain.ErrNotFound
+	}
+	r.mu.Lock()
+	delete(r.pendingTokens, postID)
+	r.mu.Unlock()
+	return nil
+}
+
+// ListRecentPhotoFingerprints returns perceptual hashes of photos on posts by
+// email posted at or after since. post.email is citext, so eq ignores case.
+// Filtering on the poster's email is owner-scoped: it runs only with the
+// user's JWT. Anon cannot read poster emails, so without a login there are
+// no recent photos to compare and the lookup is skipped.
+func (r *SupabaseREST) ListRecentPhotoFingerprints(ctx context.Context, email string, since time.Time) ([]domain.PhotoFingerprint, error) {
+	if r.accessToken == "" {
+		return nil, nil
+	}
+	query := url.Values{
+		"select":           {"post_id,position,perceptual_hash,post!inner(email,time_posted)"},
+		"post.email":       {"eq." + strings.TrimSpace(email)},
+		"post.time_posted": {"gte." + strconv.FormatInt(since.Unix(), 10)},
+		"perceptual_hash":  {"not.is.null"},
+		"order":            {"post_id.desc,position.asc"},
+	}
+	var rows []struct {
+		PostID         int64 `json:"post_id"`
+		Position       int   `json:"position"`
+		PerceptualHash int64 `json:"perceptual_hash"`
+	}
+	if err := r.do(ctx, restRequest{method: http.MethodGet, table: "photo", query: query}, &rows); err != nil {
+		return nil, fmt.Errorf("querying recent photo fingerprints: %w", err)
+	}
+
+	fingerprints := make([]domain.PhotoFingerprint, 0, len(rows))
+	for _, row := range rows {
+		fingerprints = append(fingerprints, domain.PhotoFingerprint{
+			PostID:         row.PostID,
+			Position:       row.Position,
+			PerceptualHash: uint64(row.PerceptualHash),
+		})
+	}
+	return fingerprints, nil
+}
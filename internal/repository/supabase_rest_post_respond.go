@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// restMessageRow is a row returned by public.respond_to_post: the stored
+// message plus the poster's address, which anon cannot select directly.
+type restMessageRow struct {
+	domain.Message
+	PostEmail string `json:"post_email"`
+}
+
+// RecordPostResponse stores a queued response through the respond_to_post
+// function and returns it with the poster's email. The function is SECURITY
+// DEFINER, so neither app_private nor post.email has to be exposed to anon.
+func (r *SupabaseREST) RecordPostResponse(ctx context.Context, postID int64, replyToEmail, message, ip, userAgent string) (domain.Message, string, error) {
+	params := map[string]any{
+		"p_post_id":    postID,
+		"p_reply_to":   replyToEmail,
+		"p_message":    message,
+		"p_ip":         nullIfBlank(ip),
+		"p_user_agent": nullIfBlank(userAgent),
+	}
+	var rows []restMessageRow
+	if err := r.do(ctx, restRequest{method: http.MethodPost, table: "rpc/respond_to_post", body: params}, &rows); err != nil {
+		return domain.Message{}, "", fmt.Errorf("recording response message: %w", err)
+	}
+	if len(rows) == 0 {
+		return domain.Message{}, "", fmt.Errorf("recording response message: no row returned")
+	}
+	return rows[0].Message, strings.TrimSpace(rows[0].PostEmail), nil
+}
+
+// CreateResponseMessage stores a queued response to a post.
+func (r *SupabaseREST) CreateResponseMessage(ctx context.Context, postID int64, replyToEmail, message, ip, userAgent string) (domain.Message, error) {
+	saved, _, err := r.RecordPostResponse(ctx, postID, replyToEmail, message, ip, userAgent)
+	return saved, err
+}
+
+// SetMessageMailgunID records the provider message id returned on send. The
+// set_response_mailgun_id function only fills an empty id.
+func (r *SupabaseREST) SetMessageMailgunID(ctx context.Context, messageID int64, mailgunMessageID string) error {
+	var updated bool
+	err := r.do(ctx, restRequest{
+		method: http.MethodPost,
+		table:  "rpc/set_response_mailgun_id",
+		body: map[string]any{
+			"p_message_id":         messageID,
+			"p_mailgun_message_id": mailgunMessageID,
+		},
+	}, &updated)
+	if err != nil {
+		return fmt.Errorf("updating message mailgun id: %w", err)
+	}
+	if !updated {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func nullIfBlank(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
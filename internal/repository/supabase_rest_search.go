@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// SearchActivePosts matches text with plainto_tsquery('english') on the fts
+// column, like the Postgres adapter. PostgREST cannot order by ts_rank, so
+// matches come back newest first.
+func (r *SupabaseREST) SearchActivePosts(ctx context.Context, queryText string, categoryID, subcategoryID int64, page, perPage int) ([]domain.Post, bool, error) {
+	queryText = strings.TrimSpace(queryText)
+	if page < 1 {
+		page = 1
+	}
+	if perPage <= 0 {
+		perPage = 100
+	}
+
+	query := url.Values{
+		"select": {restPostColumns},
+		"status": {"eq." + strconv.Itoa(domain.PostStatusActive)},
+		"order":  {"time_posted.desc,id.desc"},
+		"limit":  {strconv.Itoa(perPage + 1)},
+		"offset": {strconv.Itoa((page - 1) * perPage)},
+	}
+	if categoryID > 0 {
+		query.Set("category_id", "eq."+strconv.FormatInt(categoryID, 10))
+	}
+	if subcategoryID > 0 {
+		query.Set("subcategory_id", "eq."+strconv.FormatInt(subcategoryID, 10))
+	}
+	if queryText != "" {
+		query.Set("fts", "plfts(english)."+queryText)
+	}
+
+	var rows []restPostRow
+	if err := r.do(ctx, restRequest{method: http.MethodGet, table: "post", query: query}, &rows); err != nil {
+		return nil, false, fmt.Errorf("querying search posts: %w", err)
+	}
+	posts := make([]domain.Post, 0, len(rows))
+	for _, row := range rows {
+		posts = append(posts, row.toDomain(r.now(), false))
+	}
+
+	hasMore := len(posts) > perPage
+	if hasMore {
+		posts = posts[:perPage]
+	}
+	return posts, hasMore, nil
+}
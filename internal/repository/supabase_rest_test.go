@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// fakePostgREST records the last request and answers with a fixed status
+// and body.
+type fakePostgREST struct {
+	status int
+	body   string
+
+	request *http.Request
+	payload []byte
+}
+
+func newFakeSupabaseREST(t *testing.T, fake *fakePostgREST) *SupabaseREST {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		fake.request = req
+		fake.payload, _ = io.ReadAll(req.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(fake.status)
+		_, _ = io.WriteString(w, fake.body)
+	}))
+	t.Cleanup(server.Close)
+
+	rest, err := NewSupabaseREST(server.URL+"/", "sb_publishable_test")
+	if err != nil {
+		t.Fatalf("NewSupabaseREST: %v", err)
+	}
+	rest.now = func() time.Time { return time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC) }
+	return rest
+}
+
+func TestNewSupabaseREST_RequiresURLAndKey(t *testing.T) {
+	if _, err := NewSupabaseREST(" ", "key"); err == nil {
+		t.Fatalf("expected an error without a url")
+	}
+	if _, err := NewSupabaseREST("https://example.supabase.co", " "); err == nil {
+		t.Fatalf("expected an error without a key")
+	}
+}
+
+func TestSupabaseREST_GetPostByID_DecodesDerivedColumns(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusOK, body: `[{
+		"id": 42, "category_id": 5, "name": "Desk", "body": "Sturdy", "status": 1,
+		"time_posted": 1760000000, "time_posted_at": "2025-10-09T08:53:20Z",
+		"price": null, "image_source1": "legacy/one.jpg", "image_source2": null,
+		"photo": [{"position": 1, "s3_key": "photos/two.jpg"}]
+	}]`}
+	rest := newFakeSupabaseREST(t, fake).WithAccessToken("user-jwt")
+
+	post, err := rest.GetPostByID(t.Context(), 42)
+	if err != nil {
+		t.Fatalf("GetPostByID: %v", err)
+	}
+	if post.ID != 42 || post.Body != "Sturdy" || post.HasPrice || !post.HasImage {
+		t.Fatalf("unexpected post %+v", post)
+	}
+	if post.ImageSource1 != "legacy/one.jpg" || post.ImageSource2 != "photos/two.jpg" {
+		t.Fatalf("expected photo rows to fill image sources by position, got %q, %q", post.ImageSource1, post.ImageSource2)
+	}
+	if !post.TimeModifiedAt.Equal(time.Unix(0, 0)) {
+		t.Fatalf("expected a null time_modified_at to read as the epoch, got %v", post.TimeModifiedAt)
+	}
+
+	req := fake.request
+	if req.Method != http.MethodGet || req.URL.Path != "/rest/v1/post" {
+		t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+	}
+	if got := req.URL.Query().Get("id"); got != "eq.42" {
+		t.Fatalf("expected id filter eq.42, got %q", got)
+	}
+	if got := req.URL.Query().Get("select"); !strings.Contains(got, ",body") || !strings.Contains(got, "photo(position,s3_key)") {
+		t.Fatalf("expected the detail columns with embedded photos, got %q", got)
+	}
+	for _, column := range strings.Split(req.URL.Query().Get("select"), ",") {
+		if column == "email" || column == "access_token" {
+			t.Fatalf("expected the public select to leave out %s", column)
+		}
+	}
+	if req.Header.Get("apikey") != "sb_publishable_test" || req.Header.Get("Authorization") != "Bearer user-jwt" {
+		t.Fatalf("expected the key as apikey and the user token as bearer, got %q and %q", req.Header.Get("apikey"), req.Header.Get("Authorization"))
+	}
+}
+
+func TestSupabaseREST_GetPostByID_EmptyResultIsNotFound(t *testing.T) {
+	rest := newFakeSupabaseREST(t, &fakePostgREST{status: http.StatusOK, body: `[]`})
+
+	if _, err := rest.GetPostByID(t.Context(), 7); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSupabaseREST_SearchActivePosts_PagesWithOneExtraRow(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusOK, body: `[
+		{"id": 3, "status": 1, "price": 10},
+		{"id": 2, "status": 1},
+		{"id": 1, "status": 1}
+	]`}
+	rest := newFakeSupabaseREST(t, fake)
+
+	posts, hasMore, err := rest.SearchActivePosts(t.Context(), " desk lamp ", 5, 14, 3, 2)
+	if err != nil {
+		t.Fatalf("SearchActivePosts: %v", err)
+	}
+	if len(posts) != 2 || !hasMore || posts[0].ID != 3 || !posts[0].HasPrice || posts[0].Price != 10 {
+		t.Fatalf("expected two posts and hasMore, got %+v, %v", posts, hasMore)
+	}
+
+	query := fake.request.URL.Query()
+	want := map[string]string{
+		"fts":            "plfts(english).desk lamp",
+		"status":         "eq.1",
+		"category_id":    "eq.5",
+		"subcategory_id": "eq.14",
+		"limit":          "3",
+		"offset":         "4",
+		"order":          "time_posted.desc,id.desc",
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Fatalf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+	if fake.request.Header.Get("Authorization") != "Bearer sb_publishable_test" {
+		t.Fatalf("expected the key as bearer without a user token, got %q", fake.request.Header.Get("Authorization"))
+	}
+}
+
+func TestSupabaseREST_SavePostPhotos_UpsertsByPosition(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusCreated}
+	rest := newFakeSupabaseREST(t, fake)
+
+	err := rest.SavePostPhotos(t.Context(), []domain.PostCreateSavedPhoto{
+		{PostID: 9, S3Key: " photos/a.jpg ", Position: 0, PerceptualHash: 1<<63 | 1},
+		{PostID: 9, S3Key: "photos/b.jpg", TickerS3Key: "photos/b_ticker.jpg", Position: 1},
+	})
+	if err != nil {
+		t.Fatalf("SavePostPhotos: %v", err)
+	}
+
+	req := fake.request
+	if req.Method != http.MethodPost || req.URL.Path != "/rest/v1/photo" || req.URL.Query().Get("on_conflict") != "post_id,position" {
+		t.Fatalf("unexpected request %s %s", req.Method, req.URL)
+	}
+	if got := req.Header.Get("Prefer"); got != "resolution=merge-duplicates,return=minimal" {
+		t.Fatalf("unexpected Prefer %q", got)
+	}
+	var rows []map[string]any
+	decoder := json.NewDecoder(strings.NewReader(string(fake.payload)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rows); err != nil || len(rows) != 2 {
+		t.Fatalf("expected two photo rows, got %s (%v)", fake.payload, err)
+	}
+	if rows[0]["s3_key"] != "photos/a.jpg" || rows[0]["perceptual_hash"] != json.Number("-9223372036854775807") {
+		t.Fatalf("expected a trimmed key and the hash as signed bigint, got %v", rows[0])
+	}
+	if rows[1]["perceptual_hash"] != nil || rows[1]["ticker_s3_key"] != "photos/b_ticker.jpg" {
+		t.Fatalf("expected a null hash and the ticker key, got %v", rows[1])
+	}
+}
+
+func TestSupabaseREST_RecordPostResponse_CallsRespondFunction(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusOK, body: `[{
+		"id": 11, "post_id": 42, "message": "Still available?", "email": "buyer@stanford.edu",
+		"raw_email": "buyer@stanford.edu", "source": "cli", "status": "queued",
+		"post_email": "seller@stanford.edu"
+	}]`}
+	rest := newFakeSupabaseREST(t, fake)
+
+	message, postEmail, err := rest.RecordPostResponse(t.Context(), 42, "buyer@stanford.edu", "Still available?", "", "supost-cli")
+	if err != nil {
+		t.Fatalf("RecordPostResponse: %v", err)
+	}
+	if message.ID != 11 || message.PostID != 42 || message.Status != "queued" || postEmail != "seller@stanford.edu" {
+		t.Fatalf("unexpected message %+v to %q", message, postEmail)
+	}
+
+	req := fake.request
+	if req.Method != http.MethodPost || req.URL.Path != "/rest/v1/rpc/respond_to_post" || req.Header.Get("Content-Profile") != "" {
+		t.Fatalf("expected a call to respond_to_post, got %s %s", req.Method, req.URL.Path)
+	}
+	var params map[string]any
+	if err := json.Unmarshal(fake.payload, &params); err != nil {
+		t.Fatalf("decoding params: %v", err)
+	}
+	if params["p_post_id"] != float64(42) || params["p_reply_to"] != "buyer@stanford.edu" || params["p_ip"] != nil || params["p_user_agent"] != "supost-cli" {
+		t.Fatalf("unexpected params %v", params)
+	}
+}
+
+func TestSupabaseREST_SetMessageMailgunID_NoRowIsNotFound(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusOK, body: `false`}
+	rest := newFakeSupabaseREST(t, fake)
+
+	if err := rest.SetMessageMailgunID(t.Context(), 5, "<id@mailgun>"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if fake.request.Method != http.MethodPost || fake.request.URL.Path != "/rest/v1/rpc/set_response_mailgun_id" {
+		t.Fatalf("unexpected request %s %s", fake.request.Method, fake.request.URL)
+	}
+}
+
+func TestSupabaseREST_DeletePendingPost_UsesCreatedToken(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusCreated, body: `[{"id": 77, "time_posted_at": "2026-10-19T12:00:00Z"}]`}
+	rest := newFakeSupabaseREST(t, fake)
+
+	if err := rest.DeletePendingPost(t.Context(), 77); !errors.Is(err, domain.ErrNotFound) || fake.request != nil {
+		t.Fatalf("expected an unknown post refused without a request, got %v", err)
+	}
+	if _, err := rest.CreatePendingPost(t.Context(), domain.PostCreateSubmission{Email: "pat@stanford.edu", Name: "Desk", AccessToken: "edit-token"}); err != nil {
+		t.Fatalf("CreatePendingPost: %v", err)
+	}
+
+	fake.status, fake.body = http.StatusOK, `true`
+	if err := rest.DeletePendingPost(t.Context(), 77); err != nil {
+		t.Fatalf("DeletePendingPost: %v", err)
+	}
+	var params map[string]any
+	if err := json.Unmarshal(fake.payload, &params); err != nil {
+		t.Fatalf("decoding params: %v", err)
+	}
+	if fake.request.URL.Path != "/rest/v1/rpc/delete_pending_post" || params["p_post_id"] != float64(77) || params["p_access_token"] != "edit-token" {
+		t.Fatalf("unexpected delete %s %v", fake.request.URL.Path, params)
+	}
+}
+
+func TestSupabaseREST_ReportsPostgRESTErrors(t *testing.T) {
+	rest := newFakeSupabaseREST(t, &fakePostgREST{
+		status: http.StatusForbidden,
+		body:   `{"code":"42501","message":"new row violates row-level security policy for table \"post\"","details":null,"hint":null}`,
+	})
+
+	_, err := rest.CreatePendingPost(t.Context(), domain.PostCreateSubmission{Email: "pat@stanford.edu", Name: "Desk"})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	for _, want := range []string{"inserting post", "status 403", "42501", "row-level security"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %q", want, err.Error())
+		}
+	}
+}
+
+func TestSupabaseREST_CreatePendingPost_KeepsTokenWithoutReadingItBack(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusCreated, body: `[{"id": 77, "time_posted_at": "2026-10-19T12:00:00Z"}]`}
+	rest := newFakeSupabaseREST(t, fake).WithAccessToken("user-jwt")
+
+	persisted, err := rest.CreatePendingPost(t.Context(), domain.PostCreateSubmission{Email: "pat@stanford.edu", Name: "Desk", AccessToken: "edit-token"})
+	if err != nil {
+		t.Fatalf("CreatePendingPost: %v", err)
+	}
+	if persisted.PostID != 77 || persisted.AccessToken != "edit-token" {
+		t.Fatalf("unexpected persisted post %+v", persisted)
+	}
+	if got := fake.request.URL.Query().Get("select"); got != "id,time_posted_at" {
+		t.Fatalf("expected only id and time read back, got %q", got)
+	}
+}
+
+func TestSupabaseREST_ListRecentPhotoFingerprints_SkipsAnon(t *testing.T) {
+	fake := &fakePostgREST{status: http.StatusOK, body: `[{"post_id": 5, "position": 0, "perceptual_hash": -1}]`}
+	rest := newFakeSupabaseREST(t, fake)
+
+	fingerprints, err := rest.ListRecentPhotoFingerprints(t.Context(), "pat@stanford.edu", time.Unix(0, 0))
+	if err != nil || len(fingerprints) != 0 {
+		t.Fatalf("expected anon to skip the lookup, got %+v, %v", fingerprints, err)
+	}
+	if fake.request != nil {
+		t.Fatalf("expected no request without a user token")
+	}
+
+	fingerprints, err = rest.WithAccessToken("user-jwt").ListRecentPhotoFingerprints(t.Context(), "pat@stanford.edu", time.Unix(0, 0))
+	if err != nil || len(fingerprints) != 1 || fingerprints[0].PerceptualHash != 1<<64-1 {
+		t.Fatalf("unexpected fingerprints %+v, %v", fingerprints, err)
+	}
+	if fake.request.Header.Get("Authorization") != "Bearer user-jwt" {
+		t.Fatalf("expected the user's token as bearer, got %q", fake.request.Header.Get("Authorization"))
+	}
+}
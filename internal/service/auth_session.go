@@ -0,0 +1,398 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// sessionRefreshSkew refreshes a session this long before it expires so a
+// command never starts with a token that dies mid-request.
+const sessionRefreshSkew = time.Minute
+
+// AuthSessionProvider defines Supabase Auth session side effects where consumed.
+type AuthSessionProvider interface {
+	SignInWithPassword(ctx context.Context, email string, password string) (domain.AuthSession, error)
+	RefreshSession(ctx context.Context, refreshToken string) (domain.AuthSession, error)
+	SignOut(ctx context.Context, accessToken string) error
+	SendOTP(ctx context.Context, request domain.AuthOTPRequest) error
+	VerifyOTP(ctx context.Context, verification domain.AuthOTPVerification) (domain.AuthSession, error)
+	GetUser(ctx context.Context, accessToken string) (domain.AuthIdentity, error)
+}
+
+// AuthSessionStore persists the logged-in session between runs.
+// LoadSession returns domain.ErrNotFound when nobody is logged in.
+type AuthSessionStore interface {
+	LoadSession() (domain.AuthSession, error)
+	SaveSession(session domain.AuthSession) error
+	DeleteSession() error
+}
+
+// AuthSessionService logs users in and out and hands out a valid session,
+// refreshing it when it is about to expire.
+type AuthSessionService struct {
+	provider    AuthSessionProvider
+	store       AuthSessionStore
+	emailPolicy *EmailDomainChecker
+	now         func() time.Time
+}
+
+// NewAuthSessionService constructs AuthSessionService.
+func NewAuthSessionService(provider AuthSessionProvider, store AuthSessionStore) *AuthSessionService {
+	return &AuthSessionService{provider: provider, store: store, now: time.Now}
+}
+
+// Login signs in with email and password and stores the session.
+func (s *AuthSessionService) Login(ctx context.Context, email string, password string) (domain.AuthSession, error) {
+	email, err := normalizeLoginEmail(email)
+	if err != nil {
+		return domain.AuthSession{}, err
+	}
+	if password == "" {
+		return domain.AuthSession{}, fmt.Errorf("password is required")
+	}
+	if err := s.checkEmailDomain(email); err != nil {
+		return domain.AuthSession{}, err
+	}
+
+	session, err := s.provider.SignInWithPassword(ctx, email, password)
+	if err != nil {
+		return domain.AuthSession{}, err
+	}
+	return s.save(session)
+}
+
+// SendEmailOTP emails a one-time code or magic link, depending on the
+// project's email template. redirectTo is where a clicked link lands.
+// It returns the normalized email for the verify step.
+func (s *AuthSessionService) SendEmailOTP(ctx context.Context, email string, redirectTo string) (string, error) {
+	email, err := normalizeLoginEmail(email)
+	if err != nil {
+		return "", err
+	}
+	if err := s.checkEmailDomain(email); err != nil {
+		return "", err
+	}
+	if err := s.provider.SendOTP(ctx, domain.AuthOTPRequest{Email: email, RedirectTo: strings.TrimSpace(redirectTo)}); err != nil {
+		return "", err
+	}
+	return email, nil
+}
+
+// SendPhoneOTP texts a one-time code to the phone number given at signup.
+// It returns the normalized phone for the verify step.
+func (s *AuthSessionService) SendPhoneOTP(ctx context.Context, phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if !looksLikePhone(phone) {
+		return "", fmt.Errorf("phone must be a valid international number (example: +16505551234)")
+	}
+	if err := s.provider.SendOTP(ctx, domain.AuthOTPRequest{Phone: phone}); err != nil {
+		return "", err
+	}
+	return phone, nil
+}
+
+// VerifyOTP exchanges a code or token hash for a session and stores it.
+func (s *AuthSessionService) VerifyOTP(ctx context.Context, verification domain.AuthOTPVerification) (domain.AuthSession, error) {
+	verification.Token = strings.Join(strings.Fields(verification.Token), "")
+	verification.TokenHash = strings.TrimSpace(verification.TokenHash)
+	if verification.Token == "" && verification.TokenHash == "" {
+		return domain.AuthSession{}, fmt.Errorf("verification code is required")
+	}
+	if verification.Token != "" && verification.Email == "" && verification.Phone == "" {
+		return domain.AuthSession{}, fmt.Errorf("email or phone is required to verify a code")
+	}
+	if verification.Email != "" {
+		if err := s.checkEmailDomain(verification.Email); err != nil {
+			return domain.AuthSession{}, err
+		}
+	}
+
+	session, err := s.provider.VerifyOTP(ctx, verification)
+	if err != nil {
+		return domain.AuthSession{}, err
+	}
+	return s.save(session)
+}
+
+// CompleteMagicLink finishes an email login from whatever the user has:
+//   - the URL Supabase redirected to, with the session in its fragment;
+//   - the link from the email, carrying a token hash;
+//   - the one-time code from the email.
+func (s *AuthSessionService) CompleteMagicLink(ctx context.Context, email string, input string) (domain.AuthSession, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return domain.AuthSession{}, fmt.Errorf("magic link or code is required")
+	}
+	if !strings.Contains(input, "://") && !strings.HasPrefix(input, "#") {
+		return s.VerifyOTP(ctx, domain.AuthOTPVerification{Type: domain.AuthOTPTypeEmail, Email: email, Token: input})
+	}
+
+	parsed, err := url.Parse(input)
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("parsing magic link: %w", err)
+	}
+	fragment, err := url.ParseQuery(parsed.Fragment)
+	if err != nil {
+		return domain.AuthSession{}, fmt.Errorf("parsing magic link fragment: %w", err)
+	}
+	query := parsed.Query()
+	for _, values := range []url.Values{fragment, query} {
+		if description := cmp.Or(values.Get("error_description"), values.Get("error")); description != "" {
+			return domain.AuthSession{}, fmt.Errorf("%w: magic link rejected: %s", domain.ErrUnauthorized, description)
+		}
+	}
+
+	if fragment.Get("access_token") != "" {
+		// Pasted input is untrusted: Supabase must accept the token before
+		// it lands in the session file.
+		session := s.sessionFromRedirect(fragment)
+		user, err := s.provider.GetUser(ctx, session.AccessToken)
+		if err != nil {
+			return domain.AuthSession{}, fmt.Errorf("checking magic link session: %w", err)
+		}
+		session.UserID, session.Email, session.Phone = user.UserID, user.Email, user.Phone
+		return s.save(session)
+	}
+	if tokenHash := cmp.Or(query.Get("token_hash"), query.Get("token")); tokenHash != "" {
+		otpType := cmp.Or(query.Get("type"), domain.AuthOTPTypeMagicLink)
+		return s.VerifyOTP(ctx, domain.AuthOTPVerification{Type: otpType, TokenHash: tokenHash})
+	}
+	if query.Get("code") != "" {
+		return domain.AuthSession{}, fmt.Errorf("magic link uses the PKCE flow, which the CLI does not support; paste the code from the email instead")
+	}
+	return domain.AuthSession{}, fmt.Errorf("magic link has no session or token")
+}
+
+// Session returns the stored session, refreshing and re-saving it first
+// when it expires within sessionRefreshSkew. The bool reports a refresh.
+// Not being logged in returns an error wrapping domain.ErrUnauthorized.
+func (s *AuthSessionService) Session(ctx context.Context) (domain.AuthSession, bool, error) {
+	session, err := s.store.LoadSession()
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.AuthSession{}, false, fmt.Errorf("%w: not logged in; run `supost login`", domain.ErrUnauthorized)
+	}
+	if err != nil {
+		return domain.AuthSession{}, false, err
+	}
+	if s.now().Add(sessionRefreshSkew).Before(session.ExpiresAt) {
+		return session, false, nil
+	}
+
+	if strings.TrimSpace(session.RefreshToken) == "" {
+		return domain.AuthSession{}, false, fmt.Errorf("%w: session expired; run `supost login`", domain.ErrUnauthorized)
+	}
+	refreshed, err := s.provider.RefreshSession(ctx, session.RefreshToken)
+	if err != nil {
+		return domain.AuthSession{}, false, fmt.Errorf("refreshing session (run `supost login` if this persists): %w", err)
+	}
+	saved, err := s.save(refreshed)
+	if err != nil {
+		return domain.AuthSession{}, false, err
+	}
+	return saved, true, nil
+}
+
+// WhoAmI returns the identity in the current session's access token.
+func (s *AuthSessionService) WhoAmI(ctx context.Context) (domain.AuthIdentity, error) {
+	session, refreshed, err := s.Session(ctx)
+	if err != nil {
+		return domain.AuthIdentity{}, err
+	}
+	identity, err := decodeAccessTokenIdentity(session.AccessToken)
+	if err != nil {
+		return domain.AuthIdentity{}, err
+	}
+	identity.Refreshed = refreshed
+	return identity, nil
+}
+
+// VerifiedIdentity returns who the current session belongs to according to
+// Supabase (GET /auth/v1/user), not the token's own claims, whose signature
+// the CLI cannot check. Owner actions authorize against it; any failure to
+// resolve the user rejects the action.
+func (s *AuthSessionService) VerifiedIdentity(ctx context.Context) (domain.AuthIdentity, error) {
+	session, refreshed, err := s.Session(ctx)
+	if err != nil {
+		return domain.AuthIdentity{}, err
+	}
+	identity, err := s.provider.GetUser(ctx, session.AccessToken)
+	if err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("%w: verifying session with Supabase (run `supost login` if this persists): %v", domain.ErrUnauthorized, err)
+	}
+	identity.Refreshed = refreshed
+	return identity, nil
+}
+
+// DatabaseClaims returns the role and JWT claims Postgres row level
+// security should see: the current session's token payload, or anon when
+// nobody is logged in (or the session can no longer be refreshed). The
+// token is checked with Supabase first, because the session file can be
+// edited, and only the anon and authenticated roles are accepted.
+func (s *AuthSessionService) DatabaseClaims(ctx context.Context) (domain.DatabaseClaims, error) {
+	session, _, err := s.Session(ctx)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		return domain.DatabaseClaims{Role: "anon", Claims: json.RawMessage(`{"role":"anon"}`)}, nil
+	}
+	if err != nil {
+		return domain.DatabaseClaims{}, err
+	}
+	payload, err := accessTokenPayload(session.AccessToken)
+	if err != nil {
+		return domain.DatabaseClaims{}, err
+	}
+	var claims accessTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return domain.DatabaseClaims{}, fmt.Errorf("parsing access token claims: %w", err)
+	}
+	role := cmp.Or(claims.Role, "authenticated")
+	if role != "anon" && role != "authenticated" {
+		return domain.DatabaseClaims{}, fmt.Errorf("%w: session role %q cannot be used for row level security", domain.ErrUnauthorized, role)
+	}
+	identity, err := s.provider.GetUser(ctx, session.AccessToken)
+	if err != nil {
+		return domain.DatabaseClaims{}, fmt.Errorf("%w: verifying session with Supabase (run `supost login` if this persists): %v", domain.ErrUnauthorized, err)
+	}
+	if identity.UserID != claims.Subject {
+		return domain.DatabaseClaims{}, fmt.Errorf("%w: session token does not belong to the Supabase user", domain.ErrUnauthorized)
+	}
+	return domain.DatabaseClaims{
+		Role:   role,
+		UserID: claims.Subject,
+		Claims: json.RawMessage(payload),
+	}, nil
+}
+
+// Logout revokes the session with Supabase and deletes it locally. The
+// local session is removed even when revocation fails.
+func (s *AuthSessionService) Logout(ctx context.Context) (domain.AuthLogoutResult, error) {
+	session, err := s.store.LoadSession()
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.AuthLogoutResult{}, nil
+	}
+	if err != nil {
+		return domain.AuthLogoutResult{}, err
+	}
+
+	result := domain.AuthLogoutResult{LoggedOut: true, Email: session.Email}
+	if err := s.provider.SignOut(ctx, session.AccessToken); err != nil {
+		result.RevokeError = err.Error()
+	} else {
+		result.Revoked = true
+	}
+	if err := s.store.DeleteSession(); err != nil {
+		return domain.AuthLogoutResult{}, err
+	}
+	return result, nil
+}
+
+func (s *AuthSessionService) save(session domain.AuthSession) (domain.AuthSession, error) {
+	if session.UserID == "" || session.Email == "" {
+		// Older GoTrue responses omit the user; the token claims carry it.
+		if identity, err := decodeAccessTokenIdentity(session.AccessToken); err == nil {
+			if session.UserID == "" {
+				session.UserID = identity.UserID
+			}
+			if session.Email == "" {
+				session.Email = identity.Email
+			}
+		}
+	}
+	if err := s.store.SaveSession(session); err != nil {
+		return domain.AuthSession{}, fmt.Errorf("saving session: %w", err)
+	}
+	return session, nil
+}
+
+// sessionFromRedirect reads the implicit-flow session Supabase appends to
+// the redirect URL fragment.
+func (s *AuthSessionService) sessionFromRedirect(values url.Values) domain.AuthSession {
+	session := domain.AuthSession{
+		AccessToken:  values.Get("access_token"),
+		RefreshToken: values.Get("refresh_token"),
+		TokenType:    values.Get("token_type"),
+	}
+	if expiresAt, err := strconv.ParseInt(values.Get("expires_at"), 10, 64); err == nil && expiresAt > 0 {
+		session.ExpiresAt = time.Unix(expiresAt, 0).UTC()
+	} else if expiresIn, err := strconv.ParseInt(values.Get("expires_in"), 10, 64); err == nil {
+		session.ExpiresAt = s.now().Add(time.Duration(expiresIn) * time.Second).UTC()
+	}
+	return session
+}
+
+func normalizeLoginEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || !strings.Contains(email, "@") {
+		return "", fmt.Errorf("email must be valid")
+	}
+	return email, nil
+}
+
+type accessTokenClaims struct {
+	Subject      string         `json:"sub"`
+	Email        string         `json:"email"`
+	Phone        string         `json:"phone"`
+	Role         string         `json:"role"`
+	SessionID    string         `json:"session_id"`
+	IssuedAt     int64          `json:"iat"`
+	ExpiresAt    int64          `json:"exp"`
+	UserMetadata map[string]any `json:"user_metadata"`
+}
+
+// decodeAccessTokenIdentity reads the claims of a Supabase JWT. The
+// signature is not verified, so the result is only fit for display and for
+// tokens just issued by Supabase; authorization uses VerifiedIdentity.
+func decodeAccessTokenIdentity(accessToken string) (domain.AuthIdentity, error) {
+	payload, err := accessTokenPayload(accessToken)
+	if err != nil {
+		return domain.AuthIdentity{}, err
+	}
+	var claims accessTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return domain.AuthIdentity{}, fmt.Errorf("parsing access token claims: %w", err)
+	}
+
+	identity := domain.AuthIdentity{
+		UserID:    claims.Subject,
+		Email:     strings.ToLower(strings.TrimSpace(claims.Email)),
+		Phone:     claims.Phone,
+		Role:      claims.Role,
+		SessionID: claims.SessionID,
+	}
+	if name, ok := claims.UserMetadata["display_name"].(string); ok {
+		identity.DisplayName = strings.TrimSpace(name)
+	}
+	if phone, ok := claims.UserMetadata["phone"].(string); ok && identity.Phone == "" {
+		// Password signups keep the phone only in user metadata.
+		identity.Phone = strings.TrimSpace(phone)
+	}
+	if claims.IssuedAt > 0 {
+		identity.IssuedAt = time.Unix(claims.IssuedAt, 0).UTC()
+	}
+	if claims.ExpiresAt > 0 {
+		identity.ExpiresAt = time.Unix(claims.ExpiresAt, 0).UTC()
+	}
+	return identity, nil
+}
+
+// accessTokenPayload returns the JSON claims segment of a JWT.
+func accessTokenPayload(accessToken string) ([]byte, error) {
+	parts := strings.Split(strings.TrimSpace(accessToken), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("access token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("decoding access token claims: %w", err)
+	}
+	return payload, nil
+}
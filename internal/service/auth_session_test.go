@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+type mockAuthSessionProvider struct {
+	signIn       domain.AuthSession
+	refreshed    domain.AuthSession
+	refreshCalls int
+	signInCalls  int
+	signOutToken string
+	signOutErr   error
+	otpRequests  []domain.AuthOTPRequest
+	verified     []domain.AuthOTPVerification
+	// users are the access tokens Supabase accepts and who they belong to.
+	users       map[string]domain.AuthIdentity
+	userLookups int
+}
+
+func (m *mockAuthSessionProvider) SignInWithPassword(_ context.Context, email string, password string) (domain.AuthSession, error) {
+	m.signInCalls++
+	if password != "supost123!" {
+		return domain.AuthSession{}, domain.ErrUnauthorized
+	}
+	return m.signIn, nil
+}
+
+func (m *mockAuthSessionProvider) RefreshSession(_ context.Context, refreshToken string) (domain.AuthSession, error) {
+	m.refreshCalls++
+	if refreshToken != m.signIn.RefreshToken {
+		return domain.AuthSession{}, domain.ErrUnauthorized
+	}
+	return m.refreshed, nil
+}
+
+func (m *mockAuthSessionProvider) SignOut(_ context.Context, accessToken string) error {
+	m.signOutToken = accessToken
+	return m.signOutErr
+}
+
+func (m *mockAuthSessionProvider) SendOTP(_ context.Context, request domain.AuthOTPRequest) error {
+	m.otpRequests = append(m.otpRequests, request)
+	return nil
+}
+
+func (m *mockAuthSessionProvider) VerifyOTP(_ context.Context, verification domain.AuthOTPVerification) (domain.AuthSession, error) {
+	m.verified = append(m.verified, verification)
+	if verification.Token != "123456" && verification.TokenHash != "pkce-free-hash" {
+		return domain.AuthSession{}, domain.ErrUnauthorized
+	}
+	return m.signIn, nil
+}
+
+func (m *mockAuthSessionProvider) GetUser(_ context.Context, accessToken string) (domain.AuthIdentity, error) {
+	m.userLookups++
+	user, ok := m.users[accessToken]
+	if !ok {
+		return domain.AuthIdentity{}, fmt.Errorf("supabase user lookup rejected: %w: invalid JWT", domain.ErrUnauthorized)
+	}
+	return user, nil
+}
+
+type memoryAuthSessionStore struct {
+	session *domain.AuthSession
+	saves   int
+}
+
+func (m *memoryAuthSessionStore) LoadSession() (domain.AuthSession, error) {
+	if m.session == nil {
+		return domain.AuthSession{}, domain.ErrNotFound
+	}
+	return *m.session, nil
+}
+
+func (m *memoryAuthSessionStore) SaveSession(session domain.AuthSession) error {
+	m.saves++
+	m.session = &session
+	return nil
+}
+
+func (m *memoryAuthSessionStore) DeleteSession() error {
+	m.session = nil
+	return nil
+}
+
+func testAccessToken(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("encoding claims: %v", err)
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestAuthSessionService_LoginRefreshesExpiredSessionForWhoAmI(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	provider := &mockAuthSessionProvider{
+		signIn: domain.AuthSession{
+			AccessToken:  testAccessToken(t, map[string]any{"sub": "user-1", "email": "user@stanford.edu"}),
+			RefreshToken: "refresh-1",
+			ExpiresAt:    now.Add(30 * time.Second),
+		},
+		refreshed: domain.AuthSession{
+			AccessToken: testAccessToken(t, map[string]any{
+				"sub":           "user-1",
+				"email":         "User@Stanford.edu",
+				"role":          "authenticated",
+				"iat":           now.Unix(),
+				"exp":           now.Add(time.Hour).Unix(),
+				"user_metadata": map[string]any{"display_name": "Greg"},
+			}),
+			RefreshToken: "refresh-2",
+			ExpiresAt:    now.Add(time.Hour),
+		},
+	}
+	store := &memoryAuthSessionStore{}
+	svc := NewAuthSessionService(provider, store)
+	svc.now = func() time.Time { return now }
+
+	session, err := svc.Login(context.Background(), " USER@stanford.edu ", "supost123!")
+	if err != nil {
+		t.Fatalf("unexpected login error: %v", err)
+	}
+	if session.UserID != "user-1" || session.Email != "user@stanford.edu" || store.saves != 1 {
+		t.Fatalf("expected the session saved with claims filled in, got %+v (saves %d)", session, store.saves)
+	}
+
+	identity, err := svc.WhoAmI(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected whoami error: %v", err)
+	}
+	if provider.refreshCalls != 1 || !identity.Refreshed || store.session.RefreshToken != "refresh-2" {
+		t.Fatalf("expected the near-expiry session refreshed and re-saved, got %+v (refreshes %d)", identity, provider.refreshCalls)
+	}
+	if identity.Email != "user@stanford.edu" || identity.DisplayName != "Greg" || identity.Role != "authenticated" || !identity.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+
+	if _, err := svc.WhoAmI(context.Background()); err != nil || provider.refreshCalls != 1 {
+		t.Fatalf("expected a fresh session reused without refresh, got err %v (refreshes %d)", err, provider.refreshCalls)
+	}
+}
+
+func TestAuthSessionService_NotLoggedIn(t *testing.T) {
+	svc := NewAuthSessionService(&mockAuthSessionProvider{}, &memoryAuthSessionStore{})
+
+	if _, err := svc.WhoAmI(context.Background()); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized when not logged in, got %v", err)
+	}
+	result, err := svc.Logout(context.Background())
+	if err != nil || result.LoggedOut {
+		t.Fatalf("expected logout without a session to be a no-op, got %+v, %v", result, err)
+	}
+	if _, err := svc.Login(context.Background(), "bad-email", "supost123!"); err == nil {
+		t.Fatalf("expected invalid email rejected")
+	}
+}
+
+func TestAuthSessionService_DatabaseClaims(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	store := &memoryAuthSessionStore{}
+	provider := &mockAuthSessionProvider{users: map[string]domain.AuthIdentity{}}
+	svc := NewAuthSessionService(provider, store)
+	svc.now = func() time.Time { return now }
+
+	anon, err := svc.DatabaseClaims(context.Background())
+	if err != nil || anon.Role != "anon" || string(anon.Claims) != `{"role":"anon"}` {
+		t.Fatalf("expected anon claims without a session, got %+v, %v", anon, err)
+	}
+
+	accessToken := testAccessToken(t, map[string]any{"sub": "user-1", "role": "authenticated", "email": "pat@stanford.edu"})
+	provider.users[accessToken] = domain.AuthIdentity{UserID: "user-1", Email: "pat@stanford.edu", Role: "authenticated"}
+	store.session = &domain.AuthSession{AccessToken: accessToken, ExpiresAt: now.Add(time.Hour)}
+	claims, err := svc.DatabaseClaims(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected claims error: %v", err)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(claims.Claims, &payload); err != nil || payload["sub"] != "user-1" || payload["email"] != "pat@stanford.edu" {
+		t.Fatalf("expected the token payload passed through, got %s, %v", claims.Claims, err)
+	}
+	if claims.Role != "authenticated" || claims.UserID != "user-1" {
+		t.Fatalf("expected the authenticated role for user-1, got %+v", claims)
+	}
+}
+
+func TestAuthSessionService_DatabaseClaimsRejectsForgedSessions(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	genuine := testAccessToken(t, map[string]any{"sub": "user-1", "role": "authenticated"})
+	provider := &mockAuthSessionProvider{users: map[string]domain.AuthIdentity{
+		genuine: {UserID: "user-1", Role: "authenticated"},
+	}}
+	store := &memoryAuthSessionStore{}
+	svc := NewAuthSessionService(provider, store)
+	svc.now = func() time.Time { return now }
+
+	for name, accessToken := range map[string]string{
+		"service_role claim": testAccessToken(t, map[string]any{"sub": "user-1", "role": "service_role"}),
+		"postgres claim":     testAccessToken(t, map[string]any{"sub": "user-1", "role": "postgres"}),
+		"unsigned token":     testAccessToken(t, map[string]any{"sub": "user-2", "role": "authenticated"}),
+	} {
+		store.session = &domain.AuthSession{AccessToken: accessToken, ExpiresAt: now.Add(time.Hour)}
+		if claims, err := svc.DatabaseClaims(context.Background()); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("%s: expected the session refused, got %+v, %v", name, claims, err)
+		}
+	}
+
+	// A genuine token edited to claim another user is also refused.
+	provider.users[testAccessToken(t, map[string]any{"sub": "user-2", "role": "authenticated"})] = domain.AuthIdentity{UserID: "user-1"}
+	store.session = &domain.AuthSession{AccessToken: testAccessToken(t, map[string]any{"sub": "user-2", "role": "authenticated"}), ExpiresAt: now.Add(time.Hour)}
+	if _, err := svc.DatabaseClaims(context.Background()); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected a subject mismatch refused, got %v", err)
+	}
+}
+
+func TestAuthSessionService_LogoutDeletesLocalSessionWhenRevokeFails(t *testing.T) {
+	provider := &mockAuthSessionProvider{signOutErr: errors.New("network down")}
+	store := &memoryAuthSessionStore{session: &domain.AuthSession{AccessToken: "access-1", Email: "user@stanford.edu"}}
+	svc := NewAuthSessionService(provider, store)
+
+	result, err := svc.Logout(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected logout error: %v", err)
+	}
+	if provider.signOutToken != "access-1" {
+		t.Fatalf("expected revoke with the access token, got %q", provider.signOutToken)
+	}
+	if !result.LoggedOut || result.Revoked || result.RevokeError != "network down" || store.session != nil {
+		t.Fatalf("expected the local session removed despite the revoke failure, got %+v", result)
+	}
+}
+
+func TestAuthSessionService_OTPAndMagicLink(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	accessToken := testAccessToken(t, map[string]any{"sub": "user-1", "email": "user@stanford.edu"})
+	provider := &mockAuthSessionProvider{
+		signIn: domain.AuthSession{AccessToken: accessToken, RefreshToken: "refresh-1", ExpiresAt: now.Add(time.Hour)},
+		users:  map[string]domain.AuthIdentity{accessToken: {UserID: "user-1", Email: "user@stanford.edu"}},
+	}
+	store := &memoryAuthSessionStore{}
+	svc := NewAuthSessionService(provider, store)
+	svc.now = func() time.Time { return now }
+
+	email, err := svc.SendEmailOTP(context.Background(), " User@Stanford.edu ", "http://127.0.0.1:4000/auth/callback")
+	if err != nil || email != "user@stanford.edu" {
+		t.Fatalf("unexpected email otp result %q, %v", email, err)
+	}
+	if _, err := svc.SendPhoneOTP(context.Background(), "650-555"); err == nil {
+		t.Fatalf("expected an invalid phone rejected")
+	}
+	if _, err := svc.SendPhoneOTP(context.Background(), " +16505551234 "); err != nil {
+		t.Fatalf("unexpected phone otp error: %v", err)
+	}
+	if len(provider.otpRequests) != 2 || provider.otpRequests[0].RedirectTo == "" || provider.otpRequests[1].Phone != "+16505551234" {
+		t.Fatalf("unexpected otp requests %+v", provider.otpRequests)
+	}
+
+	if _, err := svc.CompleteMagicLink(context.Background(), email, "123 456"); err != nil {
+		t.Fatalf("unexpected code verification error: %v", err)
+	}
+	if _, err := svc.CompleteMagicLink(context.Background(), email, "https://example.supabase.co/auth/v1/verify?token=pkce-free-hash&type=magiclink&redirect_to=http://127.0.0.1:4000/auth/callback"); err != nil {
+		t.Fatalf("unexpected email link verification error: %v", err)
+	}
+	first, second := provider.verified[0], provider.verified[1]
+	if first.Type != domain.AuthOTPTypeEmail || first.Email != email || first.Token != "123456" {
+		t.Fatalf("expected the code verified as an email otp, got %+v", first)
+	}
+	if second.Type != domain.AuthOTPTypeMagicLink || second.TokenHash != "pkce-free-hash" || second.Email != "" {
+		t.Fatalf("expected the link verified by token hash, got %+v", second)
+	}
+
+	store.session = nil
+	session, err := svc.CompleteMagicLink(context.Background(), email, "http://127.0.0.1:4000/auth/callback#access_token="+accessToken+"&refresh_token=refresh-9&expires_in=3600&token_type=bearer&type=magiclink")
+	if err != nil {
+		t.Fatalf("unexpected redirect error: %v", err)
+	}
+	if len(provider.verified) != 2 || store.session == nil || session.RefreshToken != "refresh-9" || session.UserID != "user-1" || !session.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected the redirect session stored without a verify call, got %+v", session)
+	}
+	if provider.userLookups != 1 {
+		t.Fatalf("expected the redirect token checked with Supabase once, got %d lookups", provider.userLookups)
+	}
+
+	store.session = nil
+	forged := testAccessToken(t, map[string]any{"sub": "user-2", "email": "victim@stanford.edu"})
+	_, err = svc.CompleteMagicLink(context.Background(), email, "http://127.0.0.1:4000/auth/callback#access_token="+forged+"&refresh_token=refresh-x&expires_in=3600")
+	if !errors.Is(err, domain.ErrUnauthorized) || store.session != nil {
+		t.Fatalf("expected a token Supabase rejects to fail without saving, got %v (session %+v)", err, store.session)
+	}
+
+	_, err = svc.CompleteMagicLink(context.Background(), email, "http://127.0.0.1:4000/auth/callback#error=access_denied&error_description=Email+link+is+invalid+or+has+expired")
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected an expired link to wrap ErrUnauthorized, got %v", err)
+	}
+}
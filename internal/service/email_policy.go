@@ -0,0 +1,281 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// DefaultEmailPolicyCollege is the college whose rules are built in.
+const DefaultEmailPolicyCollege = "stanford"
+
+// DefaultEmailDomainRules returns the built-in Stanford affiliation rules.
+func DefaultEmailDomainRules() domain.EmailDomainRules {
+	return domain.EmailDomainRules{
+		Name: "Stanford",
+		Hint: "@stanford.edu, @cs.stanford.edu",
+		AllowedDomains: []string{
+			"stanford.edu",
+			"alumni.stanford.edu",
+			"stanfordalumni.org",
+			"stanfordchildrens.org",
+			"stanfordhealthcare.org",
+			"stanfordmed.org",
+			"lpch.org",
+		},
+		AllowedSuffixes: []string{".stanford.edu"},
+	}
+}
+
+// EmailDomainChecker applies a resolved EmailDomainPolicy. The zero value
+// is not usable; build one with NewEmailDomainChecker.
+type EmailDomainChecker struct {
+	college  string
+	rules    domain.EmailDomainRules
+	allowed  map[string]struct{}
+	suffixes []string
+	blocked  []string
+}
+
+// NewEmailDomainChecker resolves policy against the built-in rules and
+// validates every entry.
+func NewEmailDomainChecker(policy domain.EmailDomainPolicy) (*EmailDomainChecker, error) {
+	college := strings.ToLower(strings.TrimSpace(policy.College))
+	if college == "" {
+		college = DefaultEmailPolicyCollege
+	}
+
+	rules := DefaultEmailDomainRules()
+	rules.AllowedDomains = append(rules.AllowedDomains, policy.Rules.AllowedDomains...)
+	rules.AllowedSuffixes = append(rules.AllowedSuffixes, policy.Rules.AllowedSuffixes...)
+	rules.BlockedDomains = append(rules.BlockedDomains, policy.Rules.BlockedDomains...)
+
+	override, ok := lookupCollegeRules(policy.Colleges, college)
+	if !ok && college != DefaultEmailPolicyCollege {
+		return nil, fmt.Errorf("no email domain rules for college %q", college)
+	}
+	if ok {
+		if override.Name != "" {
+			rules.Name = override.Name
+		}
+		if override.Hint != "" || len(override.AllowedDomains) > 0 || len(override.AllowedSuffixes) > 0 {
+			// The Stanford hint would be wrong for another college's lists.
+			rules.Hint = override.Hint
+		}
+		if len(override.AllowedDomains) > 0 {
+			rules.AllowedDomains = override.AllowedDomains
+		}
+		if len(override.AllowedSuffixes) > 0 {
+			rules.AllowedSuffixes = override.AllowedSuffixes
+		}
+		if len(override.BlockedDomains) > 0 {
+			rules.BlockedDomains = override.BlockedDomains
+		}
+	}
+
+	problems := make([]string, 0)
+	normalizeList := func(kind string, values []string, suffix bool) []string {
+		out := make([]string, 0, len(values))
+		seen := make(map[string]bool, len(values))
+		for _, value := range values {
+			entry := strings.ToLower(strings.TrimSpace(value))
+			entry = strings.TrimPrefix(entry, "@")
+			if suffix {
+				entry = "." + strings.TrimPrefix(entry, ".")
+			}
+			if !validPolicyDomain(strings.TrimPrefix(entry, "."), !suffix) {
+				problems = append(problems, fmt.Sprintf("invalid %s %q", kind, value))
+				continue
+			}
+			if !seen[entry] {
+				seen[entry] = true
+				out = append(out, entry)
+			}
+		}
+		return out
+	}
+	rules.AllowedDomains = normalizeList("allowed domain", rules.AllowedDomains, false)
+	rules.AllowedSuffixes = normalizeList("allowed suffix", rules.AllowedSuffixes, true)
+	rules.BlockedDomains = normalizeList("blocked domain", rules.BlockedDomains, false)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("email domain policy: %s", strings.Join(problems, "; "))
+	}
+	if len(rules.AllowedDomains) == 0 && len(rules.AllowedSuffixes) == 0 {
+		return nil, fmt.Errorf("email domain policy for %q allows no domains", college)
+	}
+	if rules.Name == "" {
+		rules.Name = college
+	}
+	if rules.Hint == "" {
+		rules.Hint = defaultPolicyHint(rules)
+	}
+
+	return buildEmailDomainChecker(college, rules), nil
+}
+
+// WithEmailDomainPolicy replaces the built-in Stanford rules for post
+// create submissions.
+func (s *PostCreateService) WithEmailDomainPolicy(checker *EmailDomainChecker) *PostCreateService {
+	s.emailPolicy = checker
+	return s
+}
+
+// WithEmailDomainPolicy replaces the built-in Stanford rules for signup.
+func (s *UserSignupService) WithEmailDomainPolicy(checker *EmailDomainChecker) *UserSignupService {
+	s.emailPolicy = checker
+	return s
+}
+
+// WithEmailDomainPolicy replaces the built-in Stanford rules for password
+// logins, email one-time codes, and magic links.
+func (s *AuthSessionService) WithEmailDomainPolicy(checker *EmailDomainChecker) *AuthSessionService {
+	s.emailPolicy = checker
+	return s
+}
+
+func (s *PostCreateService) emailDomainChecker() *EmailDomainChecker {
+	if s.emailPolicy == nil {
+		return defaultEmailDomainChecker()
+	}
+	return s.emailPolicy
+}
+
+func (s *UserSignupService) emailDomainChecker() *EmailDomainChecker {
+	if s.emailPolicy == nil {
+		return defaultEmailDomainChecker()
+	}
+	return s.emailPolicy
+}
+
+// checkEmailDomain refuses email logins for addresses outside the policy,
+// matching what signup would have accepted.
+func (s *AuthSessionService) checkEmailDomain(email string) error {
+	checker := s.emailPolicy
+	if checker == nil {
+		checker = defaultEmailDomainChecker()
+	}
+	if decision := checker.Check(email); !decision.Allowed {
+		return fmt.Errorf("email must be %s: %s", checker.Requirement(), decision.Reason)
+	}
+	return nil
+}
+
+// defaultEmailDomainChecker backs services that were not given a policy.
+// The built-in rules are already normalized.
+func defaultEmailDomainChecker() *EmailDomainChecker {
+	return buildEmailDomainChecker(DefaultEmailPolicyCollege, DefaultEmailDomainRules())
+}
+
+func buildEmailDomainChecker(college string, rules domain.EmailDomainRules) *EmailDomainChecker {
+	checker := &EmailDomainChecker{
+		college:  college,
+		rules:    rules,
+		allowed:  make(map[string]struct{}, len(rules.AllowedDomains)),
+		suffixes: rules.AllowedSuffixes,
+		blocked:  rules.BlockedDomains,
+	}
+	for _, allowed := range rules.AllowedDomains {
+		checker.allowed[allowed] = struct{}{}
+	}
+	return checker
+}
+
+// College is the college whose rules are in effect.
+func (c *EmailDomainChecker) College() string {
+	return c.college
+}
+
+// Rules returns the resolved, normalized rules.
+func (c *EmailDomainChecker) Rules() domain.EmailDomainRules {
+	return c.rules
+}
+
+// Requirement describes an accepted address for validation messages, for
+// example "a Stanford email (e.g., @stanford.edu, @cs.stanford.edu)".
+func (c *EmailDomainChecker) Requirement() string {
+	return fmt.Sprintf("a %s email (e.g., %s)", c.rules.Name, c.rules.Hint)
+}
+
+// Allowed reports whether email passes the policy.
+func (c *EmailDomainChecker) Allowed(email string) bool {
+	return c.Check(email).Allowed
+}
+
+// Check decides one address and reports which rule decided it.
+func (c *EmailDomainChecker) Check(email string) domain.EmailPolicyDecision {
+	normalized := strings.ToLower(strings.TrimSpace(email))
+	decision := domain.EmailPolicyDecision{Email: normalized, College: c.college}
+
+	at := strings.LastIndex(normalized, "@")
+	if at <= 0 || at == len(normalized)-1 || !validPolicyDomain(normalized[at+1:], true) {
+		decision.Rule = domain.EmailPolicyRuleInvalid
+		decision.Reason = "not a valid email address"
+		return decision
+	}
+	decision.Domain = normalized[at+1:]
+
+	for _, blocked := range c.blocked {
+		if decision.Domain == blocked || strings.HasSuffix(decision.Domain, "."+blocked) {
+			decision.Rule, decision.Match = domain.EmailPolicyRuleBlockedDomain, blocked
+			decision.Reason = fmt.Sprintf("%s is blocked", blocked)
+			return decision
+		}
+	}
+	if _, ok := c.allowed[decision.Domain]; ok {
+		decision.Allowed = true
+		decision.Rule, decision.Match = domain.EmailPolicyRuleAllowedDomain, decision.Domain
+		decision.Reason = fmt.Sprintf("%s is an allowed %s domain", decision.Domain, c.rules.Name)
+		return decision
+	}
+	for _, suffix := range c.suffixes {
+		if strings.HasSuffix(decision.Domain, suffix) {
+			decision.Allowed = true
+			decision.Rule, decision.Match = domain.EmailPolicyRuleAllowedSuffix, suffix
+			decision.Reason = fmt.Sprintf("%s is under %s", decision.Domain, suffix)
+			return decision
+		}
+	}
+	decision.Rule = domain.EmailPolicyRuleNoMatch
+	decision.Reason = fmt.Sprintf("%s is not an allowed %s domain", decision.Domain, c.rules.Name)
+	return decision
+}
+
+func lookupCollegeRules(colleges map[string]domain.EmailDomainRules, college string) (domain.EmailDomainRules, bool) {
+	for key, rules := range colleges {
+		if strings.EqualFold(strings.TrimSpace(key), college) {
+			return rules, true
+		}
+	}
+	return domain.EmailDomainRules{}, false
+}
+
+func defaultPolicyHint(rules domain.EmailDomainRules) string {
+	examples := make([]string, 0, 2)
+	if len(rules.AllowedDomains) > 0 {
+		examples = append(examples, "@"+rules.AllowedDomains[0])
+	}
+	if len(rules.AllowedSuffixes) > 0 {
+		examples = append(examples, "@*"+rules.AllowedSuffixes[0])
+	}
+	return strings.Join(examples, ", ")
+}
+
+// validPolicyDomain accepts hostnames of letters, digits, and hyphens.
+// Suffixes may be a single label, such as "edu".
+func validPolicyDomain(value string, requireDot bool) bool {
+	if value == "" || len(value) > 253 || (requireDot && !strings.Contains(value, ".")) {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, ch := range label {
+			if !(ch >= 'a' && ch <= 'z') && !(ch >= '0' && ch <= '9') && ch != '-' {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestEmailDomainChecker_DefaultStanfordRules(t *testing.T) {
+	checker := defaultEmailDomainChecker()
+	for _, tc := range []struct {
+		email   string
+		allowed bool
+		rule    string
+	}{
+		{"pat@stanford.edu", true, domain.EmailPolicyRuleAllowedDomain},
+		{" Pat@LPCH.org ", true, domain.EmailPolicyRuleAllowedDomain},
+		{"pat@cs.stanford.edu", true, domain.EmailPolicyRuleAllowedSuffix},
+		{"pat@notstanford.edu", false, domain.EmailPolicyRuleNoMatch},
+		{"pat@gmail.com", false, domain.EmailPolicyRuleNoMatch},
+		{"stanford.edu", false, domain.EmailPolicyRuleInvalid},
+		{"pat@", false, domain.EmailPolicyRuleInvalid},
+	} {
+		decision := checker.Check(tc.email)
+		if decision.Allowed != tc.allowed || decision.Rule != tc.rule {
+			t.Fatalf("%q: expected allowed=%t rule=%s, got %+v", tc.email, tc.allowed, tc.rule, decision)
+		}
+	}
+	if got := checker.Requirement(); got != "a Stanford email (e.g., @stanford.edu, @cs.stanford.edu)" {
+		t.Fatalf("unexpected requirement %q", got)
+	}
+}
+
+func TestEmailDomainChecker_ConfiguredListsAndCollegeOverrides(t *testing.T) {
+	checker, err := NewEmailDomainChecker(domain.EmailDomainPolicy{
+		Rules: domain.EmailDomainRules{
+			AllowedDomains: []string{"@SLAC.org"},
+			BlockedDomains: []string{"alumni.stanford.edu"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected policy error: %v", err)
+	}
+	if !checker.Allowed("pat@slac.org") {
+		t.Fatalf("expected a configured domain allowed")
+	}
+	decision := checker.Check("pat@mail.alumni.stanford.edu")
+	if decision.Allowed || decision.Rule != domain.EmailPolicyRuleBlockedDomain || decision.Match != "alumni.stanford.edu" {
+		t.Fatalf("expected the blocklist to win over the suffix rule, got %+v", decision)
+	}
+
+	berkeley, err := NewEmailDomainChecker(domain.EmailDomainPolicy{
+		College: "Berkeley",
+		Colleges: map[string]domain.EmailDomainRules{
+			"berkeley": {Name: "Berkeley", AllowedDomains: []string{"berkeley.edu"}, AllowedSuffixes: []string{"berkeley.edu"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected override error: %v", err)
+	}
+	if !berkeley.Allowed("oski@eecs.berkeley.edu") || berkeley.Allowed("pat@stanford.edu") {
+		t.Fatalf("expected the college override to replace the Stanford lists, got %+v", berkeley.Rules())
+	}
+	if got := berkeley.Requirement(); got != "a Berkeley email (e.g., @berkeley.edu, @*.berkeley.edu)" {
+		t.Fatalf("unexpected requirement %q", got)
+	}
+
+	if _, err := NewEmailDomainChecker(domain.EmailDomainPolicy{College: "mit"}); err == nil || !strings.Contains(err.Error(), `"mit"`) {
+		t.Fatalf("expected an unknown college rejected, got %v", err)
+	}
+	if _, err := NewEmailDomainChecker(domain.EmailDomainPolicy{Rules: domain.EmailDomainRules{BlockedDomains: []string{"not a domain"}}}); err == nil {
+		t.Fatalf("expected an invalid entry rejected")
+	}
+}
+
+func TestEmailDomainPolicy_AppliesToSignupAndEmailLogin(t *testing.T) {
+	provider := &mockUserSignupProvider{}
+	_, err := NewUserSignupService(provider).SignUp(context.Background(), domain.UserSignupSubmission{
+		DisplayName: "Pat",
+		Email:       "pat@gmail.com",
+		Phone:       "+16505551234",
+		Password:    "supost123!",
+	})
+	if err == nil || !strings.Contains(err.Error(), "email must be a Stanford email") || provider.called {
+		t.Fatalf("expected signup with a non-Stanford email refused, got %v", err)
+	}
+
+	auth := &mockAuthSessionProvider{}
+	svc := NewAuthSessionService(auth, &memoryAuthSessionStore{})
+	if _, err := svc.Login(context.Background(), "pat@gmail.com", "supost123!"); err == nil || !strings.Contains(err.Error(), "email must be a Stanford email") || auth.signInCalls != 0 {
+		t.Fatalf("expected a password login for a non-Stanford email refused before Supabase, got %v", err)
+	}
+	if _, err := svc.SendEmailOTP(context.Background(), "pat@gmail.com", ""); err == nil || len(auth.otpRequests) != 0 {
+		t.Fatalf("expected an email code for a non-Stanford email refused, got %v", err)
+	}
+	_, err = svc.VerifyOTP(context.Background(), domain.AuthOTPVerification{Type: domain.AuthOTPTypeEmail, Email: "pat@gmail.com", Token: "123456"})
+	if err == nil || !strings.Contains(err.Error(), "gmail.com is not an allowed Stanford domain") {
+		t.Fatalf("expected verification for a non-Stanford email refused, got %v", err)
+	}
+}
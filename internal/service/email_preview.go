@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	EmailPreviewPublish  = "publish"
+	EmailPreviewResponse = "response"
+)
+
+// EmailPreviewRepository defines the post lookup used to preview emails.
+type EmailPreviewRepository interface {
+	GetPostByID(ctx context.Context, postID int64) (domain.Post, error)
+}
+
+// EmailPreviewService renders publish/response emails for an existing post
+// without sending or persisting anything.
+type EmailPreviewService struct {
+	repo      EmailPreviewRepository
+	templates *EmailTemplates
+}
+
+// NewEmailPreviewService constructs EmailPreviewService.
+func NewEmailPreviewService(repo EmailPreviewRepository) *EmailPreviewService {
+	return &EmailPreviewService{repo: repo}
+}
+
+// WithEmailTemplates overrides the embedded email templates.
+func (s *EmailPreviewService) WithEmailTemplates(templates *EmailTemplates) *EmailPreviewService {
+	s.templates = templates
+	return s
+}
+
+// PreviewPublish renders the publish-link email the post owner would receive.
+func (s *EmailPreviewService) PreviewPublish(ctx context.Context, postID int64, baseURL string) (domain.EmailPreview, error) {
+	post, err := s.lookupPost(ctx, postID)
+	if err != nil {
+		return domain.EmailPreview{}, err
+	}
+	templates, err := resolveEmailTemplates(s.templates)
+	if err != nil {
+		return domain.EmailPreview{}, err
+	}
+
+	publishURL := buildPublishURL(baseURL, post.AccessToken)
+	subject, text, html, err := buildPublishEmailContent(templates, post.Name, publishURL, postTimestamp(post))
+	if err != nil {
+		return domain.EmailPreview{}, err
+	}
+	return domain.EmailPreview{
+		Kind:    EmailPreviewPublish,
+		PostID:  post.ID,
+		To:      strings.TrimSpace(post.Email),
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	}, nil
+}
+
+// PreviewResponse renders the response email the post owner would receive.
+func (s *EmailPreviewService) PreviewResponse(ctx context.Context, postID int64, replyTo, message, baseURL string) (domain.EmailPreview, error) {
+	normalized, err := normalizePostRespondInput(domain.PostRespondSubmission{
+		PostID:  postID,
+		Message: message,
+		ReplyTo: replyTo,
+	})
+	if err != nil {
+		return domain.EmailPreview{}, err
+	}
+	post, err := s.lookupPost(ctx, postID)
+	if err != nil {
+		return domain.EmailPreview{}, err
+	}
+	templates, err := resolveEmailTemplates(s.templates)
+	if err != nil {
+		return domain.EmailPreview{}, err
+	}
+
+	subject, text, html, err := buildResponseEmailContent(templates, post, normalized, baseURL)
+	if err != nil {
+		return domain.EmailPreview{}, err
+	}
+	return domain.EmailPreview{
+		Kind:    EmailPreviewResponse,
+		PostID:  post.ID,
+		To:      strings.TrimSpace(post.Email),
+		ReplyTo: normalized.ReplyTo,
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	}, nil
+}
+
+func (s *EmailPreviewService) lookupPost(ctx context.Context, postID int64) (domain.Post, error) {
+	if postID <= 0 {
+		return domain.Post{}, fmt.Errorf("post id must be positive")
+	}
+	return s.repo.GetPostByID(ctx, postID)
+}
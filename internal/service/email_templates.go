@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	publishTextTemplate  = "publish.txt.tmpl"
+	publishHTMLTemplate  = "publish.html.tmpl"
+	responseTextTemplate = "response.txt.tmpl"
+	responseHTMLTemplate = "response.html.tmpl"
+)
+
+//go:embed templates/email/*.tmpl
+var embeddedEmailTemplates embed.FS
+
+// EmailTemplates holds parsed text + HTML bodies for outgoing emails.
+type EmailTemplates struct {
+	publishText  *texttemplate.Template
+	publishHTML  *htmltemplate.Template
+	responseText *texttemplate.Template
+	responseHTML *htmltemplate.Template
+}
+
+var defaultEmailTemplates = sync.OnceValues(func() (*EmailTemplates, error) {
+	return LoadEmailTemplates()
+})
+
+// DefaultEmailTemplates returns the embedded templates.
+func DefaultEmailTemplates() (*EmailTemplates, error) {
+	return defaultEmailTemplates()
+}
+
+// LoadEmailTemplates parses the embedded templates, then applies overrides
+// from overrideDirs in order. A file in an override dir replaces the embedded
+// file of the same name (e.g. publish.html.tmpl); later dirs win.
+func LoadEmailTemplates(overrideDirs ...string) (*EmailTemplates, error) {
+	source := func(name string) (string, []byte, error) {
+		for i := len(overrideDirs) - 1; i >= 0; i-- {
+			dir := strings.TrimSpace(overrideDirs[i])
+			if dir == "" {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			raw, err := os.ReadFile(path)
+			if err == nil {
+				return path, raw, nil
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return "", nil, fmt.Errorf("reading email template %s: %w", path, err)
+			}
+		}
+		raw, err := embeddedEmailTemplates.ReadFile("templates/email/" + name)
+		if err != nil {
+			return "", nil, fmt.Errorf("reading embedded email template %s: %w", name, err)
+		}
+		return name, raw, nil
+	}
+
+	parseText := func(name string) (*texttemplate.Template, error) {
+		origin, raw, err := source(name)
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("parsing email template %s: %w", origin, err)
+		}
+		return tmpl, nil
+	}
+	parseHTML := func(name string) (*htmltemplate.Template, error) {
+		origin, raw, err := source(name)
+		if err != nil {
+			return nil, err
+		}
+		tmpl, err := htmltemplate.New(name).Option("missingkey=error").Parse(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("parsing email template %s: %w", origin, err)
+		}
+		return tmpl, nil
+	}
+
+	var (
+		templates EmailTemplates
+		err       error
+	)
+	if templates.publishText, err = parseText(publishTextTemplate); err != nil {
+		return nil, err
+	}
+	if templates.publishHTML, err = parseHTML(publishHTMLTemplate); err != nil {
+		return nil, err
+	}
+	if templates.responseText, err = parseText(responseTextTemplate); err != nil {
+		return nil, err
+	}
+	if templates.responseHTML, err = parseHTML(responseHTMLTemplate); err != nil {
+		return nil, err
+	}
+	return &templates, nil
+}
+
+// RenderPublish renders the publish-link text and HTML bodies.
+func (t *EmailTemplates) RenderPublish(data domain.PublishEmailData) (string, string, error) {
+	text, err := executeEmailTemplate(t.publishText, data)
+	if err != nil {
+		return "", "", err
+	}
+	html, err := executeEmailTemplate(t.publishHTML, data)
+	if err != nil {
+		return "", "", err
+	}
+	return text, html, nil
+}
+
+// RenderResponse renders the post-response text and HTML bodies.
+func (t *EmailTemplates) RenderResponse(data domain.ResponseEmailData) (string, string, error) {
+	text, err := executeEmailTemplate(t.responseText, data)
+	if err != nil {
+		return "", "", err
+	}
+	html, err := executeEmailTemplate(t.responseHTML, data)
+	if err != nil {
+		return "", "", err
+	}
+	return text, html, nil
+}
+
+type emailTemplate interface {
+	Name() string
+	Execute(w io.Writer, data any) error
+}
+
+func executeEmailTemplate(tmpl emailTemplate, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering email template %s: %w", tmpl.Name(), err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// resolveEmailTemplates returns t, or the embedded defaults when t is nil.
+func resolveEmailTemplates(t *EmailTemplates) (*EmailTemplates, error) {
+	if t != nil {
+		return t, nil
+	}
+	return DefaultEmailTemplates()
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+var updateEmailGolden = flag.Bool("update", false, "rewrite email golden files in testdata/email")
+
+func goldenPreviewPost() domain.Post {
+	return domain.Post{
+		ID:           130031908,
+		Email:        "wientjes@alumni.stanford.edu",
+		Name:         "Desk & chair <IKEA>",
+		AccessToken:  "abc123",
+		TimePostedAt: time.Date(2026, time.February, 17, 15, 4, 0, 0, time.UTC),
+	}
+}
+
+func TestEmailPreviewService_Golden(t *testing.T) {
+	svc := NewEmailPreviewService(&mockPostRespondRepo{post: goldenPreviewPost()})
+
+	publish, err := svc.PreviewPublish(context.Background(), 130031908, "https://supost.com")
+	if err != nil {
+		t.Fatalf("unexpected publish preview error: %v", err)
+	}
+	if publish.Subject != "SUpost - Publish your post! Desk & chair <IKEA>" {
+		t.Fatalf("unexpected publish subject %q", publish.Subject)
+	}
+	assertEmailGolden(t, "publish.txt", publish.Text)
+	assertEmailGolden(t, "publish.html", publish.HTML)
+
+	response, err := svc.PreviewResponse(
+		context.Background(),
+		130031908,
+		"Buyer@Gmail.com",
+		"Is it still available?\nI can pick up <today>.",
+		"https://supost.com/",
+	)
+	if err != nil {
+		t.Fatalf("unexpected response preview error: %v", err)
+	}
+	if response.ReplyTo != "buyer@gmail.com" {
+		t.Fatalf("expected normalized reply-to, got %q", response.ReplyTo)
+	}
+	assertEmailGolden(t, "response.txt", response.Text)
+	assertEmailGolden(t, "response.html", response.HTML)
+}
+
+func TestLoadEmailTemplates_OverrideDirReplacesMatchingFileOnly(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, publishTextTemplate), []byte("Custom publish: {{.PublishURL}}\n"), 0o644); err != nil {
+		t.Fatalf("writing override: %v", err)
+	}
+	templates, err := LoadEmailTemplates("", dir)
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+
+	text, html, err := templates.RenderPublish(domain.PublishEmailData{PublishURL: "https://supost.com/post/publish/x"})
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	if text != "Custom publish: https://supost.com/post/publish/x" {
+		t.Fatalf("expected overridden text body, got %q", text)
+	}
+	if !strings.Contains(html, "<!DOCTYPE html>") {
+		t.Fatalf("expected embedded html body to remain, got %q", html)
+	}
+}
+
+func TestLoadEmailTemplates_InvalidOverrideFails(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, responseHTMLTemplate), []byte("{{.ReplyTo"), 0o644); err != nil {
+		t.Fatalf("writing override: %v", err)
+	}
+	if _, err := LoadEmailTemplates(dir); err == nil || !strings.Contains(err.Error(), responseHTMLTemplate) {
+		t.Fatalf("expected parse error naming %s, got %v", responseHTMLTemplate, err)
+	}
+}
+
+func TestPostRespondService_SendsHTMLAlternative(t *testing.T) {
+	repo := &mockPostRespondRepo{post: goldenPreviewPost()}
+	sender := &mockPostRespondSender{}
+	svc := NewPostRespondService(repo)
+
+	_, err := svc.Respond(context.Background(), domain.PostRespondSubmission{
+		PostID:  130031908,
+		Message: "Still available?",
+		ReplyTo: "buyer@gmail.com",
+	}, false, "https://supost.com", "response@mg.supost.com", sender)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(sender.last.HTML, `<a href="mailto:buyer@gmail.com">`) {
+		t.Fatalf("expected html alternative in sent message, got %q", sender.last.HTML)
+	}
+}
+
+func assertEmailGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "email", name)
+	if *updateEmailGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got+"\n"), 0o644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file %s (run with -update to create): %v", path, err)
+	}
+	if strings.TrimSuffix(string(want), "\n") != got {
+		t.Fatalf("%s mismatch\n--- want ---\n%s\n--- got ---\n%s", path, want, got)
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	relayAddressPrefix = "reply+"
+	relaySignatureLen  = 16
+)
+
+// MailRelay builds and verifies anonymous reply addresses of the form
+// reply+<message_id>.<direction>.<signature>@<domain>. The signature is a
+// truncated HMAC-SHA256 over the message id, the direction, and the email of
+// the participant the address was handed to, so addresses cannot be guessed,
+// retargeted, or reused once that participant is no longer on the thread.
+type MailRelay struct {
+	domain string
+	secret []byte
+}
+
+// NewMailRelay constructs MailRelay.
+func NewMailRelay(domain, secret string) (*MailRelay, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	secret = strings.TrimSpace(secret)
+	if domain == "" {
+		return nil, fmt.Errorf("mail relay domain is required")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("mail relay secret is required")
+	}
+	return &MailRelay{domain: domain, secret: []byte(secret)}, nil
+}
+
+// Address returns the relay address, handed to participant, that delivers to
+// direction for messageID.
+func (m *MailRelay) Address(messageID int64, direction domain.RelayDirection, participant string) string {
+	local := fmt.Sprintf("%d.%s", messageID, direction)
+	return relayAddressPrefix + local + "." + m.sign(local, participant) + "@" + m.domain
+}
+
+// Parse reads the message id and direction from a relay address. It does not
+// check the signature, which needs the thread's participant; see Verify.
+func (m *MailRelay) Parse(address string) (int64, domain.RelayDirection, error) {
+	messageID, direction, _, err := m.parse(address)
+	return messageID, direction, err
+}
+
+// Verify checks that address was issued to participant. A mismatch means the
+// address was forged, altered, or handed to someone no longer on the thread.
+func (m *MailRelay) Verify(address string, participant string) error {
+	messageID, direction, signature, err := m.parse(address)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(participant) == "" {
+		return fmt.Errorf("%w: relay message %d has no participant to verify against", domain.ErrUnauthorized, messageID)
+	}
+	expected := m.sign(fmt.Sprintf("%d.%s", messageID, direction), participant)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return fmt.Errorf("%w: relay address signature mismatch", domain.ErrUnauthorized)
+	}
+	return nil
+}
+
+func (m *MailRelay) parse(address string) (int64, domain.RelayDirection, string, error) {
+	addr := strings.ToLower(strings.TrimSpace(address))
+	if parsed, err := mail.ParseAddress(addr); err == nil {
+		addr = strings.ToLower(parsed.Address)
+	}
+
+	at := strings.LastIndex(addr, "@")
+	if at <= 0 || addr[at+1:] != m.domain {
+		return 0, "", "", fmt.Errorf("%w: %q is not a relay address", domain.ErrNotFound, address)
+	}
+	local := addr[:at]
+	if !strings.HasPrefix(local, relayAddressPrefix) {
+		return 0, "", "", fmt.Errorf("%w: %q is not a relay address", domain.ErrNotFound, address)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(local, relayAddressPrefix), ".")
+	if len(parts) != 3 {
+		return 0, "", "", fmt.Errorf("%w: malformed relay address %q", domain.ErrNotFound, address)
+	}
+	messageID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || messageID <= 0 {
+		return 0, "", "", fmt.Errorf("%w: malformed relay address %q", domain.ErrNotFound, address)
+	}
+	direction := domain.RelayDirection(parts[1])
+	if direction != domain.RelayToPoster && direction != domain.RelayToResponder {
+		return 0, "", "", fmt.Errorf("%w: malformed relay address %q", domain.ErrNotFound, address)
+	}
+	return messageID, direction, parts[2], nil
+}
+
+func (m *MailRelay) sign(local string, participant string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(local + "." + strings.ToLower(strings.TrimSpace(participant))))
+	return hex.EncodeToString(mac.Sum(nil))[:relaySignatureLen]
+}
+
+// MailRelayRepository defines the lookups needed to route relay replies.
+type MailRelayRepository interface {
+	GetMessageByID(ctx context.Context, messageID int64) (domain.Message, error)
+	GetPostByID(ctx context.Context, postID int64) (domain.Post, error)
+}
+
+// MailRelayService forwards inbound replies between a post owner and a responder.
+type MailRelayService struct {
+	repo  MailRelayRepository
+	relay *MailRelay
+}
+
+// NewMailRelayService constructs MailRelayService.
+func NewMailRelayService(repo MailRelayRepository, relay *MailRelay) *MailRelayService {
+	return &MailRelayService{repo: repo, relay: relay}
+}
+
+// Forward routes one inbound email to the other party. The relay address must
+// have been issued to the thread's participant on the sending side; that
+// signature is the permission check. From and Sender can be spoofed, so they
+// only set SenderMatched on the result.
+func (s *MailRelayService) Forward(
+	ctx context.Context,
+	inbound domain.InboundEmail,
+	fromEmail string,
+	sender PostRespondEmailSender,
+) (domain.InboundRelayResult, error) {
+	if sender == nil {
+		return domain.InboundRelayResult{}, fmt.Errorf("relay email sender is required")
+	}
+	messageID, direction, err := s.relay.Parse(inbound.Recipient)
+	if err != nil {
+		return domain.InboundRelayResult{}, err
+	}
+
+	message, err := s.repo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return domain.InboundRelayResult{}, fmt.Errorf("loading relay message %d: %w", messageID, err)
+	}
+	post, err := s.repo.GetPostByID(ctx, message.PostID)
+	if err != nil {
+		return domain.InboundRelayResult{}, fmt.Errorf("loading relay post %d: %w", message.PostID, err)
+	}
+
+	posterEmail := strings.TrimSpace(post.Email)
+	responderEmail := strings.TrimSpace(message.Email)
+	var (
+		participant string
+		forwardTo   string
+		replyVia    domain.RelayDirection
+	)
+	switch direction {
+	case domain.RelayToPoster:
+		participant, forwardTo, replyVia = responderEmail, posterEmail, domain.RelayToResponder
+	case domain.RelayToResponder:
+		participant, forwardTo, replyVia = posterEmail, responderEmail, domain.RelayToPoster
+	}
+	if err := s.relay.Verify(inbound.Recipient, participant); err != nil {
+		return domain.InboundRelayResult{}, fmt.Errorf("relay message %d: %w", messageID, err)
+	}
+	if forwardTo == "" {
+		return domain.InboundRelayResult{}, fmt.Errorf("relay message %d has no destination email", messageID)
+	}
+
+	text := strings.TrimSpace(inbound.Text)
+	if text == "" {
+		return domain.InboundRelayResult{}, fmt.Errorf("validation failed: inbound email has no text body")
+	}
+	subject := strings.TrimSpace(inbound.Subject)
+	if subject == "" {
+		subject = "Re: " + strings.TrimSpace(post.Name)
+	}
+
+	replyTo := s.relay.Address(messageID, replyVia, forwardTo)
+	if _, err := sender.SendResponseEmail(ctx, domain.ResponseEmailMessage{
+		From:    strings.TrimSpace(fromEmail),
+		To:      forwardTo,
+		ReplyTo: replyTo,
+		Subject: subject,
+		Text:    text,
+		HTML:    strings.TrimSpace(inbound.HTML),
+	}); err != nil {
+		return domain.InboundRelayResult{}, err
+	}
+
+	return domain.InboundRelayResult{
+		MessageID:     messageID,
+		PostID:        post.ID,
+		Direction:     direction,
+		ForwardedTo:   forwardTo,
+		ReplyTo:       replyTo,
+		SenderMatched: inboundSentBy(inbound, participant),
+	}, nil
+}
+
+// inboundSentBy reports whether the From or Sender header names expected.
+// Headers are set by the sending client, so this is a hint, never a check.
+func inboundSentBy(inbound domain.InboundEmail, expected string) bool {
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	if expected == "" {
+		return false
+	}
+	for _, raw := range []string{inbound.Sender, inbound.From} {
+		addr := strings.TrimSpace(raw)
+		if parsed, err := mail.ParseAddress(addr); err == nil {
+			addr = parsed.Address
+		}
+		if strings.EqualFold(addr, expected) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+type mockMailRelayRepo struct {
+	message domain.Message
+	post    domain.Post
+}
+
+func (m *mockMailRelayRepo) GetMessageByID(_ context.Context, messageID int64) (domain.Message, error) {
+	if messageID != m.message.ID {
+		return domain.Message{}, domain.ErrNotFound
+	}
+	return m.message, nil
+}
+
+func (m *mockMailRelayRepo) GetPostByID(_ context.Context, postID int64) (domain.Post, error) {
+	if postID != m.post.ID {
+		return domain.Post{}, domain.ErrNotFound
+	}
+	return m.post, nil
+}
+
+func newTestMailRelay(t *testing.T) *MailRelay {
+	t.Helper()
+	relay, err := NewMailRelay("MG.supost.com", "relay-secret")
+	if err != nil {
+		t.Fatalf("unexpected relay constructor error: %v", err)
+	}
+	return relay
+}
+
+func TestMailRelay_AddressRoundTripAndTamperDetection(t *testing.T) {
+	relay := newTestMailRelay(t)
+	address := relay.Address(77, domain.RelayToPoster, "Buyer@Gmail.com")
+	if !strings.HasPrefix(address, "reply+77.p.") || !strings.HasSuffix(address, "@mg.supost.com") {
+		t.Fatalf("unexpected relay address %q", address)
+	}
+
+	messageID, direction, err := relay.Parse("Post Owner <" + strings.ToUpper(address) + ">")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if messageID != 77 || direction != domain.RelayToPoster {
+		t.Fatalf("unexpected parse result %d %q", messageID, direction)
+	}
+	if err := relay.Verify(strings.ToUpper(address), " buyer@gmail.com "); err != nil {
+		t.Fatalf("expected the address verified for its participant, got %v", err)
+	}
+	if err := relay.Verify(address, "someone@else.com"); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected an address issued to someone else refused, got %v", err)
+	}
+	if err := relay.Verify(address, ""); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected a blank participant refused, got %v", err)
+	}
+
+	retargeted := strings.Replace(address, "reply+77.", "reply+78.", 1)
+	if err := relay.Verify(retargeted, "buyer@gmail.com"); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected signature mismatch for retargeted address, got %v", err)
+	}
+	if _, _, err := relay.Parse("reply+77.p.abc@example.com"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected not-a-relay error for foreign domain, got %v", err)
+	}
+	otherSecret, _ := NewMailRelay("mg.supost.com", "other-secret")
+	if err := otherSecret.Verify(address, "buyer@gmail.com"); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected signature mismatch under a different secret, got %v", err)
+	}
+}
+
+func TestMailRelayService_ForwardsBothDirections(t *testing.T) {
+	relay := newTestMailRelay(t)
+	repo := &mockMailRelayRepo{
+		message: domain.Message{ID: 77, PostID: 130031908, Email: "buyer@gmail.com"},
+		post:    domain.Post{ID: 130031908, Email: "owner@stanford.edu", Name: "Desk"},
+	}
+	svc := NewMailRelayService(repo, relay)
+
+	sender := &mockPostRespondSender{}
+	result, err := svc.Forward(context.Background(), domain.InboundEmail{
+		Recipient: relay.Address(77, domain.RelayToResponder, "owner@stanford.edu"),
+		From:      "Owner <owner@stanford.edu>",
+		Subject:   "Re: Desk",
+		Text:      "Yes, still available.",
+	}, "response@mg.supost.com", sender)
+	if err != nil {
+		t.Fatalf("unexpected forward error: %v", err)
+	}
+	if sender.last.To != "buyer@gmail.com" || result.ForwardedTo != "buyer@gmail.com" || !result.SenderMatched {
+		t.Fatalf("expected forward to responder from the matching sender, got %+v / %+v", sender.last, result)
+	}
+	if sender.last.ReplyTo != relay.Address(77, domain.RelayToPoster, "buyer@gmail.com") {
+		t.Fatalf("expected reply-to issued to the responder to route back to poster, got %q", sender.last.ReplyTo)
+	}
+	if strings.Contains(sender.last.ReplyTo, "owner@stanford.edu") || sender.last.From != "response@mg.supost.com" {
+		t.Fatalf("expected poster address to stay hidden, got %+v", sender.last)
+	}
+
+	sender = &mockPostRespondSender{}
+	_, err = svc.Forward(context.Background(), domain.InboundEmail{
+		Recipient: relay.Address(77, domain.RelayToPoster, "buyer@gmail.com"),
+		Sender:    "buyer@gmail.com",
+		Text:      "Great, see you at 5.",
+	}, "response@mg.supost.com", sender)
+	if err != nil {
+		t.Fatalf("unexpected forward error: %v", err)
+	}
+	if sender.last.To != "owner@stanford.edu" || sender.last.Subject != "Re: Desk" {
+		t.Fatalf("expected forward to poster with default subject, got %+v", sender.last)
+	}
+}
+
+func TestMailRelayService_AuthorizesByAddressNotHeaders(t *testing.T) {
+	relay := newTestMailRelay(t)
+	repo := &mockMailRelayRepo{
+		message: domain.Message{ID: 77, PostID: 130031908, Email: "buyer@gmail.com"},
+		post:    domain.Post{ID: 130031908, Email: "owner@stanford.edu", Name: "Desk"},
+	}
+	svc := NewMailRelayService(repo, relay)
+
+	sender := &mockPostRespondSender{}
+	_, err := svc.Forward(context.Background(), domain.InboundEmail{
+		Recipient: relay.Address(77, domain.RelayToPoster, "spammer@example.com"),
+		From:      "buyer@gmail.com",
+		Text:      "Buy now",
+	}, "response@mg.supost.com", sender)
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected an address not issued to the responder refused despite a forged From, got %v", err)
+	}
+	if sender.sent {
+		t.Fatalf("did not expect relay to send for an address issued to someone else")
+	}
+
+	result, err := svc.Forward(context.Background(), domain.InboundEmail{
+		Recipient: relay.Address(77, domain.RelayToPoster, "buyer@gmail.com"),
+		From:      "Buyer Alias <buyer.alias@example.com>",
+		Text:      "Replying from my other account.",
+	}, "response@mg.supost.com", sender)
+	if err != nil || !sender.sent || result.SenderMatched {
+		t.Fatalf("expected the issued address forwarded with the header mismatch noted, got %+v, %v", result, err)
+	}
+}
+
+func TestPostRespondService_ReplyRelayHidesResponderAddress(t *testing.T) {
+	relay := newTestMailRelay(t)
+	repo := &mockPostRespondRepo{post: goldenPreviewPost()}
+	sender := &mockPostRespondSender{}
+	svc := NewPostRespondService(repo).WithReplyRelay(relay)
+
+	result, err := svc.Respond(context.Background(), domain.PostRespondSubmission{
+		PostID:  130031908,
+		Message: "Still available?",
+		ReplyTo: "buyer@gmail.com",
+	}, false, "https://supost.com", "response@mg.supost.com", sender)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !repo.saveCalled || repo.savedMessage.Email != "buyer@gmail.com" {
+		t.Fatalf("expected responder's real address to be persisted, got %+v", repo.savedMessage)
+	}
+	wantRelay := relay.Address(77, domain.RelayToResponder, repo.post.Email)
+	if result.ReplyRelay != wantRelay || sender.last.ReplyTo != wantRelay {
+		t.Fatalf("expected reply-to %q, got result=%q sent=%q", wantRelay, result.ReplyRelay, sender.last.ReplyTo)
+	}
+	for _, field := range []string{sender.last.Subject, sender.last.Text, sender.last.HTML} {
+		if strings.Contains(field, "buyer@gmail.com") {
+			t.Fatalf("expected responder address to be hidden from poster, found in %q", field)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// messageStatusRank orders delivery statuses so late or duplicate events never
+// move a message backwards (e.g. a delayed "delivered" after a complaint).
+var messageStatusRank = map[string]int{
+	domain.MessageStatusQueued:       0,
+	domain.MessageStatusDelivered:    1,
+	domain.MessageStatusFailed:       1,
+	domain.MessageStatusUnsubscribed: 2,
+	domain.MessageStatusComplained:   3,
+}
+
+// MessageStatusRepository defines message lookups and status updates.
+type MessageStatusRepository interface {
+	GetMessageByID(ctx context.Context, messageID int64) (domain.Message, error)
+	GetMessageByMailgunID(ctx context.Context, mailgunMessageID string) (domain.Message, error)
+	UpdateMessageStatus(ctx context.Context, messageID int64, status string) (domain.Message, error)
+}
+
+// MessageStatusService tracks delivery status for response messages.
+type MessageStatusService struct {
+	repo MessageStatusRepository
+}
+
+// NewMessageStatusService constructs MessageStatusService.
+func NewMessageStatusService(repo MessageStatusRepository) *MessageStatusService {
+	return &MessageStatusService{repo: repo}
+}
+
+// GetStatus returns one message row including its delivery status.
+func (s *MessageStatusService) GetStatus(ctx context.Context, messageID int64) (domain.Message, error) {
+	if messageID <= 0 {
+		return domain.Message{}, fmt.Errorf("message id must be positive")
+	}
+	return s.repo.GetMessageByID(ctx, messageID)
+}
+
+// ApplyEvent maps one Mailgun event onto the correlated message row. Events
+// that do not change status, or reference unknown messages, are reported as
+// not applied rather than failing so Mailgun does not retry them.
+func (s *MessageStatusService) ApplyEvent(ctx context.Context, event domain.MailEvent) (domain.MailEventResult, error) {
+	result := domain.MailEventResult{Event: strings.ToLower(strings.TrimSpace(event.Event))}
+
+	status, ok := messageStatusForEvent(result.Event, event.Severity)
+	if !ok {
+		result.Reason = "event does not change message status"
+		return result, nil
+	}
+	mailgunMessageID := strings.TrimSpace(event.MessageID)
+	if mailgunMessageID == "" {
+		result.Reason = "event has no message id"
+		return result, nil
+	}
+
+	message, err := s.repo.GetMessageByMailgunID(ctx, mailgunMessageID)
+	if errors.Is(err, domain.ErrNotFound) {
+		result.Reason = "no message matches mailgun message id"
+		return result, nil
+	}
+	if err != nil {
+		return domain.MailEventResult{}, fmt.Errorf("loading message for mailgun id: %w", err)
+	}
+	result.MessageID = message.ID
+	result.Status = message.Status
+
+	if messageStatusRank[status] < messageStatusRank[message.Status] {
+		result.Reason = fmt.Sprintf("status %q does not override %q", status, message.Status)
+		return result, nil
+	}
+
+	updated, err := s.repo.UpdateMessageStatus(ctx, message.ID, status)
+	if err != nil {
+		return domain.MailEventResult{}, fmt.Errorf("updating message %d status: %w", message.ID, err)
+	}
+	result.Status = updated.Status
+	result.Applied = true
+	return result, nil
+}
+
+// messageStatusForEvent maps a Mailgun event name to a message status.
+// Temporary failures are retried by Mailgun and do not change status.
+func messageStatusForEvent(event, severity string) (string, bool) {
+	switch event {
+	case "delivered":
+		return domain.MessageStatusDelivered, true
+	case "failed":
+		if strings.EqualFold(strings.TrimSpace(severity), "temporary") {
+			return "", false
+		}
+		return domain.MessageStatusFailed, true
+	case "complained":
+		return domain.MessageStatusComplained, true
+	case "unsubscribed":
+		return domain.MessageStatusUnsubscribed, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+type mockMessageStatusRepo struct {
+	message      domain.Message
+	updateCalled bool
+}
+
+func (m *mockMessageStatusRepo) GetMessageByID(_ context.Context, messageID int64) (domain.Message, error) {
+	if messageID != m.message.ID {
+		return domain.Message{}, domain.ErrNotFound
+	}
+	return m.message, nil
+}
+
+func (m *mockMessageStatusRepo) GetMessageByMailgunID(_ context.Context, mailgunMessageID string) (domain.Message, error) {
+	if mailgunMessageID != m.message.MailgunMessageID {
+		return domain.Message{}, domain.ErrNotFound
+	}
+	return m.message, nil
+}
+
+func (m *mockMessageStatusRepo) UpdateMessageStatus(_ context.Context, _ int64, status string) (domain.Message, error) {
+	m.updateCalled = true
+	m.message.Status = status
+	return m.message, nil
+}
+
+func TestMessageStatusService_ApplyEvent_MapsMailgunEvents(t *testing.T) {
+	cases := []struct {
+		event    string
+		severity string
+		want     string
+		applied  bool
+	}{
+		{event: "delivered", want: domain.MessageStatusDelivered, applied: true},
+		{event: "failed", severity: "permanent", want: domain.MessageStatusFailed, applied: true},
+		{event: "failed", severity: "temporary", want: domain.MessageStatusQueued},
+		{event: "complained", want: domain.MessageStatusComplained, applied: true},
+		{event: "unsubscribed", want: domain.MessageStatusUnsubscribed, applied: true},
+		{event: "opened", want: domain.MessageStatusQueued},
+	}
+	for _, tc := range cases {
+		repo := &mockMessageStatusRepo{message: domain.Message{ID: 77, Status: domain.MessageStatusQueued, MailgunMessageID: "77@mg.supost.com"}}
+		svc := NewMessageStatusService(repo)
+
+		result, err := svc.ApplyEvent(context.Background(), domain.MailEvent{
+			Event:     tc.event,
+			Severity:  tc.severity,
+			MessageID: "77@mg.supost.com",
+		})
+		if err != nil {
+			t.Fatalf("%s/%s: unexpected error: %v", tc.event, tc.severity, err)
+		}
+		if result.Applied != tc.applied || repo.message.Status != tc.want {
+			t.Fatalf("%s/%s: expected applied=%t status=%q, got %+v (stored %q)", tc.event, tc.severity, tc.applied, tc.want, result, repo.message.Status)
+		}
+	}
+}
+
+func TestMessageStatusService_ApplyEvent_DoesNotDowngrade(t *testing.T) {
+	repo := &mockMessageStatusRepo{message: domain.Message{ID: 77, Status: domain.MessageStatusComplained, MailgunMessageID: "77@mg.supost.com"}}
+	svc := NewMessageStatusService(repo)
+
+	result, err := svc.ApplyEvent(context.Background(), domain.MailEvent{Event: "delivered", MessageID: "77@mg.supost.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied || repo.updateCalled || repo.message.Status != domain.MessageStatusComplained {
+		t.Fatalf("expected late delivered event to be ignored, got %+v", result)
+	}
+}
+
+func TestMessageStatusService_ApplyEvent_UnknownMessageIsIgnored(t *testing.T) {
+	svc := NewMessageStatusService(&mockMessageStatusRepo{message: domain.Message{ID: 77, MailgunMessageID: "77@mg.supost.com"}})
+
+	result, err := svc.ApplyEvent(context.Background(), domain.MailEvent{Event: "delivered", MessageID: "other@mg.supost.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied || result.Reason == "" {
+		t.Fatalf("expected unknown message to be reported as not applied, got %+v", result)
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// Messages returns the responses on the user's posts as one thread per post.
+// With postID set, only that post's thread is returned, even when it has no
+// responses yet. Otherwise posts without responses are left out and threads
+// are ordered by their latest response, newest first.
+func (s *MyPostsService) Messages(ctx context.Context, postID int64) (domain.MyMessagesResult, error) {
+	var (
+		email string
+		posts []domain.Post
+	)
+	if postID != 0 {
+		owner, post, err := s.ownedPost(ctx, postID)
+		if err != nil {
+			return domain.MyMessagesResult{}, err
+		}
+		email, posts = owner, []domain.Post{post}
+	} else {
+		owner, err := s.ownerEmail(ctx)
+		if err != nil {
+			return domain.MyMessagesResult{}, err
+		}
+		owned, err := s.repo.ListPostsByEmail(ctx, owner)
+		if err != nil {
+			return domain.MyMessagesResult{}, fmt.Errorf("listing posts for %s: %w", owner, err)
+		}
+		email, posts = owner, owned
+	}
+
+	result := domain.MyMessagesResult{Email: email, PostID: postID, Threads: []domain.MessageThread{}}
+	if len(posts) == 0 {
+		return result, nil
+	}
+
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	messages, err := s.repo.ListMessagesForPosts(ctx, postIDs)
+	if err != nil {
+		return domain.MyMessagesResult{}, fmt.Errorf("listing messages for %s: %w", email, err)
+	}
+
+	byPost := make(map[int64][]domain.InboxMessage, len(posts))
+	for _, message := range messages {
+		byPost[message.PostID] = append(byPost[message.PostID], inboxMessage(message))
+	}
+	for _, post := range posts {
+		thread := byPost[post.ID]
+		if len(thread) == 0 && postID == 0 {
+			continue
+		}
+		sort.SliceStable(thread, func(i, j int) bool {
+			return thread[i].CreatedAt.Before(thread[j].CreatedAt)
+		})
+		result.Threads = append(result.Threads, domain.MessageThread{
+			PostID:     post.ID,
+			PostName:   post.Name,
+			PostStatus: domain.PostStatusLabel(post.Status),
+			Messages:   append([]domain.InboxMessage{}, thread...),
+		})
+		result.MessageCount += len(thread)
+	}
+	sort.SliceStable(result.Threads, func(i, j int) bool {
+		return latestInboxMessage(result.Threads[i]).After(latestInboxMessage(result.Threads[j]))
+	})
+	return result, nil
+}
+
+func inboxMessage(message domain.Message) domain.InboxMessage {
+	return domain.InboxMessage{
+		ID:        message.ID,
+		PostID:    message.PostID,
+		ReplyTo:   message.Email,
+		Message:   message.Message,
+		Status:    message.Status,
+		Scammed:   message.Scammed,
+		CreatedAt: message.CreatedAt,
+	}
+}
+
+func latestInboxMessage(thread domain.MessageThread) (latest time.Time) {
+	for _, message := range thread.Messages {
+		if message.CreatedAt.After(latest) {
+			latest = message.CreatedAt
+		}
+	}
+	return latest
+}
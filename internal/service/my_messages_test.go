@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+)
+
+func TestMyPostsService_MessagesThreadsResponsesOnOwnPosts(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewInMemory()
+	svc := NewMyPostsService(repo, loggedInAs(t, map[string]any{"email": "pat@stanford.edu", "exp": now.Add(time.Hour).Unix()}))
+	svc.now = func() time.Time { return now }
+	const ownPostID, otherPostID = 130031896, 130031901
+
+	empty, err := svc.Messages(context.Background(), 0)
+	if err != nil || empty.MessageCount != 0 || len(empty.Threads) != 0 {
+		t.Fatalf("expected an empty inbox, got %+v, %v", empty, err)
+	}
+
+	first, _ := repo.CreateResponseMessage(context.Background(), ownPostID, "buyer@stanford.edu", "Is it still available?", "127.0.0.1", "test")
+	second, _ := repo.CreateResponseMessage(context.Background(), ownPostID, "scam@example.com", "I will send a check", "127.0.0.1", "test")
+	_, _ = repo.CreateResponseMessage(context.Background(), otherPostID, "buyer@stanford.edu", "Not pat's post", "127.0.0.1", "test")
+	if _, err := repo.UpdateMessageStatus(context.Background(), first.ID, domain.MessageStatusDelivered); err != nil {
+		t.Fatalf("unexpected status update error: %v", err)
+	}
+
+	inbox, err := svc.Messages(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected messages error: %v", err)
+	}
+	if inbox.Email != "pat@stanford.edu" || inbox.MessageCount != 2 || len(inbox.Threads) != 1 {
+		t.Fatalf("expected one thread with pat's two responses, got %+v", inbox)
+	}
+	thread := inbox.Threads[0]
+	if thread.PostID != ownPostID || thread.PostStatus != "pending" || len(thread.Messages) != 2 {
+		t.Fatalf("unexpected thread %+v", thread)
+	}
+	if thread.Messages[0].ID != first.ID || thread.Messages[0].ReplyTo != "buyer@stanford.edu" || thread.Messages[0].Status != domain.MessageStatusDelivered {
+		t.Fatalf("expected the first response first with its status, got %+v", thread.Messages[0])
+	}
+	if thread.Messages[1].ID != second.ID {
+		t.Fatalf("expected responses oldest first, got %+v", thread.Messages)
+	}
+
+	filtered, err := svc.Messages(context.Background(), ownPostID)
+	if err != nil || filtered.PostID != ownPostID || len(filtered.Threads) != 1 {
+		t.Fatalf("unexpected filtered inbox %+v, %v", filtered, err)
+	}
+	if _, err := svc.Messages(context.Background(), otherPostID); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected another account's post refused, got %v", err)
+	}
+}
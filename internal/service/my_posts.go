@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// MyPostsRepository reads and changes posts on behalf of their owner. The
+// Owned* writes match on email as well as id and return domain.ErrNotFound
+// when nothing matched.
+type MyPostsRepository interface {
+	GetPostByID(ctx context.Context, postID int64) (domain.Post, error)
+	ListPostsByEmail(ctx context.Context, email string) ([]domain.Post, error)
+	PublishOwnedPost(ctx context.Context, postID int64, email string, at time.Time) error
+	UpdateOwnedPost(ctx context.Context, postID int64, email string, edit domain.PostEdit, at time.Time) error
+	DeleteOwnedPost(ctx context.Context, postID int64, email string, at time.Time) error
+	ListMessagesForPosts(ctx context.Context, postIDs []int64) ([]domain.Message, error)
+}
+
+// AuthSessionSource resolves the logged-in user. AuthSessionService
+// implements it by asking Supabase who the session's access token belongs
+// to; failures wrap domain.ErrUnauthorized.
+type AuthSessionSource interface {
+	VerifiedIdentity(ctx context.Context) (domain.AuthIdentity, error)
+}
+
+// MyPostsService lists and manages the logged-in user's posts. Ownership is
+// the post email matching the email Supabase reports for the session, so no
+// per-post access token is needed and a hand-made token cannot claim
+// someone else's posts.
+type MyPostsService struct {
+	repo     MyPostsRepository
+	sessions AuthSessionSource
+	now      func() time.Time
+}
+
+// NewMyPostsService constructs MyPostsService.
+func NewMyPostsService(repo MyPostsRepository, sessions AuthSessionSource) *MyPostsService {
+	return &MyPostsService{repo: repo, sessions: sessions, now: time.Now}
+}
+
+// List returns the user's pending, active, and expired posts, newest first.
+func (s *MyPostsService) List(ctx context.Context) (domain.MyPostsResult, error) {
+	email, err := s.ownerEmail(ctx)
+	if err != nil {
+		return domain.MyPostsResult{}, err
+	}
+	posts, err := s.repo.ListPostsByEmail(ctx, email)
+	if err != nil {
+		return domain.MyPostsResult{}, fmt.Errorf("listing posts for %s: %w", email, err)
+	}
+	return domain.MyPostsResult{Email: email, Posts: posts}, nil
+}
+
+// Publish makes one of the user's pending posts active.
+func (s *MyPostsService) Publish(ctx context.Context, postID int64) (domain.MyPostActionResult, error) {
+	email, post, err := s.ownedPost(ctx, postID)
+	if err != nil {
+		return domain.MyPostActionResult{}, err
+	}
+	if post.Status != domain.PostStatusPending {
+		return domain.MyPostActionResult{}, fmt.Errorf("%w: post %d is %s, only pending posts can be published", domain.ErrConflict, postID, domain.PostStatusLabel(post.Status))
+	}
+	if err := s.repo.PublishOwnedPost(ctx, postID, email, s.now()); err != nil {
+		return domain.MyPostActionResult{}, fmt.Errorf("publishing post %d: %w", postID, err)
+	}
+	post.Status = domain.PostStatusActive
+	return myPostActionResult("publish", post), nil
+}
+
+// Edit changes the name, body, or price of one of the user's posts. The
+// category's price rule from post create still applies.
+func (s *MyPostsService) Edit(ctx context.Context, postID int64, edit domain.PostEdit) (domain.MyPostActionResult, error) {
+	edit.Name = strings.TrimSpace(edit.Name)
+	edit.Body = strings.TrimSpace(edit.Body)
+	if edit.Name == "" && edit.Body == "" && !edit.PriceProvided {
+		return domain.MyPostActionResult{}, fmt.Errorf("nothing to edit: set a name, body, or price")
+	}
+
+	email, post, err := s.ownedPost(ctx, postID)
+	if err != nil {
+		return domain.MyPostActionResult{}, err
+	}
+	if edit.PriceProvided {
+		if !domain.CategoryPriceAllowed(post.CategoryID) {
+			return domain.MyPostActionResult{}, fmt.Errorf("price is not allowed for this category")
+		}
+		if edit.Price < 0 {
+			return domain.MyPostActionResult{}, fmt.Errorf("price must be non-negative")
+		}
+	}
+
+	// The repository writes every field, so carry over what is not changing.
+	if edit.Name == "" {
+		edit.Name = post.Name
+	}
+	if edit.Body == "" {
+		edit.Body = post.Body
+	}
+	if !edit.PriceProvided {
+		edit.Price, edit.PriceProvided = post.Price, post.HasPrice
+	}
+	if err := s.repo.UpdateOwnedPost(ctx, postID, email, edit, s.now()); err != nil {
+		return domain.MyPostActionResult{}, fmt.Errorf("updating post %d: %w", postID, err)
+	}
+	post.Name, post.Body, post.Price, post.HasPrice = edit.Name, edit.Body, edit.Price, edit.PriceProvided
+	return myPostActionResult("edit", post), nil
+}
+
+// Delete marks one of the user's posts deleted.
+func (s *MyPostsService) Delete(ctx context.Context, postID int64) (domain.MyPostActionResult, error) {
+	email, post, err := s.ownedPost(ctx, postID)
+	if err != nil {
+		return domain.MyPostActionResult{}, err
+	}
+	if err := s.repo.DeleteOwnedPost(ctx, postID, email, s.now()); err != nil {
+		return domain.MyPostActionResult{}, fmt.Errorf("deleting post %d: %w", postID, err)
+	}
+	post.Status = domain.PostStatusDeleted
+	return myPostActionResult("delete", post), nil
+}
+
+// ownerEmail returns the verified email of the logged-in user. Posts are
+// owned by email, so an address Supabase has not confirmed owns nothing.
+func (s *MyPostsService) ownerEmail(ctx context.Context) (string, error) {
+	identity, err := s.sessions.VerifiedIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+	if identity.Email == "" {
+		return "", fmt.Errorf("%w: account has no email; log in with an email account", domain.ErrUnauthorized)
+	}
+	if identity.EmailConfirmedAt.IsZero() {
+		return "", fmt.Errorf("%w: email %s is not confirmed; follow the link Supabase sent, then try again", domain.ErrUnauthorized, identity.Email)
+	}
+	return identity.Email, nil
+}
+
+// ownedPost loads a post and checks that the session's email owns it.
+// Deleted posts read as not found.
+func (s *MyPostsService) ownedPost(ctx context.Context, postID int64) (string, domain.Post, error) {
+	if postID <= 0 {
+		return "", domain.Post{}, fmt.Errorf("post id must be positive")
+	}
+	email, err := s.ownerEmail(ctx)
+	if err != nil {
+		return "", domain.Post{}, err
+	}
+	post, err := s.repo.GetPostByID(ctx, postID)
+	if errors.Is(err, domain.ErrNotFound) || (err == nil && post.Status == domain.PostStatusDeleted) {
+		return "", domain.Post{}, fmt.Errorf("post %d: %w", postID, domain.ErrNotFound)
+	}
+	if err != nil {
+		return "", domain.Post{}, fmt.Errorf("loading post %d: %w", postID, err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(post.Email), email) {
+		return "", domain.Post{}, fmt.Errorf("%w: post %d belongs to another account", domain.ErrUnauthorized, postID)
+	}
+	return email, post, nil
+}
+
+func myPostActionResult(action string, post domain.Post) domain.MyPostActionResult {
+	return domain.MyPostActionResult{
+		Action:   action,
+		PostID:   post.ID,
+		PostName: post.Name,
+		Status:   domain.PostStatusLabel(post.Status),
+		Price:    post.Price,
+		HasPrice: post.HasPrice,
+	}
+}
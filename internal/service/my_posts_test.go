@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+)
+
+// staticAuthSession stands in for a Supabase user lookup.
+type staticAuthSession struct {
+	identity domain.AuthIdentity
+	err      error
+}
+
+func (s staticAuthSession) VerifiedIdentity(context.Context) (domain.AuthIdentity, error) {
+	return s.identity, s.err
+}
+
+// loggedInAs is a session Supabase accepts, belonging to the user in claims,
+// whose email Supabase has confirmed.
+func loggedInAs(t *testing.T, claims map[string]any) staticAuthSession {
+	t.Helper()
+	identity, err := decodeAccessTokenIdentity(testAccessToken(t, claims))
+	if err != nil {
+		t.Fatalf("decoding test claims: %v", err)
+	}
+	identity.EmailConfirmedAt = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return staticAuthSession{identity: identity}
+}
+
+// unconfirmed is s with the email confirmation cleared.
+func unconfirmed(s staticAuthSession) staticAuthSession {
+	s.identity.EmailConfirmedAt = time.Time{}
+	return s
+}
+
+func TestMyPostsService_OwnerListsPublishesEditsAndDeletes(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewInMemory()
+	svc := NewMyPostsService(repo, loggedInAs(t, map[string]any{"email": "Pat@Stanford.edu", "exp": now.Add(time.Hour).Unix()}))
+	svc.now = func() time.Time { return now }
+	const pendingID = 130031896
+
+	listed, err := svc.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	if listed.Email != "pat@stanford.edu" || len(listed.Posts) != 1 || listed.Posts[0].ID != pendingID {
+		t.Fatalf("expected pat's pending post, got %+v", listed)
+	}
+
+	published, err := svc.Publish(context.Background(), pendingID)
+	if err != nil || published.Status != "active" {
+		t.Fatalf("unexpected publish result %+v, %v", published, err)
+	}
+	if _, err := svc.Publish(context.Background(), pendingID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected publishing an active post to conflict, got %v", err)
+	}
+	post, _ := repo.GetPostByID(context.Background(), pendingID)
+	if post.Status != domain.PostStatusActive || post.TimePosted != now.Unix() {
+		t.Fatalf("expected the post active and stamped at publish time, got %+v", post)
+	}
+
+	bodyBefore := post.Body
+	edited, err := svc.Edit(context.Background(), pendingID, domain.PostEdit{Name: "  Keyboard only  ", Price: 40, PriceProvided: true})
+	if err != nil {
+		t.Fatalf("unexpected edit error: %v", err)
+	}
+	post, _ = repo.GetPostByID(context.Background(), pendingID)
+	if edited.PostName != "Keyboard only" || post.Name != "Keyboard only" || post.Price != 40 || !post.HasPrice || post.Body != bodyBefore {
+		t.Fatalf("expected name and price changed and body kept, got %+v", post)
+	}
+
+	if _, err := svc.Delete(context.Background(), pendingID); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, err := svc.Delete(context.Background(), pendingID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected a deleted post to read as not found, got %v", err)
+	}
+	listed, _ = svc.List(context.Background())
+	if len(listed.Posts) != 0 {
+		t.Fatalf("expected the deleted post hidden, got %+v", listed.Posts)
+	}
+}
+
+func TestMyPostsService_EnforcesEmailClaim(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewInMemory()
+	const otherPostID = 130031901 // alex@stanford.edu
+
+	svc := NewMyPostsService(repo, loggedInAs(t, map[string]any{"email": "pat@stanford.edu", "exp": now.Add(time.Hour).Unix()}))
+	svc.now = func() time.Time { return now }
+	for name, action := range map[string]func() error{
+		"publish": func() error { _, err := svc.Publish(context.Background(), otherPostID); return err },
+		"edit": func() error {
+			_, err := svc.Edit(context.Background(), otherPostID, domain.PostEdit{Name: "mine now"})
+			return err
+		},
+		"delete": func() error { _, err := svc.Delete(context.Background(), otherPostID); return err },
+	} {
+		if err := action(); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("%s: expected ErrUnauthorized for another account's post, got %v", name, err)
+		}
+	}
+	if post, _ := repo.GetPostByID(context.Background(), otherPostID); post.Name != "Sublet room in EVGR premium 2b2b" || post.Status != domain.PostStatusActive {
+		t.Fatalf("expected alex's post untouched, got %+v", post)
+	}
+
+	for name, sessions := range map[string]AuthSessionSource{
+		"no email":             loggedInAs(t, map[string]any{"phone": "16505551234", "exp": now.Add(time.Hour).Unix()}),
+		"unconfirmed email":    unconfirmed(loggedInAs(t, map[string]any{"email": "pat@stanford.edu", "exp": now.Add(time.Hour).Unix()})),
+		"rejected by Supabase": staticAuthSession{err: fmt.Errorf("%w: invalid JWT", domain.ErrUnauthorized)},
+		"not logged in":        staticAuthSession{err: domain.ErrUnauthorized},
+	} {
+		svc := NewMyPostsService(repo, sessions)
+		svc.now = func() time.Time { return now }
+		if _, err := svc.List(context.Background()); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("%s: expected ErrUnauthorized, got %v", name, err)
+		}
+	}
+}
+
+func TestMyPostsService_ForgedSessionCannotActOnPosts(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	const pendingID = 130031896
+	forged := testAccessToken(t, map[string]any{"sub": "user-9", "email": "pat@stanford.edu", "exp": now.Add(time.Hour).Unix()})
+	store := &memoryAuthSessionStore{session: &domain.AuthSession{AccessToken: forged, RefreshToken: "refresh-9", ExpiresAt: now.Add(time.Hour)}}
+	provider := &mockAuthSessionProvider{}
+	sessions := NewAuthSessionService(provider, store)
+	sessions.now = func() time.Time { return now }
+
+	repo := repository.NewInMemory()
+	svc := NewMyPostsService(repo, sessions)
+	svc.now = func() time.Time { return now }
+	if _, err := svc.Publish(context.Background(), pendingID); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected a token Supabase rejects to be refused, got %v", err)
+	}
+	if provider.userLookups != 1 {
+		t.Fatalf("expected the session checked with Supabase, got %d lookups", provider.userLookups)
+	}
+	if post, _ := repo.GetPostByID(context.Background(), pendingID); post.Status != domain.PostStatusPending {
+		t.Fatalf("expected pat's post untouched, got status %d", post.Status)
+	}
+
+	// The email Supabase reports wins over the token's claim.
+	provider.users = map[string]domain.AuthIdentity{forged: {UserID: "user-9", Email: "mallory@stanford.edu"}}
+	if _, err := svc.Publish(context.Background(), pendingID); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected another account's post refused, got %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// Password policy defaults used when domain.PasswordPolicy fields are zero.
+const (
+	defaultPasswordMinLength      = 8
+	defaultPasswordMinClasses     = 3
+	defaultPasswordPassphraseLen  = 16
+	defaultPasswordMinEntropyBits = 28
+	// Supabase Auth hashes passwords with bcrypt, which ignores bytes past 72.
+	passwordMaxBytes = 72
+)
+
+//go:embed passwords/common.txt
+var embeddedCommonPasswords string
+
+// commonPasswordRanks maps each embedded common password to its rank.
+var commonPasswordRanks = sync.OnceValue(func() map[string]int {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(strings.NewReader(embeddedCommonPasswords))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, ok := ranks[line]; !ok {
+			ranks[line] = len(ranks) + 1
+		}
+	}
+	return ranks
+})
+
+// PasswordChecker evaluates passwords against a PasswordPolicy.
+type PasswordChecker struct {
+	policy domain.PasswordPolicy
+}
+
+// NewPasswordChecker fills zero policy fields with defaults.
+func NewPasswordChecker(policy domain.PasswordPolicy) *PasswordChecker {
+	if policy.MinLength <= 0 {
+		policy.MinLength = defaultPasswordMinLength
+	}
+	if policy.MinCharacterClasses <= 0 {
+		policy.MinCharacterClasses = defaultPasswordMinClasses
+	}
+	if policy.PassphraseLength <= 0 {
+		policy.PassphraseLength = defaultPasswordPassphraseLen
+	}
+	if policy.MinEntropyBits <= 0 {
+		policy.MinEntropyBits = defaultPasswordMinEntropyBits
+	}
+	return &PasswordChecker{policy: policy}
+}
+
+// Policy returns the policy in effect, defaults applied.
+func (c *PasswordChecker) Policy() domain.PasswordPolicy {
+	return c.policy
+}
+
+// Check runs every rule and reports each failure with its own message. The
+// email and display name are the ones being signed up with.
+func (c *PasswordChecker) Check(ctx context.Context, password string, email string, displayName string) (domain.PasswordCheckResult, error) {
+	result := domain.PasswordCheckResult{Problems: make([]domain.PasswordProblem, 0)}
+	fail := func(rule string, format string, args ...any) {
+		result.Problems = append(result.Problems, domain.PasswordProblem{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := len([]rune(password))
+	if length < c.policy.MinLength {
+		fail(domain.PasswordRuleLength, "password must be at least %d characters", c.policy.MinLength)
+	}
+	if len(password) > passwordMaxBytes {
+		fail(domain.PasswordRuleLength, "password must be at most %d bytes", passwordMaxBytes)
+	}
+	if classes := passwordCharacterClasses(password); length < c.policy.PassphraseLength && classes < c.policy.MinCharacterClasses {
+		fail(domain.PasswordRuleClasses, "password must mix at least %d of lowercase, uppercase, digits, and symbols (has %d), or be %d+ characters", c.policy.MinCharacterClasses, classes, c.policy.PassphraseLength)
+	}
+
+	lower := strings.ToLower(password)
+	ranks := commonPasswordRanks()
+	if _, ok := ranks[lower]; ok {
+		fail(domain.PasswordRuleCommon, "password is on the list of most common passwords")
+	} else if _, ok := ranks[unleetPassword(lower)]; ok {
+		fail(domain.PasswordRuleCommon, "password is a common password with letters swapped for symbols")
+	} else if isCommonPasswordVariant(lower, ranks) {
+		result.CommonVariant = true
+		fail(domain.PasswordRuleCommonVariant, "password is a common password with digits or symbols added at the end; choose something less guessable")
+	}
+
+	if part := containedPersonalToken(lower, emailLocalTokens(email)); part != "" {
+		fail(domain.PasswordRuleEmail, "password must not contain your email name (%q)", part)
+	}
+	if part := containedPersonalToken(lower, displayNameTokens(displayName)); part != "" {
+		fail(domain.PasswordRuleDisplayName, "password must not contain your display name (%q)", part)
+	}
+
+	result.EntropyBits = estimatePasswordEntropy(password, ranks)
+	if result.EntropyBits < c.policy.MinEntropyBits {
+		fail(domain.PasswordRuleEntropy, "password is too easy to guess (about %.0f bits, need %.0f); avoid common words, sequences, repeats, and years", result.EntropyBits, c.policy.MinEntropyBits)
+	}
+
+	return result, nil
+}
+
+// isCommonPasswordVariant reports whether lower is a common password once the
+// digits and symbols tacked onto its end are dropped ("Sunshine2024!",
+// "p@ssw0rd1!"). Passwords that are common as typed are left to the common
+// rule.
+func isCommonPasswordVariant(lower string, ranks map[string]int) bool {
+	trimSymbols := strings.TrimRightFunc(lower, func(ch rune) bool {
+		return unicode.IsPunct(ch) || unicode.IsSymbol(ch)
+	})
+	trimAll := strings.TrimRightFunc(lower, func(ch rune) bool {
+		return unicode.IsDigit(ch) || unicode.IsPunct(ch) || unicode.IsSymbol(ch)
+	})
+	for _, base := range []string{trimSymbols, trimAll} {
+		if base == "" || base == lower {
+			continue
+		}
+		if _, ok := ranks[base]; ok {
+			return true
+		}
+		if _, ok := ranks[unleetPassword(base)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func passwordCharacterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, ch := range password {
+		switch {
+		case unicode.IsLower(ch):
+			lower = true
+		case unicode.IsUpper(ch):
+			upper = true
+		case unicode.IsDigit(ch):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
+
+func unleetPassword(value string) string {
+	return strings.Map(func(ch rune) rune {
+		if plain, ok := passwordLeetSubstitutions[ch]; ok {
+			return plain
+		}
+		return ch
+	}, value)
+}
+
+// emailLocalTokens returns the local part of email and its pieces split on
+// dots, dashes, underscores, and plus tags.
+func emailLocalTokens(email string) []string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return nil
+	}
+	local := email[:at]
+	tokens := []string{local}
+	tokens = append(tokens, strings.FieldsFunc(local, func(ch rune) bool {
+		return ch == '.' || ch == '-' || ch == '_' || ch == '+'
+	})...)
+	return tokens
+}
+
+// displayNameTokens returns the name without spaces and each word in it.
+func displayNameTokens(displayName string) []string {
+	words := strings.Fields(strings.ToLower(displayName))
+	if len(words) == 0 {
+		return nil
+	}
+	return append([]string{strings.Join(words, "")}, words...)
+}
+
+// containedPersonalToken returns the first token of three or more
+// characters that the password contains, ignoring leetspeak.
+func containedPersonalToken(lowerPassword string, tokens []string) string {
+	plain := unleetPassword(lowerPassword)
+	for _, token := range tokens {
+		if len([]rune(token)) < 3 {
+			continue
+		}
+		if strings.Contains(lowerPassword, token) || strings.Contains(plain, token) {
+			return token
+		}
+	}
+	return ""
+}
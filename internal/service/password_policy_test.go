@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func TestPasswordChecker_ReportsEachFailedRule(t *testing.T) {
+	checker := NewPasswordChecker(domain.PasswordPolicy{})
+
+	for _, tc := range []struct {
+		password string
+		want     []string
+	}{
+		{"Ab1!", []string{domain.PasswordRuleLength, domain.PasswordRuleEntropy}},
+		{"bikelamps", []string{domain.PasswordRuleClasses}},
+		{"P@ssw0rd", []string{domain.PasswordRuleCommon, domain.PasswordRuleEntropy}},
+		{"Wientjes!9x", []string{domain.PasswordRuleEmail}},
+		{"greg-Bike-42", []string{domain.PasswordRuleDisplayName}},
+		{"Stanford2026!", []string{domain.PasswordRuleCommonVariant, domain.PasswordRuleEntropy}},
+		{"Sunshine2024!", []string{domain.PasswordRuleCommonVariant, domain.PasswordRuleEntropy}},
+		{"P@ssw0rd1!", []string{domain.PasswordRuleCommonVariant, domain.PasswordRuleEntropy}},
+	} {
+		result, err := checker.Check(context.Background(), tc.password, "g.wientjes@stanford.edu", "Greg W")
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tc.password, err)
+		}
+		got := make([]string, 0, len(result.Problems))
+		for _, problem := range result.Problems {
+			if problem.Message == "" {
+				t.Fatalf("%q: rule %s has no message", tc.password, problem.Rule)
+			}
+			got = append(got, problem.Rule)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Fatalf("%q: expected rules %v, got %+v", tc.password, tc.want, result.Problems)
+		}
+	}
+
+	result, err := checker.Check(context.Background(), "bike-lamp-42", "g.wientjes@stanford.edu", "Greg W")
+	if err != nil || !result.OK() || result.EntropyBits < 28 {
+		t.Fatalf("expected a strong password accepted, got %+v, %v", result, err)
+	}
+}
+
+func TestPasswordChecker_LongPassphraseSkipsCharacterClasses(t *testing.T) {
+	checker := NewPasswordChecker(domain.PasswordPolicy{})
+	result, err := checker.Check(context.Background(), "quiet orange bicycle harbor", "pat@stanford.edu", "Pat")
+	if err != nil || !result.OK() {
+		t.Fatalf("expected a lowercase passphrase accepted, got %+v, %v", result, err)
+	}
+}
+
+func TestEstimatePasswordEntropy_PenalizesPatterns(t *testing.T) {
+	ranks := commonPasswordRanks()
+	if bits := estimatePasswordEntropy("password", ranks); bits > 2 {
+		t.Fatalf("expected the top common password to be nearly free, got %.1f bits", bits)
+	}
+	weak := []string{"abcdefgh12", "aaaaaaaa1!", "1qaz2wsx!Q", "Password2024"}
+	for _, password := range weak {
+		if bits := estimatePasswordEntropy(password, ranks); bits >= 28 {
+			t.Fatalf("expected %q below 28 bits, got %.1f", password, bits)
+		}
+	}
+	if bits := estimatePasswordEntropy("Kx9#mPq2", ranks); bits < 28 {
+		t.Fatalf("expected random characters above 28 bits, got %.1f", bits)
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// passwordKeyboardRows are the adjacent-key runs the estimator treats as
+// cheap to guess, in both directions.
+var passwordKeyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+	"1qaz2wsx3edc4rfv5tgb6yhn7ujm8ik9ol0p",
+}
+
+// passwordLeetSubstitutions undoes the usual letter-for-symbol swaps before
+// dictionary lookups.
+var passwordLeetSubstitutions = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '1': 'i',
+	'!': 'i', '|': 'l', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z',
+}
+
+// passwordMaxWordLength bounds dictionary substring lookups.
+const passwordMaxWordLength = 24
+
+type passwordMatch struct {
+	start, end int
+	bits       float64
+}
+
+// estimatePasswordEntropy returns log2 of the estimated guesses needed to
+// crack password, in the manner of zxcvbn: it finds cheap patterns
+// (dictionary words, sequences, repeats, keyboard runs, years) and takes
+// the cheapest cover of the password by patterns and brute-forced
+// characters. dictionary maps lowercase words to their frequency rank.
+func estimatePasswordEntropy(password string, dictionary map[string]int) float64 {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+
+	matches := make([]passwordMatch, 0)
+	matches = append(matches, dictionaryMatches(runes, dictionary)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, keyboardMatches(runes)...)
+	matches = append(matches, yearMatches(runes)...)
+
+	// best[i] is the cheapest cover of runes[:i], in bits.
+	best := make([]float64, len(runes)+1)
+	for end := 1; end <= len(runes); end++ {
+		best[end] = best[end-1] + math.Log2(float64(bruteforceCardinality(runes[end-1])))
+		for _, match := range matches {
+			if match.end == end && best[match.start]+match.bits < best[end] {
+				best[end] = best[match.start] + match.bits
+			}
+		}
+	}
+	return best[len(runes)]
+}
+
+func dictionaryMatches(runes []rune, dictionary map[string]int) []passwordMatch {
+	if len(dictionary) == 0 {
+		return nil
+	}
+	lower := []rune(strings.ToLower(string(runes)))
+	unleet := make([]rune, len(lower))
+	for i, ch := range lower {
+		if plain, ok := passwordLeetSubstitutions[ch]; ok {
+			unleet[i] = plain
+		} else {
+			unleet[i] = ch
+		}
+	}
+
+	matches := make([]passwordMatch, 0)
+	for start := range lower {
+		for end := start + 3; end <= len(lower) && end-start <= passwordMaxWordLength; end++ {
+			extra := 0.0
+			if hasUpper(runes[start:end]) {
+				extra++
+			}
+			word := string(lower[start:end])
+			if rank, ok := dictionary[word]; ok {
+				matches = append(matches, passwordMatch{start, end, math.Log2(float64(rank)) + extra})
+			}
+			if rank, ok := dictionary[reverseString(word)]; ok {
+				matches = append(matches, passwordMatch{start, end, math.Log2(float64(rank)) + extra + 1})
+			}
+			if plain := string(unleet[start:end]); plain != word {
+				if rank, ok := dictionary[plain]; ok {
+					matches = append(matches, passwordMatch{start, end, math.Log2(float64(rank)) + extra + 1})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// sequenceMatches finds runs like "abc", "789", or "zyx".
+func sequenceMatches(runes []rune) []passwordMatch {
+	matches := make([]passwordMatch, 0)
+	for start := 0; start < len(runes); start++ {
+		for end := start + 3; end <= len(runes); end++ {
+			delta := runes[start+1] - runes[start]
+			if delta != 1 && delta != -1 {
+				break
+			}
+			if runes[end-1]-runes[end-2] != delta {
+				break
+			}
+			base := 26.0
+			switch first := unicode.ToLower(runes[start]); {
+			case strings.ContainsRune("az019", first):
+				base = 4
+			case unicode.IsDigit(first):
+				base = 10
+			}
+			bits := math.Log2(base * float64(end-start))
+			if delta < 0 {
+				bits++
+			}
+			matches = append(matches, passwordMatch{start, end, bits})
+		}
+	}
+	return matches
+}
+
+// repeatMatches finds runs of one character like "aaaa".
+func repeatMatches(runes []rune) []passwordMatch {
+	matches := make([]passwordMatch, 0)
+	for start := 0; start < len(runes); start++ {
+		for end := start + 3; end <= len(runes) && runes[end-1] == runes[start] && runes[end-2] == runes[start]; end++ {
+			bits := math.Log2(float64(bruteforceCardinality(runes[start]) * (end - start)))
+			matches = append(matches, passwordMatch{start, end, bits})
+		}
+	}
+	return matches
+}
+
+// keyboardMatches finds adjacent-key runs like "qwer" or "1qaz2wsx".
+func keyboardMatches(runes []rune) []passwordMatch {
+	lower := strings.ToLower(string(runes))
+	lowerRunes := []rune(lower)
+	matches := make([]passwordMatch, 0)
+	for start := range lowerRunes {
+		for end := start + 4; end <= len(lowerRunes); end++ {
+			chunk := string(lowerRunes[start:end])
+			for _, row := range passwordKeyboardRows {
+				forward := strings.Contains(row, chunk)
+				if forward || strings.Contains(row, reverseString(chunk)) {
+					bits := math.Log2(float64(len(passwordKeyboardRows)*2) * float64(end-start))
+					if !forward {
+						bits++
+					}
+					matches = append(matches, passwordMatch{start, end, bits})
+					break
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// yearMatches finds 1900-2099, which people append to words.
+func yearMatches(runes []rune) []passwordMatch {
+	matches := make([]passwordMatch, 0)
+	for start := 0; start+4 <= len(runes); start++ {
+		year := string(runes[start : start+4])
+		if (strings.HasPrefix(year, "19") || strings.HasPrefix(year, "20")) && isDigits(year) {
+			matches = append(matches, passwordMatch{start, start + 4, math.Log2(200)})
+		}
+	}
+	return matches
+}
+
+func bruteforceCardinality(ch rune) int {
+	switch {
+	case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z':
+		return 26
+	case ch >= '0' && ch <= '9':
+		return 10
+	case ch < unicode.MaxASCII:
+		return 33
+	default:
+		return 100
+	}
+}
+
+func hasUpper(runes []rune) bool {
+	for _, ch := range runes {
+		if unicode.IsUpper(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(value string) bool {
+	for _, ch := range value {
+		if ch < '0' || ch > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+func reverseString(value string) string {
+	runes := []rune(value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	defaultPhotoBackfillJob       = "photo_backfill_v1"
+	defaultPhotoBackfillBatchSize = 1000
+	maxPhotoBackfillBatchSize     = 10000
+)
+
+// PhotoBackfillRepository reads legacy photo columns and inserts the
+// public.photo rows derived from them.
+type PhotoBackfillRepository interface {
+	// ListLegacyPhotoPosts returns up to limit posts with id > afterPostID
+	// that have any legacy photo column set, ordered by id.
+	ListLegacyPhotoPosts(ctx context.Context, afterPostID int64, limit int) ([]domain.LegacyPhotoPost, error)
+	// InsertBackfilledPhotos inserts rows that do not conflict with an
+	// existing (post_id, s3_key) or (post_id, position) and returns how many
+	// were inserted.
+	InsertBackfilledPhotos(ctx context.Context, photos []domain.PostCreateSavedPhoto) (int, error)
+}
+
+// PhotoBackfillCheckpointStore persists backfill progress between runs.
+type PhotoBackfillCheckpointStore interface {
+	LoadPhotoBackfillCheckpoint(job string) (domain.PhotoBackfillCheckpoint, error)
+	SavePhotoBackfillCheckpoint(checkpoint domain.PhotoBackfillCheckpoint) error
+}
+
+// PhotoObjectChecker reports whether a storage object exists.
+type PhotoObjectChecker interface {
+	PhotoObjectExists(ctx context.Context, key string) (bool, error)
+}
+
+// PhotoBackfillService ports supabase/scripts/backfill_photo_incremental.sql:
+// it derives public.photo rows from legacy post columns in id-ordered
+// batches and checkpoints after each batch so runs can resume.
+type PhotoBackfillService struct {
+	repo        PhotoBackfillRepository
+	checkpoints PhotoBackfillCheckpointStore
+	checker     PhotoObjectChecker
+	progress    func(domain.PhotoBackfillBatch)
+	now         func() time.Time
+}
+
+// NewPhotoBackfillService constructs PhotoBackfillService.
+func NewPhotoBackfillService(repo PhotoBackfillRepository, checkpoints PhotoBackfillCheckpointStore) *PhotoBackfillService {
+	return &PhotoBackfillService{repo: repo, checkpoints: checkpoints, now: time.Now}
+}
+
+// WithObjectChecker sets the storage used when opts.Verify is set.
+func (s *PhotoBackfillService) WithObjectChecker(checker PhotoObjectChecker) *PhotoBackfillService {
+	s.checker = checker
+	return s
+}
+
+// WithProgress registers a callback invoked after every batch.
+func (s *PhotoBackfillService) WithProgress(progress func(domain.PhotoBackfillBatch)) *PhotoBackfillService {
+	s.progress = progress
+	return s
+}
+
+// Run processes batches from the job's checkpoint until no legacy posts
+// remain or opts.MaxBatches is reached. Posts that already have photo rows
+// are skipped rather than rewritten, so re-running a batch never duplicates
+// or clobbers rows. With opts.DryRun nothing is inserted and the checkpoint
+// is left untouched.
+func (s *PhotoBackfillService) Run(ctx context.Context, opts domain.PhotoBackfillOptions) (domain.PhotoBackfillReport, error) {
+	job := strings.TrimSpace(opts.Job)
+	if job == "" {
+		job = defaultPhotoBackfillJob
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPhotoBackfillBatchSize
+	}
+	if batchSize > maxPhotoBackfillBatchSize {
+		return domain.PhotoBackfillReport{}, fmt.Errorf("batch size must be at most %d", maxPhotoBackfillBatchSize)
+	}
+	if opts.Verify && s.checker == nil {
+		return domain.PhotoBackfillReport{}, fmt.Errorf("verifying photo objects requires photo storage")
+	}
+
+	checkpoint, err := s.checkpoints.LoadPhotoBackfillCheckpoint(job)
+	if err != nil {
+		return domain.PhotoBackfillReport{}, fmt.Errorf("loading checkpoint %q: %w", job, err)
+	}
+	checkpoint.Job = job
+
+	report := domain.PhotoBackfillReport{
+		Job:            job,
+		DryRun:         opts.DryRun,
+		Verified:       opts.Verify,
+		MissingObjects: make([]string, 0),
+		Checkpoint:     checkpoint,
+	}
+	cursor := checkpoint.LastPostID
+	for opts.MaxBatches <= 0 || report.Batches < opts.MaxBatches {
+		posts, err := s.repo.ListLegacyPhotoPosts(ctx, cursor, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("listing legacy photo posts after %d: %w", cursor, err)
+		}
+		if len(posts) == 0 {
+			report.Done = true
+			break
+		}
+
+		batch := domain.PhotoBackfillBatch{
+			Batch:         report.Batches + 1,
+			FirstPostID:   posts[0].PostID,
+			LastPostID:    posts[len(posts)-1].PostID,
+			SelectedPosts: len(posts),
+		}
+		rows := make([]domain.PostCreateSavedPhoto, 0, len(posts))
+		for _, post := range posts {
+			if post.PhotoRowCount > 0 {
+				batch.AlreadyBackfilled++
+				continue
+			}
+			for _, photo := range legacyPhotoRows(post) {
+				if opts.Verify {
+					missing, err := s.missingPhotoObjects(ctx, photo)
+					if err != nil {
+						return report, err
+					}
+					if len(missing) > 0 {
+						batch.MissingObjects += len(missing)
+						report.MissingObjects = append(report.MissingObjects, missing...)
+						continue
+					}
+				}
+				rows = append(rows, photo)
+			}
+		}
+		batch.PreparedPhotos = len(rows)
+
+		if !opts.DryRun {
+			inserted, err := s.repo.InsertBackfilledPhotos(ctx, rows)
+			if err != nil {
+				return report, fmt.Errorf("inserting photos for posts %d..%d: %w", batch.FirstPostID, batch.LastPostID, err)
+			}
+			batch.InsertedPhotos = inserted
+
+			checkpoint.LastPostID = batch.LastPostID
+			checkpoint.ProcessedPosts += int64(batch.SelectedPosts)
+			checkpoint.InsertedPhotos += int64(inserted)
+			checkpoint.UpdatedAt = s.now()
+			if err := s.checkpoints.SavePhotoBackfillCheckpoint(checkpoint); err != nil {
+				return report, fmt.Errorf("saving checkpoint %q: %w", job, err)
+			}
+			report.Checkpoint = checkpoint
+		}
+		cursor = batch.LastPostID
+
+		report.Batches++
+		report.SelectedPosts += batch.SelectedPosts
+		report.AlreadyBackfilled += batch.AlreadyBackfilled
+		report.PreparedPhotos += batch.PreparedPhotos
+		report.InsertedPhotos += batch.InsertedPhotos
+		if s.progress != nil {
+			s.progress(batch)
+		}
+	}
+	return report, nil
+}
+
+func (s *PhotoBackfillService) missingPhotoObjects(ctx context.Context, photo domain.PostCreateSavedPhoto) ([]string, error) {
+	missing := make([]string, 0)
+	for _, key := range []string{photo.S3Key, photo.TickerS3Key} {
+		exists, err := s.checker.PhotoObjectExists(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("checking photo object %q: %w", key, err)
+		}
+		if !exists {
+			missing = append(missing, key)
+		}
+	}
+	return missing, nil
+}
+
+// legacyPhotoRows derives photo rows the way the SQL backfill did: slot i
+// prefers image_source{i+1} over photo{i+1}_file_name, the base name is
+// normalized to post_*, keys live under posts/{id}/, duplicates keep their
+// lowest position, and the ticker key swaps post_ for ticker_.
+func legacyPhotoRows(post domain.LegacyPhotoPost) []domain.PostCreateSavedPhoto {
+	rows := make([]domain.PostCreateSavedPhoto, 0, len(post.ImageSources))
+	seen := make(map[string]bool, len(post.ImageSources))
+	for position := range post.ImageSources {
+		raw := strings.TrimSpace(post.ImageSources[position])
+		if raw == "" {
+			raw = strings.TrimSpace(post.PhotoFileNames[position])
+		}
+		base := raw[strings.LastIndex(raw, "/")+1:]
+		if base == "" {
+			continue
+		}
+
+		name := base
+		switch {
+		case strings.HasPrefix(base, "post_"):
+		case strings.HasPrefix(base, "ticker_"):
+			name = "post_" + strings.TrimPrefix(base, "ticker_")
+		default:
+			name = "post_" + base
+		}
+		key := fmt.Sprintf("posts/%d/%s", post.PostID, name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rows = append(rows, domain.PostCreateSavedPhoto{
+			PostID:      post.PostID,
+			S3Key:       key,
+			TickerS3Key: strings.Replace(key, "/post_", "/ticker_", 1),
+			Position:    position,
+		})
+	}
+	return rows
+}
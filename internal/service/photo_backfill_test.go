@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+)
+
+type memoryBackfillCheckpoints struct {
+	saved map[string]domain.PhotoBackfillCheckpoint
+	saves int
+}
+
+func (m *memoryBackfillCheckpoints) LoadPhotoBackfillCheckpoint(job string) (domain.PhotoBackfillCheckpoint, error) {
+	return m.saved[job], nil
+}
+
+func (m *memoryBackfillCheckpoints) SavePhotoBackfillCheckpoint(checkpoint domain.PhotoBackfillCheckpoint) error {
+	if m.saved == nil {
+		m.saved = make(map[string]domain.PhotoBackfillCheckpoint)
+	}
+	m.saved[checkpoint.Job] = checkpoint
+	m.saves++
+	return nil
+}
+
+type fakePhotoObjectChecker struct {
+	missing map[string]bool
+	checked []string
+}
+
+func (c *fakePhotoObjectChecker) PhotoObjectExists(_ context.Context, key string) (bool, error) {
+	c.checked = append(c.checked, key)
+	return !c.missing[key], nil
+}
+
+func inMemoryPhotoKeys(t *testing.T, repo *repository.InMemory, postID int64) string {
+	t.Helper()
+	photos, err := repo.ListPostPhotos(context.Background(), postID)
+	if err != nil {
+		t.Fatalf("listing photos: %v", err)
+	}
+	keys := make([]string, 0, len(photos))
+	for _, photo := range photos {
+		keys = append(keys, photo.S3Key+"|"+photo.TickerS3Key)
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestLegacyPhotoRows_NormalizesLikeTheSQLBackfill(t *testing.T) {
+	rows := legacyPhotoRows(domain.LegacyPhotoPost{
+		PostID:         42,
+		ImageSources:   [4]string{" https://cdn.example/posts/42/post_42a.jpg ", "", "", "ticker_42a.jpg"},
+		PhotoFileNames: [4]string{"ignored.jpg", "42b.jpg", "  ", ""},
+	})
+
+	want := []domain.PostCreateSavedPhoto{
+		{PostID: 42, S3Key: "posts/42/post_42a.jpg", TickerS3Key: "posts/42/ticker_42a.jpg", Position: 0},
+		{PostID: 42, S3Key: "posts/42/post_42b.jpg", TickerS3Key: "posts/42/ticker_42b.jpg", Position: 1},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows (slot 3 duplicates slot 0), got %+v", len(want), rows)
+	}
+	for idx := range want {
+		if rows[idx] != want[idx] {
+			t.Fatalf("row %d: expected %+v, got %+v", idx, want[idx], rows[idx])
+		}
+	}
+}
+
+func TestPhotoBackfillService_InMemoryResumesAndIsIdempotent(t *testing.T) {
+	repo := repository.NewInMemory()
+	checkpoints := &memoryBackfillCheckpoints{}
+	var progress []domain.PhotoBackfillBatch
+	svc := NewPhotoBackfillService(repo, checkpoints).
+		WithProgress(func(batch domain.PhotoBackfillBatch) { progress = append(progress, batch) })
+	ctx := context.Background()
+
+	first, err := svc.Run(ctx, domain.PhotoBackfillOptions{Job: "test", BatchSize: 1, MaxBatches: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Done || first.InsertedPhotos != 1 || first.Checkpoint.LastPostID != 130031899 {
+		t.Fatalf("expected one batch for the lowest legacy post, got %+v", first)
+	}
+
+	rest, err := svc.Run(ctx, domain.PhotoBackfillOptions{Job: "test", BatchSize: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !rest.Done || rest.SelectedPosts != 1 || rest.InsertedPhotos != 2 {
+		t.Fatalf("expected the resumed run to finish the remaining post, got %+v", rest)
+	}
+	if rest.Checkpoint.ProcessedPosts != 2 || rest.Checkpoint.InsertedPhotos != 3 || checkpoints.saves != 2 {
+		t.Fatalf("unexpected checkpoint %+v after %d saves", rest.Checkpoint, checkpoints.saves)
+	}
+	if len(progress) != 2 || progress[1].FirstPostID != 130031900 {
+		t.Fatalf("expected one progress report per batch, got %+v", progress)
+	}
+	want := "posts/130031900/post_130031900a.jpg|posts/130031900/ticker_130031900a.jpg,posts/130031900/post_130031900b.jpg|posts/130031900/ticker_130031900b.jpg"
+	if got := inMemoryPhotoKeys(t, repo, 130031900); got != want {
+		t.Fatalf("unexpected photo rows %s", got)
+	}
+
+	caughtUp, err := svc.Run(ctx, domain.PhotoBackfillOptions{Job: "test"})
+	if err != nil || !caughtUp.Done || caughtUp.Batches != 0 {
+		t.Fatalf("expected a caught-up job to do nothing, got %+v, %v", caughtUp, err)
+	}
+
+	// A fresh job re-reads every post but must not add rows a second time.
+	again, err := svc.Run(ctx, domain.PhotoBackfillOptions{Job: "fresh"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.SelectedPosts != 2 || again.AlreadyBackfilled != 2 || again.InsertedPhotos != 0 {
+		t.Fatalf("expected a re-run to skip backfilled posts, got %+v", again)
+	}
+	if got := inMemoryPhotoKeys(t, repo, 130031900); got != want {
+		t.Fatalf("expected photo rows unchanged by a re-run, got %s", got)
+	}
+}
+
+func TestPhotoBackfillService_VerifySkipsMissingObjects(t *testing.T) {
+	repo := repository.NewInMemory()
+	checker := &fakePhotoObjectChecker{missing: map[string]bool{"posts/130031900/ticker_130031900b.jpg": true}}
+	svc := NewPhotoBackfillService(repo, &memoryBackfillCheckpoints{}).WithObjectChecker(checker)
+
+	report, err := svc.Run(context.Background(), domain.PhotoBackfillOptions{Verify: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.InsertedPhotos != 2 || len(report.MissingObjects) != 1 || report.MissingObjects[0] != "posts/130031900/ticker_130031900b.jpg" {
+		t.Fatalf("expected the photo with a missing ticker skipped, got %+v", report)
+	}
+	if len(checker.checked) != 6 {
+		t.Fatalf("expected post and ticker keys checked for 3 photos, got %v", checker.checked)
+	}
+	if got := inMemoryPhotoKeys(t, repo, 130031900); strings.Contains(got, "130031900b") {
+		t.Fatalf("expected no row for the unverified photo, got %s", got)
+	}
+
+	if _, err := NewPhotoBackfillService(repo, &memoryBackfillCheckpoints{}).Run(context.Background(), domain.PhotoBackfillOptions{Verify: true}); err == nil {
+		t.Fatalf("expected verify without photo storage to fail")
+	}
+}
+
+func TestPhotoBackfillService_DryRunWritesNothing(t *testing.T) {
+	repo := repository.NewInMemory()
+	checkpoints := &memoryBackfillCheckpoints{}
+
+	report, err := NewPhotoBackfillService(repo, checkpoints).Run(context.Background(), domain.PhotoBackfillOptions{DryRun: true, BatchSize: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Done || report.Batches != 2 || report.PreparedPhotos != 3 || report.InsertedPhotos != 0 {
+		t.Fatalf("expected a dry run to walk every batch without inserting, got %+v", report)
+	}
+	if checkpoints.saves != 0 || inMemoryPhotoKeys(t, repo, 130031900) != "" {
+		t.Fatalf("expected no rows or checkpoint written")
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// heifBrands are ISO-BMFF ftyp brands written by HEIC/HEIF encoders (iPhone
+// camera default). mif1/msf1 are generic and resolved via compatible brands.
+var heifBrands = map[string]struct{}{
+	"heic": {},
+	"heix": {},
+	"hevc": {},
+	"hevx": {},
+	"heim": {},
+	"heis": {},
+}
+
+var avifBrands = map[string]struct{}{
+	"avif": {},
+	"avis": {},
+}
+
+// detectPhotoFormat identifies an upload by its magic bytes: jpeg, png, gif,
+// webp, HEIC, AVIF, or "" when unrecognized.
+func detectPhotoFormat(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xFF, 0xD8, 0xFF}):
+		return "jpeg"
+	case bytes.HasPrefix(content, []byte("\x89PNG\r\n\x1a\n")):
+		return "png"
+	case bytes.HasPrefix(content, []byte("GIF87a")), bytes.HasPrefix(content, []byte("GIF89a")):
+		return "gif"
+	case len(content) >= 12 && bytes.Equal(content[:4], []byte("RIFF")) && bytes.Equal(content[8:12], []byte("WEBP")):
+		return "webp"
+	}
+	return isoBMFFImageFormat(content)
+}
+
+// unsupportedPhotoFormat names a photo format the upload pipeline cannot
+// decode ("HEIC" or "AVIF"), or returns "" when the photo may proceed. The
+// content is checked first; the declared content type and file extension
+// only name the format when the bytes are not a recognizable container.
+func unsupportedPhotoFormat(photo domain.PostCreatePhotoUpload) string {
+	format := detectPhotoFormat(photo.Content)
+	if format == "HEIC" || format == "AVIF" {
+		return format
+	}
+	if format != "" {
+		return ""
+	}
+	switch strings.ToLower(strings.TrimSpace(photo.ContentType)) {
+	case "image/heic", "image/heif", "image/heic-sequence", "image/heif-sequence":
+		return "HEIC"
+	case "image/avif":
+		return "AVIF"
+	}
+	switch strings.ToLower(filepath.Ext(strings.TrimSpace(photo.FileName))) {
+	case ".heic", ".heif":
+		return "HEIC"
+	case ".avif":
+		return "AVIF"
+	}
+	return ""
+}
+
+// isoBMFFImageFormat inspects the leading ftyp box for HEIF/AVIF brands.
+func isoBMFFImageFormat(content []byte) string {
+	if len(content) < 16 || !bytes.Equal(content[4:8], []byte("ftyp")) {
+		return ""
+	}
+	boxSize := int(content[0])<<24 | int(content[1])<<16 | int(content[2])<<8 | int(content[3])
+	if boxSize < 16 || boxSize > len(content) {
+		boxSize = len(content)
+	}
+	brands := []string{string(content[8:12])}
+	for offset := 16; offset+4 <= boxSize; offset += 4 {
+		brands = append(brands, string(content[offset:offset+4]))
+	}
+
+	generic := false
+	for _, brand := range brands {
+		if _, ok := avifBrands[brand]; ok {
+			return "AVIF"
+		}
+	}
+	for _, brand := range brands {
+		if _, ok := heifBrands[brand]; ok {
+			return "HEIC"
+		}
+		if brand == "mif1" || brand == "msf1" {
+			generic = true
+		}
+	}
+	if generic {
+		return "HEIC"
+	}
+	return ""
+}
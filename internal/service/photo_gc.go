@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+const (
+	defaultPhotoGCGracePeriod = 24 * time.Hour
+	defaultPhotoGCPageSize    = 1000
+)
+
+// PhotoObjectStore lists and deletes raw objects under the photo key prefix.
+type PhotoObjectStore interface {
+	PhotoKeyPrefix() string
+	ListPhotoObjects(ctx context.Context, pageToken string, pageSize int) (domain.PhotoObjectPage, error)
+	DeletePhotoObject(ctx context.Context, key string) error
+}
+
+// PhotoReferenceRepository reports which storage keys public.photo still
+// references as s3_key or ticker_s3_key.
+type PhotoReferenceRepository interface {
+	ListReferencedPhotoKeys(ctx context.Context, keys []string) (map[string]bool, error)
+}
+
+// PhotoGCService finds photo objects no photo row references.
+type PhotoGCService struct {
+	repo  PhotoReferenceRepository
+	store PhotoObjectStore
+	now   func() time.Time
+}
+
+// NewPhotoGCService constructs PhotoGCService.
+func NewPhotoGCService(repo PhotoReferenceRepository, store PhotoObjectStore) *PhotoGCService {
+	return &PhotoGCService{repo: repo, store: store, now: time.Now}
+}
+
+// Run walks the store page by page, looks up each page's keys against the
+// photo table, and reports unreferenced objects older than the grace
+// period. Unless opts.DryRun is set, those orphans are deleted as each page
+// is processed; individual delete failures are reported, not fatal.
+func (s *PhotoGCService) Run(ctx context.Context, opts domain.PhotoGCOptions) (domain.PhotoGCReport, error) {
+	grace := opts.GracePeriod
+	if grace == 0 {
+		grace = defaultPhotoGCGracePeriod
+	}
+	if grace < 0 {
+		return domain.PhotoGCReport{}, fmt.Errorf("grace period must not be negative")
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPhotoGCPageSize
+	}
+
+	started := s.now()
+	cutoff := started.Add(-grace)
+	report := domain.PhotoGCReport{
+		DryRun:      opts.DryRun,
+		Prefix:      s.store.PhotoKeyPrefix(),
+		GracePeriod: grace,
+		Orphans:     make([]domain.PhotoObject, 0),
+		StartedAt:   started,
+	}
+
+	pageToken := ""
+	for {
+		page, err := s.store.ListPhotoObjects(ctx, pageToken, pageSize)
+		if err != nil {
+			return domain.PhotoGCReport{}, fmt.Errorf("listing photo objects (page %d): %w", report.Pages+1, err)
+		}
+		report.Pages++
+		report.Scanned += len(page.Objects)
+
+		keys := make([]string, 0, len(page.Objects))
+		for _, object := range page.Objects {
+			keys = append(keys, object.Key)
+		}
+		referenced, err := s.repo.ListReferencedPhotoKeys(ctx, keys)
+		if err != nil {
+			return domain.PhotoGCReport{}, fmt.Errorf("looking up photo references: %w", err)
+		}
+
+		for _, object := range page.Objects {
+			switch {
+			case referenced[object.Key]:
+				report.Referenced++
+			case object.LastModified.After(cutoff):
+				// Uploads land before their photo row is written.
+				report.TooRecent++
+			default:
+				report.Orphans = append(report.Orphans, object)
+				report.OrphanBytes += object.Size
+				if opts.DryRun {
+					continue
+				}
+				if err := s.store.DeletePhotoObject(ctx, object.Key); err != nil {
+					report.DeleteErrors = append(report.DeleteErrors, fmt.Sprintf("%s: %v", object.Key, err))
+					continue
+				}
+				report.Deleted++
+			}
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		pageToken = page.NextPageToken
+	}
+
+	report.FinishedAt = s.now()
+	return report, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// fakePhotoObjectStore pages through objects like S3, tolerating deletes
+// between pages.
+type fakePhotoObjectStore struct {
+	objects   []domain.PhotoObject
+	deleteErr map[string]error
+	deleted   []string
+	pageSizes []int
+}
+
+func (s *fakePhotoObjectStore) PhotoKeyPrefix() string { return "v2/posts" }
+
+func (s *fakePhotoObjectStore) ListPhotoObjects(_ context.Context, pageToken string, pageSize int) (domain.PhotoObjectPage, error) {
+	s.pageSizes = append(s.pageSizes, pageSize)
+	start := 0
+	if pageToken != "" {
+		start, _ = strconv.Atoi(pageToken)
+	}
+	end := min(start+pageSize, len(s.objects))
+	page := domain.PhotoObjectPage{Objects: append([]domain.PhotoObject(nil), s.objects[start:end]...)}
+	if end < len(s.objects) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func (s *fakePhotoObjectStore) DeletePhotoObject(_ context.Context, key string) error {
+	if err := s.deleteErr[key]; err != nil {
+		return err
+	}
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+type fakePhotoReferenceRepo struct {
+	referenced map[string]bool
+	lookups    int
+}
+
+func (r *fakePhotoReferenceRepo) ListReferencedPhotoKeys(_ context.Context, keys []string) (map[string]bool, error) {
+	r.lookups++
+	found := make(map[string]bool)
+	for _, key := range keys {
+		if r.referenced[key] {
+			found[key] = true
+		}
+	}
+	return found, nil
+}
+
+func photoGCFixture() (*fakePhotoReferenceRepo, *fakePhotoObjectStore, time.Time) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-72 * time.Hour)
+	store := &fakePhotoObjectStore{objects: []domain.PhotoObject{
+		{Key: "v2/posts/1/a.jpg", Size: 100, LastModified: old},
+		{Key: "v2/posts/1/ticker_a.jpg", Size: 10, LastModified: old},
+		{Key: "v2/posts/2/orphan.jpg", Size: 300, LastModified: old},
+		{Key: "v2/posts/3/uploading.jpg", Size: 400, LastModified: now.Add(-time.Hour)},
+		{Key: "v2/posts/4/orphan.jpg", Size: 500, LastModified: old},
+	}}
+	repo := &fakePhotoReferenceRepo{referenced: map[string]bool{"v2/posts/1/a.jpg": true, "v2/posts/1/ticker_a.jpg": true}}
+	return repo, store, now
+}
+
+func orphanKeys(report domain.PhotoGCReport) string {
+	keys := make([]string, 0, len(report.Orphans))
+	for _, object := range report.Orphans {
+		keys = append(keys, object.Key)
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestPhotoGCService_DryRunReportsOrphansAcrossPages(t *testing.T) {
+	repo, store, now := photoGCFixture()
+	svc := NewPhotoGCService(repo, store)
+	svc.now = func() time.Time { return now }
+
+	report, err := svc.Run(context.Background(), domain.PhotoGCOptions{DryRun: true, PageSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := orphanKeys(report); got != "v2/posts/2/orphan.jpg,v2/posts/4/orphan.jpg" {
+		t.Fatalf("unexpected orphans %s", got)
+	}
+	if report.Pages != 3 || repo.lookups != 3 || report.Scanned != 5 || report.Referenced != 2 || report.TooRecent != 1 {
+		t.Fatalf("unexpected counts %+v", report)
+	}
+	if report.OrphanBytes != 800 || report.Deleted != 0 || len(store.deleted) != 0 {
+		t.Fatalf("expected a dry run to delete nothing, got %+v deleted %v", report, store.deleted)
+	}
+	if report.GracePeriod != defaultPhotoGCGracePeriod || report.Prefix != "v2/posts" {
+		t.Fatalf("expected default grace and store prefix, got %+v", report)
+	}
+}
+
+func TestPhotoGCService_DeletesOrphansAndCollectsFailures(t *testing.T) {
+	repo, store, now := photoGCFixture()
+	store.deleteErr = map[string]error{"v2/posts/4/orphan.jpg": errors.New("access denied")}
+	svc := NewPhotoGCService(repo, store)
+	svc.now = func() time.Time { return now }
+
+	report, err := svc.Run(context.Background(), domain.PhotoGCOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "v2/posts/2/orphan.jpg" || report.Deleted != 1 {
+		t.Fatalf("expected the deletable orphan removed, got %v (%+v)", store.deleted, report)
+	}
+	if len(report.DeleteErrors) != 1 || !strings.Contains(report.DeleteErrors[0], "v2/posts/4/orphan.jpg: access denied") {
+		t.Fatalf("expected the failed delete reported, got %v", report.DeleteErrors)
+	}
+}
+
+func TestPhotoGCService_GracePeriodAndPageSizeOptions(t *testing.T) {
+	repo, store, now := photoGCFixture()
+	svc := NewPhotoGCService(repo, store)
+	svc.now = func() time.Time { return now }
+
+	report, err := svc.Run(context.Background(), domain.PhotoGCOptions{DryRun: true, GracePeriod: 30 * time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TooRecent != 0 || len(report.Orphans) != 3 || report.Pages != 1 || store.pageSizes[0] != defaultPhotoGCPageSize {
+		t.Fatalf("expected a short grace to include the recent upload in one default page, got %+v", report)
+	}
+
+	if _, err := svc.Run(context.Background(), domain.PhotoGCOptions{GracePeriod: -time.Hour}); err == nil {
+		t.Fatalf("expected a negative grace period to be rejected")
+	}
+}
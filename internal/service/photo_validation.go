@@ -0,0 +1,280 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // registers GIF for photo validation
+	_ "image/jpeg" // registers JPEG for photo validation
+	_ "image/png"  // registers PNG for photo validation
+	"math/bits"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	_ "golang.org/x/image/webp" // registers WebP for photo validation
+)
+
+const (
+	defaultPhotoMaxBytes        = 10 << 20
+	defaultPhotoMaxDimension    = 12000
+	defaultPhotoMaxPixels       = 40_000_000 // about 160 MB decoded; 12000x12000 would be 576 MB
+	defaultPhotoDuplicateWindow = 30 * 24 * time.Hour
+	// duplicatePhotoMaxDistance is the largest dHash Hamming distance treated
+	// as the same picture (re-encoded, resized, or lightly recompressed).
+	duplicatePhotoMaxDistance = 6
+)
+
+var defaultPhotoAllowedFormats = []string{"jpeg", "png", "gif", "webp"}
+
+var photoFormatLabels = map[string]string{
+	"jpeg": "JPEG",
+	"png":  "PNG",
+	"gif":  "GIF",
+	"webp": "WebP",
+}
+
+// PhotoFingerprintRepository looks up perceptual hashes of a poster's
+// recently uploaded photos.
+type PhotoFingerprintRepository interface {
+	ListRecentPhotoFingerprints(ctx context.Context, email string, since time.Time) ([]domain.PhotoFingerprint, error)
+}
+
+// WithPhotoLimits overrides the submit-time photo validation limits.
+func (s *PostCreateService) WithPhotoLimits(limits domain.PhotoLimits) *PostCreateService {
+	s.photoLimits = limits
+	return s
+}
+
+func resolvePhotoLimits(limits domain.PhotoLimits) domain.PhotoLimits {
+	if limits.MaxBytes <= 0 {
+		limits.MaxBytes = defaultPhotoMaxBytes
+	}
+	// MinWidth and MinHeight have no default: small photos are accepted
+	// unless a minimum is configured.
+	if limits.MaxWidth <= 0 {
+		limits.MaxWidth = defaultPhotoMaxDimension
+	}
+	if limits.MaxHeight <= 0 {
+		limits.MaxHeight = defaultPhotoMaxDimension
+	}
+	if limits.MaxPixels <= 0 {
+		limits.MaxPixels = defaultPhotoMaxPixels
+	}
+	allowed := make([]string, 0, len(limits.AllowedFormats))
+	for _, format := range limits.AllowedFormats {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "jpg" {
+			format = "jpeg"
+		}
+		if format != "" {
+			allowed = append(allowed, format)
+		}
+	}
+	if len(allowed) == 0 {
+		allowed = defaultPhotoAllowedFormats
+	}
+	limits.AllowedFormats = allowed
+	if limits.DuplicateWindow == 0 {
+		limits.DuplicateWindow = defaultPhotoDuplicateWindow
+	}
+	return limits
+}
+
+// validatePostCreatePhotos checks each photo's format, size, and dimensions,
+// stores a perceptual hash on every photo that passes, and reports duplicates
+// within the submission and against the poster's recent posts.
+func (s *PostCreateService) validatePostCreatePhotos(ctx context.Context, email string, photos []domain.PostCreatePhotoUpload) ([]string, error) {
+	return validatePhotoUploads(ctx, s.repo, s.photoLimits, email, nil, photos)
+}
+
+// validatePhotoUploads implements photo validation for new posts and for
+// photos added to an existing post. existing holds the fingerprints already
+// on that post: new photos are compared against them by position, and their
+// post is skipped in the recent-post check.
+func validatePhotoUploads(
+	ctx context.Context,
+	fingerprints PhotoFingerprintRepository,
+	limits domain.PhotoLimits,
+	email string,
+	existing []domain.PhotoFingerprint,
+	photos []domain.PostCreatePhotoUpload,
+) ([]string, error) {
+	limits = resolvePhotoLimits(limits)
+	problems := make([]string, 0, len(photos))
+	hashed := make([]int, 0, len(photos))
+
+	for idx := range photos {
+		photo := &photos[idx]
+		if len(photo.Content) == 0 {
+			problems = append(problems, fmt.Sprintf("Photo at position %d is empty.", photo.Position))
+			continue
+		}
+		if format := unsupportedPhotoFormat(*photo); format != "" {
+			problems = append(problems, fmt.Sprintf("Photo at position %d is %s, which is not supported. Upload a JPEG, PNG, GIF, or WebP (iPhone: Settings > Camera > Formats > Most Compatible).", photo.Position, format))
+			continue
+		}
+		if !photoFormatAllowed(detectPhotoFormat(photo.Content), limits.AllowedFormats) {
+			problems = append(problems, fmt.Sprintf("Photo at position %d is not an allowed image type (allowed: %s).", photo.Position, photoFormatList(limits.AllowedFormats)))
+			continue
+		}
+
+		photoProblems := 0
+		if size := int64(len(photo.Content)); size > limits.MaxBytes {
+			problems = append(problems, fmt.Sprintf("Photo at position %d is %s; the maximum is %s.", photo.Position, formatPhotoBytes(size), formatPhotoBytes(limits.MaxBytes)))
+			photoProblems++
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(photo.Content))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Photo at position %d could not be read as an image.", photo.Position))
+			continue
+		}
+		if cfg.Width < limits.MinWidth || cfg.Height < limits.MinHeight {
+			problems = append(problems, fmt.Sprintf("Photo at position %d is %dx%d pixels; the minimum is %dx%d.", photo.Position, cfg.Width, cfg.Height, limits.MinWidth, limits.MinHeight))
+			photoProblems++
+		}
+		if cfg.Width > limits.MaxWidth || cfg.Height > limits.MaxHeight {
+			problems = append(problems, fmt.Sprintf("Photo at position %d is %dx%d pixels; the maximum is %dx%d.", photo.Position, cfg.Width, cfg.Height, limits.MaxWidth, limits.MaxHeight))
+			photoProblems++
+		}
+		// Checked from the header alone, so an oversized image is never decoded.
+		if pixels := int64(cfg.Width) * int64(cfg.Height); pixels > limits.MaxPixels {
+			problems = append(problems, fmt.Sprintf("Photo at position %d is %d pixels (%dx%d); the maximum is %d.", photo.Position, pixels, cfg.Width, cfg.Height, limits.MaxPixels))
+			photoProblems++
+		}
+		if photoProblems > 0 {
+			continue
+		}
+
+		decoded, _, err := image.Decode(bytes.NewReader(photo.Content))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("Photo at position %d could not be read as an image.", photo.Position))
+			continue
+		}
+		photo.PerceptualHash = perceptualHash(decoded)
+		hashed = append(hashed, idx)
+	}
+
+	ownPosts := make(map[int64]struct{}, 1)
+	for _, fingerprint := range existing {
+		ownPosts[fingerprint.PostID] = struct{}{}
+	}
+	for i, idx := range hashed {
+		earlier := make([]domain.PhotoFingerprint, 0, len(existing)+i)
+		for _, fingerprint := range existing {
+			if fingerprint.PerceptualHash != 0 {
+				earlier = append(earlier, fingerprint)
+			}
+		}
+		for _, prev := range hashed[:i] {
+			earlier = append(earlier, domain.PhotoFingerprint{Position: photos[prev].Position, PerceptualHash: photos[prev].PerceptualHash})
+		}
+		for _, candidate := range earlier {
+			if photoHashesMatch(photos[idx].PerceptualHash, candidate.PerceptualHash) {
+				problems = append(problems, fmt.Sprintf("Photo at position %d looks like a duplicate of the photo at position %d.", photos[idx].Position, candidate.Position))
+				break
+			}
+		}
+	}
+
+	if len(hashed) == 0 || limits.DuplicateWindow < 0 || email == "" {
+		return problems, nil
+	}
+	recent, err := fingerprints.ListRecentPhotoFingerprints(ctx, email, time.Now().Add(-limits.DuplicateWindow))
+	if err != nil {
+		return nil, fmt.Errorf("listing recent photo fingerprints: %w", err)
+	}
+	for _, idx := range hashed {
+		for _, previous := range recent {
+			if _, own := ownPosts[previous.PostID]; own {
+				continue
+			}
+			if previous.PerceptualHash != 0 && photoHashesMatch(photos[idx].PerceptualHash, previous.PerceptualHash) {
+				problems = append(problems, fmt.Sprintf("Photo at position %d looks like a duplicate of a photo in your post %d from the last %d days.", photos[idx].Position, previous.PostID, int(limits.DuplicateWindow.Hours()/24)))
+				break
+			}
+		}
+	}
+	return problems, nil
+}
+
+func photoFormatAllowed(format string, allowed []string) bool {
+	for _, candidate := range allowed {
+		if candidate == format {
+			return true
+		}
+	}
+	return false
+}
+
+func photoFormatList(formats []string) string {
+	labels := make([]string, 0, len(formats))
+	for _, format := range formats {
+		if label, ok := photoFormatLabels[format]; ok {
+			labels = append(labels, label)
+		} else {
+			labels = append(labels, strings.ToUpper(format))
+		}
+	}
+	return strings.Join(labels, ", ")
+}
+
+func formatPhotoBytes(size int64) string {
+	const mb = 1 << 20
+	if size >= mb {
+		return fmt.Sprintf("%.1f MB", float64(size)/mb)
+	}
+	return fmt.Sprintf("%d KB", (size+1023)/1024)
+}
+
+func photoHashesMatch(a, b uint64) bool {
+	return bits.OnesCount64(a^b) <= duplicatePhotoMaxDistance
+}
+
+// perceptualHash returns a 64-bit difference hash: the image is reduced to a
+// 9x8 grayscale grid (sampling at most 8x8 points per cell, so large photos
+// stay cheap) and each bit records whether a cell is brighter than its right
+// neighbor. Resizing and recompression barely change it.
+func perceptualHash(img image.Image) uint64 {
+	const gridW, gridH = 9, 8
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+
+	var gray [gridH][gridW]float64
+	for gy := 0; gy < gridH; gy++ {
+		y0 := bounds.Min.Y + gy*h/gridH
+		y1 := max(bounds.Min.Y+(gy+1)*h/gridH, y0+1)
+		stepY := max((y1-y0)/8, 1)
+		for gx := 0; gx < gridW; gx++ {
+			x0 := bounds.Min.X + gx*w/gridW
+			x1 := max(bounds.Min.X+(gx+1)*w/gridW, x0+1)
+			stepX := max((x1-x0)/8, 1)
+
+			var sum float64
+			var n int
+			for y := y0; y < y1; y += stepY {
+				for x := x0; x < x1; x += stepX {
+					r, g, b, _ := img.At(x, y).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+					n++
+				}
+			}
+			gray[gy][gx] = sum / float64(n)
+		}
+	}
+
+	var hash uint64
+	for gy := 0; gy < gridH; gy++ {
+		for gx := 0; gx < gridW-1; gx++ {
+			hash <<= 1
+			if gray[gy][gx] > gray[gy][gx+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"math/bits"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+func photoSubmission(photos ...domain.PostCreatePhotoUpload) domain.PostCreateSubmission {
+	return domain.PostCreateSubmission{
+		CategoryID:    5,
+		SubcategoryID: 14,
+		Name:          "Red bike for sale",
+		Body:          "Pick up on campus.",
+		Email:         "wientjes@alumni.stanford.edu",
+		Price:         100,
+		PriceProvided: true,
+		Photos:        photos,
+	}
+}
+
+func photoSubmitRepo() *mockPostCreateSubmitRepo {
+	return &mockPostCreateSubmitRepo{
+		categories:    []domain.Category{{ID: 5, Name: "for sale/wanted", ShortName: "for sale"}},
+		subcategories: []domain.Subcategory{{ID: 14, CategoryID: 5, Name: "furniture"}},
+		persisted:     domain.PostCreatePersisted{PostID: 130031999, AccessToken: "abcdef", PostedAt: time.Now()},
+	}
+}
+
+func decodeTestPhoto(t *testing.T, content []byte) image.Image {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("decoding test photo: %v", err)
+	}
+	return img
+}
+
+func TestPerceptualHash_StableAcrossResizeAndRecompression(t *testing.T) {
+	original := perceptualHash(decodeTestPhoto(t, testPhoto(t, "png", 800, 600, 7)))
+	resized := perceptualHash(decodeTestPhoto(t, testPhoto(t, "jpeg", 400, 300, 7)))
+	different := perceptualHash(decodeTestPhoto(t, testPhoto(t, "jpeg", 800, 600, 8)))
+
+	if !photoHashesMatch(original, resized) {
+		t.Fatalf("expected resized copy to match, distance %d", bits.OnesCount64(original^resized))
+	}
+	if photoHashesMatch(original, different) {
+		t.Fatalf("expected different picture not to match, distance %d", bits.OnesCount64(original^different))
+	}
+}
+
+func TestPostCreateService_Submit_PhotoLimitsReportEveryProblem(t *testing.T) {
+	var gifBuf bytes.Buffer
+	palette := image.NewPaletted(image.Rect(0, 0, 300, 300), []color.Color{color.Black, color.White})
+	if err := gif.Encode(&gifBuf, palette, nil); err != nil {
+		t.Fatalf("encoding gif: %v", err)
+	}
+	big := testPhoto(t, "png", 900, 300, 3)
+
+	svc := NewPostCreateService(photoSubmitRepo()).WithPhotoLimits(domain.PhotoLimits{
+		MaxBytes:       int64(len(big) - 1),
+		MinWidth:       200,
+		MinHeight:      200,
+		MaxWidth:       800,
+		AllowedFormats: []string{"JPG", "png"},
+	})
+	_, err := svc.Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "notes.jpg", Content: []byte("plain text, not a photo")},
+		domain.PostCreatePhotoUpload{FileName: "tiny.jpg", Content: testPhoto(t, "jpeg", 120, 90, 1)},
+		domain.PostCreatePhotoUpload{FileName: "huge.png", Content: big},
+		domain.PostCreatePhotoUpload{FileName: "anim.gif", Content: gifBuf.Bytes()},
+	), true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"5 errors prohibited this post from being saved",
+		"Photo at position 0 is not an allowed image type (allowed: JPEG, PNG).",
+		"Photo at position 1 is 120x90 pixels; the minimum is 200x200.",
+		" KB; the maximum is ",
+		"Photo at position 2 is 900x300 pixels; the maximum is 800x12000.",
+		"Photo at position 3 is not an allowed image type (allowed: JPEG, PNG).",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+func TestPostCreateService_Submit_AcceptsSmallPhotosByDefault(t *testing.T) {
+	_, err := NewPostCreateService(photoSubmitRepo()).Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "thumb.jpg", Content: testPhoto(t, "jpeg", 48, 32, 1)},
+		domain.PostCreatePhotoUpload{FileName: "icon.png", Content: testPhoto(t, "png", 16, 16, 2)},
+	), true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err != nil {
+		t.Fatalf("expected small photos to pass without a configured minimum, got %v", err)
+	}
+}
+
+// pngHeaderOnly is a PNG that declares width x height but carries no pixel
+// data, so only image.DecodeConfig can read it.
+func pngHeaderOnly(width, height uint32) []byte {
+	ihdr := make([]byte, 0, 17)
+	ihdr = append(ihdr, "IHDR"...)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 2, 0, 0, 0) // 8-bit RGB
+	content := []byte("\x89PNG\r\n\x1a\n")
+	content = binary.BigEndian.AppendUint32(content, 13)
+	content = append(content, ihdr...)
+	return binary.BigEndian.AppendUint32(content, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestPostCreateService_Submit_RejectsTooManyPixelsBeforeDecoding(t *testing.T) {
+	repo := photoSubmitRepo()
+	_, err := NewPostCreateService(repo).Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "bomb.png", Content: pngHeaderOnly(11000, 11000)},
+	), true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "Photo at position 0 is 121000000 pixels (11000x11000); the maximum is 40000000.") ||
+		strings.Contains(err.Error(), "could not be read") {
+		t.Fatalf("expected the pixel cap from the header alone, got %v", err)
+	}
+
+	_, err = NewPostCreateService(photoSubmitRepo()).WithPhotoLimits(domain.PhotoLimits{MaxPixels: 100_000}).Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "wide.png", Content: testPhoto(t, "png", 400, 300, 1)},
+	), true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "is 120000 pixels (400x300); the maximum is 100000.") {
+		t.Fatalf("expected a configured pixel cap enforced, got %v", err)
+	}
+}
+
+func TestPostCreateService_Submit_DetectsDuplicatePhotosInSubmission(t *testing.T) {
+	repo := photoSubmitRepo()
+	_, err := NewPostCreateService(repo).Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "a.png", Content: testPhoto(t, "png", 800, 600, 4)},
+		domain.PostCreatePhotoUpload{FileName: "b.jpg", Content: testPhoto(t, "jpeg", 800, 600, 5)},
+		domain.PostCreatePhotoUpload{FileName: "a-small.jpg", Content: testPhoto(t, "jpeg", 400, 300, 4)},
+	), true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err == nil {
+		t.Fatalf("expected duplicate error")
+	}
+	if !strings.Contains(err.Error(), "1 error prohibited this post from being saved") ||
+		!strings.Contains(err.Error(), "Photo at position 2 looks like a duplicate of the photo at position 0.") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPostCreateService_Submit_DetectsDuplicateOfRecentPost(t *testing.T) {
+	repo := photoSubmitRepo()
+	previous := perceptualHash(decodeTestPhoto(t, testPhoto(t, "jpeg", 640, 480, 6)))
+	repo.recentPhotos = []domain.PhotoFingerprint{{PostID: 130031500, Position: 0, PerceptualHash: previous}}
+
+	_, err := NewPostCreateService(repo).Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "again.png", Content: testPhoto(t, "png", 800, 600, 6)},
+	), true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "Photo at position 0 looks like a duplicate of a photo in your post 130031500 from the last 30 days.") {
+		t.Fatalf("expected recent duplicate error, got %v", err)
+	}
+	if repo.recentEmail != "wientjes@alumni.stanford.edu" {
+		t.Fatalf("expected lookup by poster email, got %q", repo.recentEmail)
+	}
+
+	disabled := photoSubmitRepo()
+	disabled.recentPhotos = repo.recentPhotos
+	_, err = NewPostCreateService(disabled).WithPhotoLimits(domain.PhotoLimits{DuplicateWindow: -1}).Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "again.png", Content: testPhoto(t, "png", 800, 600, 6)},
+	), true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err != nil {
+		t.Fatalf("expected no recent-post check when disabled, got %v", err)
+	}
+	if disabled.recentEmail != "" {
+		t.Fatalf("expected no fingerprint lookup when disabled")
+	}
+}
+
+func TestPostCreateService_Submit_SavesPerceptualHashes(t *testing.T) {
+	repo := photoSubmitRepo()
+	_, err := NewPostCreateService(repo).Submit(context.Background(), photoSubmission(
+		domain.PostCreatePhotoUpload{FileName: "a.jpg", Content: testPhoto(t, "jpeg", 400, 300, 9)},
+	), false, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, &mockPostCreatePhotoUploader{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.savedPhotos) != 1 || repo.savedPhotos[0].PerceptualHash == 0 {
+		t.Fatalf("expected saved photo with perceptual hash, got %+v", repo.savedPhotos)
+	}
+}
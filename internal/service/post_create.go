@@ -15,11 +15,17 @@ type PostCreateRepository interface {
 	ListSubcategories(ctx context.Context) ([]domain.Subcategory, error)
 	CreatePendingPost(ctx context.Context, submission domain.PostCreateSubmission) (domain.PostCreatePersisted, error)
 	SavePostPhotos(ctx context.Context, photos []domain.PostCreateSavedPhoto) error
+	DeletePendingPost(ctx context.Context, postID int64) error
+	PhotoFingerprintRepository
 }
 
 // PostCreateService builds the staged post creation flow.
 type PostCreateService struct {
-	repo PostCreateRepository
+	repo               PostCreateRepository
+	templates          *EmailTemplates
+	photoLimits        domain.PhotoLimits
+	photoUploadWorkers int
+	emailPolicy        *EmailDomainChecker
 }
 
 // NewPostCreateService constructs PostCreateService.
@@ -27,6 +33,12 @@ func NewPostCreateService(repo PostCreateRepository) *PostCreateService {
 	return &PostCreateService{repo: repo}
 }
 
+// WithEmailTemplates overrides the embedded publish-email templates.
+func (s *PostCreateService) WithEmailTemplates(templates *EmailTemplates) *PostCreateService {
+	s.templates = templates
+	return s
+}
+
 // BuildPage returns the requested post-create stage based on selected IDs.
 func (s *PostCreateService) BuildPage(ctx context.Context, categoryID, subcategoryID int64) (domain.PostCreatePage, error) {
 	categories, err := s.repo.ListCategories(ctx)
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// defaultPhotoUploadConcurrency bounds simultaneous photo uploads per post.
+const defaultPhotoUploadConcurrency = 4
+
+// WithPhotoUploadConcurrency bounds how many photos upload at once. Values
+// below 1 use the default.
+func (s *PostCreateService) WithPhotoUploadConcurrency(workers int) *PostCreateService {
+	s.photoUploadWorkers = workers
+	return s
+}
+
+// uploadPostPhotos uploads photos concurrently on a bounded worker pool. The
+// first failure cancels in-flight uploads and stops new ones; the photos that did upload are
+// returned alongside the error so the caller can roll them back. On success
+// the result keeps input order.
+func (s *PostCreateService) uploadPostPhotos(
+	ctx context.Context,
+	uploader PostCreatePhotoUploader,
+	postID int64,
+	photos []domain.PostCreatePhotoUpload,
+) ([]domain.PostCreateSavedPhoto, error) {
+	return uploadPhotosConcurrently(ctx, uploader, postID, photos, s.photoUploadWorkers)
+}
+
+func uploadPhotosConcurrently(
+	ctx context.Context,
+	uploader PostCreatePhotoUploader,
+	postID int64,
+	photos []domain.PostCreatePhotoUpload,
+	workers int,
+) ([]domain.PostCreateSavedPhoto, error) {
+	if workers < 1 {
+		workers = defaultPhotoUploadConcurrency
+	}
+
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		next     = make(chan int)
+		saved    = make([]domain.PostCreateSavedPhoto, len(photos))
+		uploaded = make([]bool, len(photos))
+		errs     = make([]error, len(photos))
+	)
+	for range min(workers, len(photos)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range next {
+				photo := photos[idx]
+				savedPhoto, err := uploader.UploadPostPhoto(uploadCtx, postID, photo)
+				if err != nil {
+					errs[idx] = fmt.Errorf("uploading photo at position %d: %w", photo.Position, err)
+					cancel()
+					continue
+				}
+				savedPhoto.PostID = postID
+				savedPhoto.Position = photo.Position
+				savedPhoto.PerceptualHash = photo.PerceptualHash
+				saved[idx] = savedPhoto
+				uploaded[idx] = true
+			}
+		}()
+	}
+
+	// Photos are handed out in position order; once an upload fails no new
+	// upload starts.
+feed:
+	for idx := range photos {
+		select {
+		case next <- idx:
+		case <-uploadCtx.Done():
+			errs[idx] = uploadCtx.Err()
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	done := make([]domain.PostCreateSavedPhoto, 0, len(photos))
+	for idx := range photos {
+		if uploaded[idx] {
+			done = append(done, saved[idx])
+		}
+	}
+	if err := firstUploadError(ctx, errs); err != nil {
+		return done, err
+	}
+	return done, nil
+}
+
+// firstUploadError prefers the failure that triggered cancellation over the
+// context errors it caused in sibling uploads.
+func firstUploadError(ctx context.Context, errs []error) error {
+	var canceled error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if ctx.Err() == nil && errors.Is(err, context.Canceled) {
+			if canceled == nil {
+				canceled = err
+			}
+			continue
+		}
+		return err
+	}
+	return canceled
+}
+
+// rollbackPostPhotos deletes uploaded photo objects and the pending post after
+// a failed submission. Cleanup runs even if ctx was canceled; cleanup errors
+// are joined onto cause.
+func (s *PostCreateService) rollbackPostPhotos(
+	ctx context.Context,
+	uploader PostCreatePhotoUploader,
+	postID int64,
+	uploaded []domain.PostCreateSavedPhoto,
+	cause error,
+) error {
+	cleanupCtx := context.WithoutCancel(ctx)
+	errs := append([]error{cause}, deletePhotoObjects(cleanupCtx, uploader, uploaded, "rolling back")...)
+	if err := s.repo.DeletePendingPost(cleanupCtx, postID); err != nil {
+		errs = append(errs, fmt.Errorf("rolling back pending post %d: %w", postID, err))
+	}
+	return errors.Join(errs...)
+}
+
+// deletePhotoObjects deletes each photo's stored objects and returns one
+// wrapped error per photo that could not be deleted.
+func deletePhotoObjects(ctx context.Context, uploader PostCreatePhotoUploader, photos []domain.PostCreateSavedPhoto, verb string) []error {
+	errs := make([]error, 0)
+	for _, photo := range photos {
+		if err := uploader.DeletePostPhoto(ctx, photo); err != nil {
+			errs = append(errs, fmt.Errorf("%s photo at position %d: %w", verb, photo.Position, err))
+		}
+	}
+	return errs
+}
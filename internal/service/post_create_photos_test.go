@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// faultyPhotoUploader fails the upload at failPosition, can block uploads at
+// blockPositions until their context is canceled (calling onBlock first), and
+// records concurrency and rollback deletes.
+type faultyPhotoUploader struct {
+	failPosition   int
+	blockPositions map[int]bool
+	onBlock        func()
+	hold           time.Duration
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	uploaded    []int
+	canceled    []int
+	deleted     []string
+}
+
+func (f *faultyPhotoUploader) UploadPostPhoto(ctx context.Context, postID int64, photo domain.PostCreatePhotoUpload) (domain.PostCreateSavedPhoto, error) {
+	f.mu.Lock()
+	f.inFlight++
+	f.maxInFlight = max(f.maxInFlight, f.inFlight)
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+
+	if f.blockPositions[photo.Position] {
+		if f.onBlock != nil {
+			f.onBlock()
+		}
+		<-ctx.Done()
+		f.mu.Lock()
+		f.canceled = append(f.canceled, photo.Position)
+		f.mu.Unlock()
+		return domain.PostCreateSavedPhoto{}, ctx.Err()
+	}
+	if f.hold > 0 {
+		time.Sleep(f.hold)
+	}
+	if photo.Position == f.failPosition {
+		return domain.PostCreateSavedPhoto{}, errors.New("s3 unavailable")
+	}
+
+	f.mu.Lock()
+	f.uploaded = append(f.uploaded, photo.Position)
+	f.mu.Unlock()
+	return domain.PostCreateSavedPhoto{
+		PostID:      postID,
+		S3Key:       fmt.Sprintf("v2/posts/%d/photo-%d.jpg", postID, photo.Position),
+		TickerS3Key: fmt.Sprintf("v2/posts/%d/ticker_photo-%d.jpg", postID, photo.Position),
+		Position:    photo.Position,
+	}, nil
+}
+
+func (f *faultyPhotoUploader) DeletePostPhoto(_ context.Context, photo domain.PostCreateSavedPhoto) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, photo.S3Key)
+	return nil
+}
+
+func fourTestPhotos(t *testing.T) []domain.PostCreatePhotoUpload {
+	t.Helper()
+	photos := make([]domain.PostCreatePhotoUpload, 0, 4)
+	for idx := 0; idx < 4; idx++ {
+		photos = append(photos, domain.PostCreatePhotoUpload{
+			FileName: fmt.Sprintf("photo-%d.jpg", idx),
+			Content:  testPhoto(t, "jpeg", 400, 300, uint32(20+idx)),
+		})
+	}
+	return photos
+}
+
+func TestPostCreateService_Submit_UploadsPhotosConcurrentlyWithinBound(t *testing.T) {
+	repo := photoSubmitRepo()
+	uploader := &faultyPhotoUploader{failPosition: -1, hold: 20 * time.Millisecond}
+	sender := &mockPublishSender{}
+
+	result, err := NewPostCreateService(repo).WithPhotoUploadConcurrency(2).Submit(
+		context.Background(), photoSubmission(fourTestPhotos(t)...), false,
+		"https://supost.com", "response@mg.supost.com", sender, uploader,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if uploader.maxInFlight != 2 {
+		t.Fatalf("expected 2 concurrent uploads, saw %d", uploader.maxInFlight)
+	}
+	if len(repo.savedPhotos) != 4 {
+		t.Fatalf("expected 4 saved photos, got %d", len(repo.savedPhotos))
+	}
+	for idx, photo := range repo.savedPhotos {
+		if photo.Position != idx {
+			t.Fatalf("expected saved photos in position order, got %+v", repo.savedPhotos)
+		}
+	}
+	if len(result.PhotoS3Keys) != 4 || !strings.HasSuffix(result.PhotoS3Keys[3], "photo-3.jpg") {
+		t.Fatalf("unexpected photo keys %v", result.PhotoS3Keys)
+	}
+}
+
+func TestPostCreateService_Submit_UploadFailureRollsBackPhotosAndPendingPost(t *testing.T) {
+	repo := photoSubmitRepo()
+	uploader := &faultyPhotoUploader{failPosition: 2, hold: 5 * time.Millisecond}
+	sender := &mockPublishSender{}
+
+	_, err := NewPostCreateService(repo).WithPhotoUploadConcurrency(1).Submit(
+		context.Background(), photoSubmission(fourTestPhotos(t)...), false,
+		"https://supost.com", "response@mg.supost.com", sender, uploader,
+	)
+	if err == nil || !strings.Contains(err.Error(), "uploading photo at position 2: s3 unavailable") {
+		t.Fatalf("expected upload failure, got %v", err)
+	}
+
+	sort.Strings(uploader.deleted)
+	want := []string{"v2/posts/130031999/photo-0.jpg", "v2/posts/130031999/photo-1.jpg"}
+	if strings.Join(uploader.deleted, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected rollback of %v, got %v", want, uploader.deleted)
+	}
+	for _, position := range uploader.uploaded {
+		if position == 3 {
+			t.Fatalf("expected photo 3 not to start after the failure")
+		}
+	}
+	if repo.deletedPostID != 130031999 {
+		t.Fatalf("expected pending post rollback, got %d", repo.deletedPostID)
+	}
+	if repo.saveCalled || sender.sent {
+		t.Fatalf("expected no photo rows and no publish email after failure")
+	}
+}
+
+func TestPostCreateService_Submit_UploadFailureCancelsInFlightUploads(t *testing.T) {
+	repo := photoSubmitRepo()
+	uploader := &faultyPhotoUploader{failPosition: 0, blockPositions: map[int]bool{1: true, 2: true}, hold: 30 * time.Millisecond}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewPostCreateService(repo).Submit(
+			context.Background(), photoSubmission(fourTestPhotos(t)[:3]...), false,
+			"https://supost.com", "response@mg.supost.com", &mockPublishSender{}, uploader,
+		)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "uploading photo at position 0: s3 unavailable") {
+			t.Fatalf("expected the root-cause failure, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("blocked uploads were not canceled")
+	}
+	if len(uploader.canceled) != 2 {
+		t.Fatalf("expected both blocked uploads canceled, got %v", uploader.canceled)
+	}
+	if len(uploader.deleted) != 0 || repo.deletedPostID != 130031999 {
+		t.Fatalf("expected only the pending post rolled back, got deletes %v post %d", uploader.deleted, repo.deletedPostID)
+	}
+}
+
+func TestPostCreateService_Submit_CallerCancellationRollsBack(t *testing.T) {
+	repo := photoSubmitRepo()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uploader := &faultyPhotoUploader{failPosition: -1, blockPositions: map[int]bool{1: true}, onBlock: cancel}
+
+	_, err := NewPostCreateService(repo).WithPhotoUploadConcurrency(1).Submit(
+		ctx, photoSubmission(fourTestPhotos(t)[:2]...), false,
+		"https://supost.com", "response@mg.supost.com", &mockPublishSender{}, uploader,
+	)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+	if len(uploader.deleted) != 1 || uploader.deleted[0] != "v2/posts/130031999/photo-0.jpg" {
+		t.Fatalf("expected the finished upload rolled back despite cancellation, got %v", uploader.deleted)
+	}
+	if repo.deletedPostID != 130031999 {
+		t.Fatalf("expected pending post rollback")
+	}
+}
+
+func TestPostCreateService_Submit_PhotoRowFailureRollsBackUploads(t *testing.T) {
+	repo := photoSubmitRepo()
+	repo.saveErr = errors.New("photo insert failed")
+	uploader := &faultyPhotoUploader{failPosition: -1}
+
+	_, err := NewPostCreateService(repo).Submit(
+		context.Background(), photoSubmission(fourTestPhotos(t)[:2]...), false,
+		"https://supost.com", "response@mg.supost.com", &mockPublishSender{}, uploader,
+	)
+	if err == nil || !strings.Contains(err.Error(), "saving post photos: photo insert failed") {
+		t.Fatalf("expected save failure, got %v", err)
+	}
+	if len(uploader.deleted) != 2 || repo.deletedPostID != 130031999 {
+		t.Fatalf("expected both uploads and the pending post rolled back, got %v / %d", uploader.deleted, repo.deletedPostID)
+	}
+}
+
+func TestPostCreateService_Submit_PublishEmailFailureRollsBack(t *testing.T) {
+	repo := photoSubmitRepo()
+	uploader := &faultyPhotoUploader{failPosition: -1}
+
+	_, err := NewPostCreateService(repo).Submit(
+		context.Background(), photoSubmission(fourTestPhotos(t)[:2]...), false,
+		"https://supost.com", "response@mg.supost.com", &mockPublishSender{err: errors.New("mailgun unavailable")}, uploader,
+	)
+	if err == nil || !strings.Contains(err.Error(), "sending publish email: mailgun unavailable") {
+		t.Fatalf("expected the email failure, got %v", err)
+	}
+	if len(uploader.deleted) != 2 || repo.deletedPostID != 130031999 {
+		t.Fatalf("expected both uploads and the pending post rolled back, got %v / %d", uploader.deleted, repo.deletedPostID)
+	}
+}
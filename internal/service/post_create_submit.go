@@ -17,24 +17,16 @@ const (
 	publishSafetyURL     = "https://supost.com/safety"
 )
 
-var exactStanfordEmailDomains = map[string]struct{}{
-	"stanford.edu":           {},
-	"alumni.stanford.edu":    {},
-	"stanfordalumni.org":     {},
-	"stanfordchildrens.org":  {},
-	"stanfordhealthcare.org": {},
-	"stanfordmed.org":        {},
-	"lpch.org":               {},
-}
-
 // PostCreateEmailSender defines publish-email side effects where consumed.
 type PostCreateEmailSender interface {
 	SendPublishEmail(ctx context.Context, msg domain.PublishEmailMessage) error
 }
 
 // PostCreatePhotoUploader defines photo upload side effects where consumed.
+// DeletePostPhoto removes an uploaded photo's objects during rollback.
 type PostCreatePhotoUploader interface {
 	UploadPostPhoto(ctx context.Context, postID int64, photo domain.PostCreatePhotoUpload) (domain.PostCreateSavedPhoto, error)
+	DeletePostPhoto(ctx context.Context, photo domain.PostCreateSavedPhoto) error
 }
 
 // Submit creates a post, persists it, and sends a publish-link email.
@@ -63,8 +55,15 @@ func (s *PostCreateService) Submit(
 		normalized.PostedAt = time.Now()
 	}
 
+	templates, err := resolveEmailTemplates(s.templates)
+	if err != nil {
+		return domain.PostCreateSubmitResult{}, err
+	}
 	publishURL := buildPublishURL(baseURL, normalized.AccessToken)
-	subject, body := buildPublishEmailContent(normalized.Name, publishURL, normalized.PostedAt)
+	subject, body, htmlBody, err := buildPublishEmailContent(templates, normalized.Name, publishURL, normalized.PostedAt)
+	if err != nil {
+		return domain.PostCreateSubmitResult{}, err
+	}
 
 	result := domain.PostCreateSubmitResult{
 		DryRun:      dryRun,
@@ -76,6 +75,7 @@ func (s *PostCreateService) Submit(
 		PhotoCount:  len(normalized.Photos),
 		Subject:     subject,
 		Body:        body,
+		HTMLBody:    htmlBody,
 	}
 
 	if dryRun {
@@ -84,6 +84,9 @@ func (s *PostCreateService) Submit(
 	if sender == nil {
 		return domain.PostCreateSubmitResult{}, fmt.Errorf("email sender is required")
 	}
+	if len(normalized.Photos) > 0 && photoUploader == nil {
+		return domain.PostCreateSubmitResult{}, fmt.Errorf("photo uploader is required")
+	}
 
 	persisted, err := s.repo.CreatePendingPost(ctx, normalized)
 	if err != nil {
@@ -93,37 +96,33 @@ func (s *PostCreateService) Submit(
 	if persisted.PostID > 0 {
 		result.PostID = persisted.PostID
 	}
-	if strings.TrimSpace(persisted.AccessToken) != "" {
-		result.AccessToken = strings.TrimSpace(persisted.AccessToken)
-		result.PublishURL = buildPublishURL(baseURL, result.AccessToken)
-		result.Subject, result.Body = buildPublishEmailContent(normalized.Name, result.PublishURL, result.PostedAt)
-	}
-	if !persisted.PostedAt.IsZero() {
-		result.PostedAt = persisted.PostedAt
-		result.Subject, result.Body = buildPublishEmailContent(normalized.Name, result.PublishURL, result.PostedAt)
+	if strings.TrimSpace(persisted.AccessToken) != "" || !persisted.PostedAt.IsZero() {
+		if strings.TrimSpace(persisted.AccessToken) != "" {
+			result.AccessToken = strings.TrimSpace(persisted.AccessToken)
+			result.PublishURL = buildPublishURL(baseURL, result.AccessToken)
+		}
+		if !persisted.PostedAt.IsZero() {
+			result.PostedAt = persisted.PostedAt
+		}
+		result.Subject, result.Body, result.HTMLBody, err = buildPublishEmailContent(templates, normalized.Name, result.PublishURL, result.PostedAt)
+		if err != nil {
+			return domain.PostCreateSubmitResult{}, err
+		}
 	}
 
+	var savedPhotos []domain.PostCreateSavedPhoto
 	if len(normalized.Photos) > 0 {
-		if photoUploader == nil {
-			return domain.PostCreateSubmitResult{}, fmt.Errorf("photo uploader is required")
-		}
 		if result.PostID <= 0 {
 			return domain.PostCreateSubmitResult{}, fmt.Errorf("invalid persisted post id for photo upload")
 		}
 
-		savedPhotos := make([]domain.PostCreateSavedPhoto, 0, len(normalized.Photos))
-		for _, photo := range normalized.Photos {
-			savedPhoto, err := photoUploader.UploadPostPhoto(ctx, result.PostID, photo)
-			if err != nil {
-				return domain.PostCreateSubmitResult{}, fmt.Errorf("uploading photo at position %d: %w", photo.Position, err)
-			}
-			savedPhoto.PostID = result.PostID
-			savedPhoto.Position = photo.Position
-			savedPhotos = append(savedPhotos, savedPhoto)
+		savedPhotos, err = s.uploadPostPhotos(ctx, photoUploader, result.PostID, normalized.Photos)
+		if err != nil {
+			return domain.PostCreateSubmitResult{}, s.rollbackPostPhotos(ctx, photoUploader, result.PostID, savedPhotos, err)
 		}
 
 		if err := s.repo.SavePostPhotos(ctx, savedPhotos); err != nil {
-			return domain.PostCreateSubmitResult{}, fmt.Errorf("saving post photos: %w", err)
+			return domain.PostCreateSubmitResult{}, s.rollbackPostPhotos(ctx, photoUploader, result.PostID, savedPhotos, fmt.Errorf("saving post photos: %w", err))
 		}
 
 		result.PhotoS3Keys = make([]string, 0, len(savedPhotos))
@@ -137,9 +136,15 @@ func (s *PostCreateService) Submit(
 		To:      result.EmailTo,
 		Subject: result.Subject,
 		Text:    result.Body,
+		HTML:    result.HTMLBody,
 	}
+	// The publish link exists only in this email, so a post whose email
+	// failed could never go live: roll it back like a failed upload.
 	if err := sender.SendPublishEmail(ctx, msg); err != nil {
-		return domain.PostCreateSubmitResult{}, err
+		if result.PostID <= 0 {
+			return domain.PostCreateSubmitResult{}, err
+		}
+		return domain.PostCreateSubmitResult{}, s.rollbackPostPhotos(ctx, photoUploader, result.PostID, savedPhotos, fmt.Errorf("sending publish email: %w", err))
 	}
 	result.EmailSent = true
 	return result, nil
@@ -168,8 +173,8 @@ func (s *PostCreateService) validateSubmissionInput(ctx context.Context, input d
 	}
 	if normalized.Email == "" {
 		problems = append(problems, "Email is required.")
-	} else if !isStanfordEmail(normalized.Email) {
-		problems = append(problems, "Email must be a Stanford email (e.g., @stanford.edu, @cs.stanford.edu).")
+	} else if !s.emailDomainChecker().Allowed(normalized.Email) {
+		problems = append(problems, "Email must be "+s.emailDomainChecker().Requirement()+".")
 	}
 	if normalized.IP != "" {
 		if _, err := netip.ParseAddr(normalized.IP); err != nil {
@@ -188,10 +193,16 @@ func (s *PostCreateService) validateSubmissionInput(ctx context.Context, input d
 	if len(normalized.Photos) > 4 {
 		problems = append(problems, "At most 4 photos are allowed.")
 	}
-	for _, photo := range normalized.Photos {
-		if len(photo.Content) == 0 {
-			problems = append(problems, fmt.Sprintf("Photo at position %d is empty.", photo.Position))
+	if len(normalized.Photos) > 0 {
+		posterEmail := ""
+		if s.emailDomainChecker().Allowed(normalized.Email) {
+			posterEmail = normalized.Email
+		}
+		photoProblems, err := s.validatePostCreatePhotos(ctx, posterEmail, normalized.Photos)
+		if err != nil {
+			return domain.PostCreateSubmission{}, err
 		}
+		problems = append(problems, photoProblems...)
 	}
 
 	if len(problems) > 0 {
@@ -234,21 +245,6 @@ func formatPostCreateValidationErrors(problems []string) string {
 	return header + "\nThere were problems with the following fields:\n\n" + strings.Join(problems, "\n")
 }
 
-func isStanfordEmail(email string) bool {
-	at := strings.LastIndex(email, "@")
-	if at <= 0 || at == len(email)-1 {
-		return false
-	}
-	domainPart := strings.ToLower(strings.TrimSpace(email[at+1:]))
-	if domainPart == "" {
-		return false
-	}
-	if _, ok := exactStanfordEmailDomains[domainPart]; ok {
-		return true
-	}
-	return strings.HasSuffix(domainPart, ".stanford.edu")
-}
-
 func generateAccessTokenHex(numBytes int) (string, error) {
 	if numBytes <= 0 {
 		return "", fmt.Errorf("numBytes must be positive")
@@ -269,7 +265,7 @@ func buildPublishURL(baseURL, accessToken string) string {
 	return root + "/post/publish/" + strings.TrimSpace(accessToken)
 }
 
-func buildPublishEmailContent(postName, publishURL string, postedAt time.Time) (string, string) {
+func buildPublishEmailContent(templates *EmailTemplates, postName, publishURL string, postedAt time.Time) (string, string, string, error) {
 	title := strings.TrimSpace(postName)
 	if title == "" {
 		title = "(untitled post)"
@@ -277,18 +273,15 @@ func buildPublishEmailContent(postName, publishURL string, postedAt time.Time) (
 	if postedAt.IsZero() {
 		postedAt = time.Now()
 	}
-	dateLine := postedAt.Format("Mon, Jan 2, 2006 03:04 PM")
 	subject := "SUpost - Publish your post! " + title
-	body := strings.Join([]string{
-		"Publish your post by pressing:",
-		"",
-		publishURL,
-		"",
-		title,
-		"",
-		"Posted on: " + dateLine + " -- Stanford University",
-		"",
-		"Do not send electronic payments to sellers: " + publishSafetyURL,
-	}, "\n")
-	return subject, body
+	text, html, err := templates.RenderPublish(domain.PublishEmailData{
+		PostName:   title,
+		PublishURL: publishURL,
+		PostedAt:   postedAt.Format("Mon, Jan 2, 2006 03:04 PM"),
+		SafetyURL:  publishSafetyURL,
+	})
+	if err != nil {
+		return "", "", "", err
+	}
+	return subject, text, html, nil
 }
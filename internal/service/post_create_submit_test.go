@@ -1,8 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +21,10 @@ type mockPostCreateSubmitRepo struct {
 	submission    domain.PostCreateSubmission
 	persisted     domain.PostCreatePersisted
 	savedPhotos   []domain.PostCreateSavedPhoto
+	recentPhotos  []domain.PhotoFingerprint
+	recentEmail   string
+	saveErr       error
+	deletedPostID int64
 	createCalled  bool
 	saveCalled    bool
 }
@@ -44,26 +54,81 @@ func (m *mockPostCreateSubmitRepo) CreatePendingPost(_ context.Context, submissi
 
 func (m *mockPostCreateSubmitRepo) SavePostPhotos(_ context.Context, photos []domain.PostCreateSavedPhoto) error {
 	m.saveCalled = true
+	if m.saveErr != nil {
+		return m.saveErr
+	}
 	m.savedPhotos = append([]domain.PostCreateSavedPhoto(nil), photos...)
 	return nil
 }
 
+func (m *mockPostCreateSubmitRepo) DeletePendingPost(_ context.Context, postID int64) error {
+	m.deletedPostID = postID
+	return nil
+}
+
+func (m *mockPostCreateSubmitRepo) ListRecentPhotoFingerprints(_ context.Context, email string, _ time.Time) ([]domain.PhotoFingerprint, error) {
+	m.recentEmail = email
+	return m.recentPhotos, nil
+}
+
+// testPhoto encodes a width x height image of an 8x8 grid of pseudo-random
+// gray cells. The grid scales with the image, so one seed at two sizes is the
+// same picture and different seeds are different pictures.
+func testPhoto(t *testing.T, format string, width, height int, seed uint32) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := uint32(y*8/height*8 + x*8/width)
+			v := (cell + 1) * (seed*2654435761 + 40503)
+			v ^= v >> 13
+			img.Set(x, y, color.RGBA{R: uint8(v), G: uint8(v >> 8), B: uint8(v >> 16), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	if format == "png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	}
+	if err != nil {
+		t.Fatalf("encoding test photo: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func (m *mockPostCreatePhotoUploader) DeletePostPhoto(_ context.Context, photo domain.PostCreateSavedPhoto) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleted = append(m.deleted, photo)
+	return nil
+}
+
 type mockPublishSender struct {
 	last domain.PublishEmailMessage
 	sent bool
+	err  error
 }
 
 func (m *mockPublishSender) SendPublishEmail(_ context.Context, msg domain.PublishEmailMessage) error {
 	m.last = msg
+	if m.err != nil {
+		return m.err
+	}
 	m.sent = true
 	return nil
 }
 
 type mockPostCreatePhotoUploader struct {
+	mu      sync.Mutex
 	uploads []domain.PostCreatePhotoUpload
+	deleted []domain.PostCreateSavedPhoto
 }
 
 func (m *mockPostCreatePhotoUploader) UploadPostPhoto(_ context.Context, postID int64, photo domain.PostCreatePhotoUpload) (domain.PostCreateSavedPhoto, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.uploads = append(m.uploads, photo)
 	return domain.PostCreateSavedPhoto{
 		PostID:      postID,
@@ -404,8 +469,8 @@ func TestPostCreateService_Submit_WithPhotosUploadsAndPersistsMetadata(t *testin
 		Price:         100,
 		PriceProvided: true,
 		Photos: []domain.PostCreatePhotoUpload{
-			{FileName: "photo-1.jpg", ContentType: "image/jpeg", Content: []byte("image-1")},
-			{FileName: "photo-2.png", ContentType: "image/png", Content: []byte("image-2")},
+			{FileName: "photo-1.jpg", ContentType: "image/jpeg", Content: testPhoto(t, "jpeg", 40, 30, 1)},
+			{FileName: "photo-2.png", ContentType: "image/png", Content: testPhoto(t, "png", 40, 30, 2)},
 		},
 	}, false, "https://supost.com", "response@mg.supost.com", sender, uploader)
 	if err != nil {
@@ -483,7 +548,7 @@ func TestPostCreateService_Submit_RejectsMissingPhotoUploaderWhenPhotosProvided(
 		Price:         100,
 		PriceProvided: true,
 		Photos: []domain.PostCreatePhotoUpload{
-			{FileName: "photo-1.jpg", ContentType: "image/jpeg", Content: []byte("image-1")},
+			{FileName: "photo-1.jpg", ContentType: "image/jpeg", Content: testPhoto(t, "jpeg", 40, 30, 1)},
 		},
 	}, false, "https://supost.com", "response@mg.supost.com", sender, nil)
 	if err == nil {
@@ -513,7 +578,7 @@ func TestPostCreateService_Submit_DryRunAllowsPhotosWithoutUploader(t *testing.T
 		Price:         100,
 		PriceProvided: true,
 		Photos: []domain.PostCreatePhotoUpload{
-			{FileName: "photo-1.jpg", ContentType: "image/jpeg", Content: []byte("image-1")},
+			{FileName: "photo-1.jpg", ContentType: "image/jpeg", Content: testPhoto(t, "jpeg", 40, 30, 1)},
 		},
 	}, true, "https://supost.com", "response@mg.supost.com", sender, nil)
 	if err != nil {
@@ -523,3 +588,50 @@ func TestPostCreateService_Submit_DryRunAllowsPhotosWithoutUploader(t *testing.T
 		t.Fatalf("expected photo_count=1, got %d", result.PhotoCount)
 	}
 }
+
+func TestPostCreateService_Submit_RejectsHEICAndAVIFPhotos(t *testing.T) {
+	repo := &mockPostCreateSubmitRepo{
+		categories: []domain.Category{{ID: 5, Name: "for sale/wanted", ShortName: "for sale"}},
+		subcategories: []domain.Subcategory{
+			{ID: 14, CategoryID: 5, Name: "furniture"},
+		},
+	}
+	svc := NewPostCreateService(repo)
+	ftyp := func(brands string) []byte {
+		box := append([]byte{0, 0, 0, byte(8 + len(brands))}, []byte("ftyp"+brands)...)
+		return append(box, []byte("....mdat")...)
+	}
+
+	_, err := svc.Submit(context.Background(), domain.PostCreateSubmission{
+		CategoryID:    5,
+		SubcategoryID: 14,
+		Name:          "Red bike for sale",
+		Body:          "Pick up on campus.",
+		Email:         "wientjes@alumni.stanford.edu",
+		Price:         100,
+		PriceProvided: true,
+		Photos: []domain.PostCreatePhotoUpload{
+			{FileName: "IMG_0001.HEIC", Content: ftyp("heic\x00\x00\x00\x00mif1heic")},
+			{FileName: "photo.bin", Content: ftyp("avif\x00\x00\x00\x00avifmif1")},
+			{FileName: "export.jpg", Content: ftyp("mif1\x00\x00\x00\x00mif1")},
+			{FileName: "IMG_0002.heic", ContentType: "application/octet-stream", Content: []byte("truncated")},
+		},
+	}, true, "https://supost.com", "response@mg.supost.com", &mockPublishSender{}, nil)
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+	for _, want := range []string{
+		"4 errors prohibited this post from being saved",
+		"Photo at position 0 is HEIC, which is not supported.",
+		"Photo at position 1 is AVIF, which is not supported.",
+		"Photo at position 2 is HEIC, which is not supported.",
+		"Photo at position 3 is HEIC, which is not supported.",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in error, got: %v", want, err)
+		}
+	}
+	if repo.createCalled {
+		t.Fatalf("expected no persistence for rejected photos")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Capmus-Team/supost-cli/internal/domain"
 )
@@ -29,6 +30,14 @@ func (m *mockPostCreateRepo) SavePostPhotos(_ context.Context, _ []domain.PostCr
 	return nil
 }
 
+func (m *mockPostCreateRepo) DeletePendingPost(_ context.Context, _ int64) error {
+	return nil
+}
+
+func (m *mockPostCreateRepo) ListRecentPhotoFingerprints(_ context.Context, _ string, _ time.Time) ([]domain.PhotoFingerprint, error) {
+	return nil, nil
+}
+
 func TestPostCreateService_BuildPage_ChooseCategory(t *testing.T) {
 	svc := NewPostCreateService(&mockPostCreateRepo{
 		categories: []domain.Category{
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// PostPhotoRepository reads and rewrites the photo rows of an existing post.
+type PostPhotoRepository interface {
+	GetPostByAccessToken(ctx context.Context, accessToken string) (domain.Post, error)
+	ListPostPhotos(ctx context.Context, postID int64) ([]domain.PostCreateSavedPhoto, error)
+	// ReplacePostPhotos makes photos the post's complete photo list in one
+	// transaction. Rows are matched by s3_key; positions are taken as given.
+	ReplacePostPhotos(ctx context.Context, postID int64, photos []domain.PostCreateSavedPhoto) error
+	PhotoFingerprintRepository
+}
+
+// postTickerRefresher is implemented by photo backends that keep an extra
+// copy of the first photo's ticker (local storage writes the legacy
+// posts/{id}/ticker_{id}a path).
+type postTickerRefresher interface {
+	RefreshPostTicker(ctx context.Context, photo domain.PostCreateSavedPhoto) error
+}
+
+// PostPhotoService manages the photos of a post identified by its access
+// token, keeping positions contiguous from 0.
+type PostPhotoService struct {
+	repo               PostPhotoRepository
+	photoLimits        domain.PhotoLimits
+	photoUploadWorkers int
+}
+
+// NewPostPhotoService constructs PostPhotoService.
+func NewPostPhotoService(repo PostPhotoRepository) *PostPhotoService {
+	return &PostPhotoService{repo: repo}
+}
+
+// WithPhotoLimits overrides the photo validation limits applied to added and
+// replacement photos.
+func (s *PostPhotoService) WithPhotoLimits(limits domain.PhotoLimits) *PostPhotoService {
+	s.photoLimits = limits
+	return s
+}
+
+// WithPhotoUploadConcurrency bounds how many added photos upload at once.
+func (s *PostPhotoService) WithPhotoUploadConcurrency(workers int) *PostPhotoService {
+	s.photoUploadWorkers = workers
+	return s
+}
+
+// List returns the post's photos in position order.
+func (s *PostPhotoService) List(ctx context.Context, accessToken string) (domain.PostPhotosResult, error) {
+	post, photos, err := s.load(ctx, accessToken)
+	if err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+	return domain.PostPhotosResult{
+		Action:   "list",
+		PostID:   post.ID,
+		PostName: post.Name,
+		HasImage: post.HasImage,
+		Photos:   photos,
+	}, nil
+}
+
+// Add validates and uploads photos, appending them after the existing ones.
+// Uploaded objects are deleted again if the upload or the row update fails.
+func (s *PostPhotoService) Add(
+	ctx context.Context,
+	accessToken string,
+	uploads []domain.PostCreatePhotoUpload,
+	uploader PostCreatePhotoUploader,
+) (domain.PostPhotosResult, error) {
+	if len(uploads) == 0 {
+		return domain.PostPhotosResult{}, fmt.Errorf("at least one photo is required")
+	}
+	if uploader == nil {
+		return domain.PostPhotosResult{}, fmt.Errorf("photo uploader is required")
+	}
+	post, photos, err := s.load(ctx, accessToken)
+	if err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+
+	uploads = normalizePostCreatePhotos(uploads)
+	for idx := range uploads {
+		uploads[idx].Position = len(photos) + idx
+	}
+	if total := len(photos) + len(uploads); total > domain.MaxPostPhotos {
+		return domain.PostPhotosResult{}, fmt.Errorf("%s", formatPostCreateValidationErrors([]string{
+			fmt.Sprintf("At most %d photos are allowed; this post has %d and %d would make %d.", domain.MaxPostPhotos, len(photos), len(uploads), total),
+		}))
+	}
+	if err := s.validateUploads(ctx, post, photos, uploads); err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+
+	added, err := uploadPhotosConcurrently(ctx, uploader, post.ID, uploads, s.photoUploadWorkers)
+	if err != nil {
+		return domain.PostPhotosResult{}, discardUploadedPhotos(ctx, uploader, added, err)
+	}
+	next := append(append([]domain.PostCreateSavedPhoto(nil), photos...), added...)
+	if err := s.repo.ReplacePostPhotos(ctx, post.ID, renumberPostPhotos(post.ID, next)); err != nil {
+		return domain.PostPhotosResult{}, discardUploadedPhotos(ctx, uploader, added, fmt.Errorf("saving post photos: %w", err))
+	}
+	return s.finish(ctx, "add", post, photos, uploader, nil)
+}
+
+// Replace swaps the photo at position for a new upload, then deletes the old
+// photo's objects.
+func (s *PostPhotoService) Replace(
+	ctx context.Context,
+	accessToken string,
+	position int,
+	upload domain.PostCreatePhotoUpload,
+	uploader PostCreatePhotoUploader,
+) (domain.PostPhotosResult, error) {
+	if uploader == nil {
+		return domain.PostPhotosResult{}, fmt.Errorf("photo uploader is required")
+	}
+	post, photos, err := s.load(ctx, accessToken)
+	if err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+	if err := checkPostPhotoPosition(post.ID, photos, position); err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+
+	uploads := normalizePostCreatePhotos([]domain.PostCreatePhotoUpload{upload})
+	uploads[0].Position = position
+	others := make([]domain.PostCreateSavedPhoto, 0, len(photos)-1)
+	for _, photo := range photos {
+		if photo.Position != position {
+			others = append(others, photo)
+		}
+	}
+	if err := s.validateUploads(ctx, post, others, uploads); err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+
+	replacement, err := uploadPhotosConcurrently(ctx, uploader, post.ID, uploads, 1)
+	if err != nil {
+		return domain.PostPhotosResult{}, discardUploadedPhotos(ctx, uploader, replacement, err)
+	}
+	next := append([]domain.PostCreateSavedPhoto(nil), photos...)
+	next[position] = replacement[0]
+	if err := s.repo.ReplacePostPhotos(ctx, post.ID, renumberPostPhotos(post.ID, next)); err != nil {
+		return domain.PostPhotosResult{}, discardUploadedPhotos(ctx, uploader, replacement, fmt.Errorf("saving post photos: %w", err))
+	}
+	return s.finish(ctx, "replace", post, photos, uploader, []domain.PostCreateSavedPhoto{photos[position]})
+}
+
+// Remove drops the photo at position, shifts later photos down, and deletes
+// the removed photo's objects.
+func (s *PostPhotoService) Remove(
+	ctx context.Context,
+	accessToken string,
+	position int,
+	uploader PostCreatePhotoUploader,
+) (domain.PostPhotosResult, error) {
+	if uploader == nil {
+		return domain.PostPhotosResult{}, fmt.Errorf("photo uploader is required")
+	}
+	post, photos, err := s.load(ctx, accessToken)
+	if err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+	if err := checkPostPhotoPosition(post.ID, photos, position); err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+
+	next := make([]domain.PostCreateSavedPhoto, 0, len(photos)-1)
+	next = append(next, photos[:position]...)
+	next = append(next, photos[position+1:]...)
+	if err := s.repo.ReplacePostPhotos(ctx, post.ID, renumberPostPhotos(post.ID, next)); err != nil {
+		return domain.PostPhotosResult{}, fmt.Errorf("saving post photos: %w", err)
+	}
+	return s.finish(ctx, "remove", post, photos, uploader, []domain.PostCreateSavedPhoto{photos[position]})
+}
+
+// Reorder rearranges photos so new position i holds the photo currently at
+// order[i]. order must name every current position exactly once.
+func (s *PostPhotoService) Reorder(
+	ctx context.Context,
+	accessToken string,
+	order []int,
+	uploader PostCreatePhotoUploader,
+) (domain.PostPhotosResult, error) {
+	post, photos, err := s.load(ctx, accessToken)
+	if err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+	if len(order) != len(photos) {
+		return domain.PostPhotosResult{}, fmt.Errorf("order must list all %d photo positions of post %d, got %d", len(photos), post.ID, len(order))
+	}
+	seen := make([]bool, len(photos))
+	next := make([]domain.PostCreateSavedPhoto, 0, len(photos))
+	for _, from := range order {
+		if from < 0 || from >= len(photos) {
+			return domain.PostPhotosResult{}, fmt.Errorf("order position %d is out of range 0..%d", from, len(photos)-1)
+		}
+		if seen[from] {
+			return domain.PostPhotosResult{}, fmt.Errorf("order lists position %d more than once", from)
+		}
+		seen[from] = true
+		next = append(next, photos[from])
+	}
+	if err := s.repo.ReplacePostPhotos(ctx, post.ID, renumberPostPhotos(post.ID, next)); err != nil {
+		return domain.PostPhotosResult{}, fmt.Errorf("saving post photos: %w", err)
+	}
+	return s.finish(ctx, "reorder", post, photos, uploader, nil)
+}
+
+// load resolves the post by access token and returns its photos ordered and
+// renumbered 0..n-1, so gaps left by older data close on the next write.
+func (s *PostPhotoService) load(ctx context.Context, accessToken string) (domain.Post, []domain.PostCreateSavedPhoto, error) {
+	accessToken = strings.TrimSpace(accessToken)
+	if accessToken == "" {
+		return domain.Post{}, nil, fmt.Errorf("access token is required")
+	}
+	post, err := s.repo.GetPostByAccessToken(ctx, accessToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.Post{}, nil, fmt.Errorf("no post matches that access token: %w", err)
+		}
+		return domain.Post{}, nil, fmt.Errorf("looking up post by access token: %w", err)
+	}
+	photos, err := s.repo.ListPostPhotos(ctx, post.ID)
+	if err != nil {
+		return domain.Post{}, nil, fmt.Errorf("listing photos for post %d: %w", post.ID, err)
+	}
+	sort.SliceStable(photos, func(i, j int) bool { return photos[i].Position < photos[j].Position })
+	return post, renumberPostPhotos(post.ID, photos), nil
+}
+
+func (s *PostPhotoService) validateUploads(
+	ctx context.Context,
+	post domain.Post,
+	current []domain.PostCreateSavedPhoto,
+	uploads []domain.PostCreatePhotoUpload,
+) error {
+	existing := make([]domain.PhotoFingerprint, 0, len(current))
+	for _, photo := range current {
+		existing = append(existing, domain.PhotoFingerprint{PostID: post.ID, Position: photo.Position, PerceptualHash: photo.PerceptualHash})
+	}
+	problems, err := validatePhotoUploads(ctx, s.repo, s.photoLimits, strings.ToLower(strings.TrimSpace(post.Email)), existing, uploads)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", formatPostCreateValidationErrors(problems))
+	}
+	return nil
+}
+
+// finish deletes the objects of removed photos, refreshes the first-photo
+// ticker copy when the first photo changed, and reloads the post so
+// has_image reflects the new photo list.
+func (s *PostPhotoService) finish(
+	ctx context.Context,
+	action string,
+	post domain.Post,
+	before []domain.PostCreateSavedPhoto,
+	uploader PostCreatePhotoUploader,
+	removed []domain.PostCreateSavedPhoto,
+) (domain.PostPhotosResult, error) {
+	updated, photos, err := s.load(ctx, post.AccessToken)
+	if err != nil {
+		return domain.PostPhotosResult{}, err
+	}
+	result := domain.PostPhotosResult{
+		Action:   action,
+		PostID:   updated.ID,
+		PostName: updated.Name,
+		HasImage: updated.HasImage,
+		Photos:   photos,
+		Removed:  removed,
+	}
+
+	cleanupCtx := context.WithoutCancel(ctx)
+	errs := make([]error, 0)
+	if uploader != nil {
+		errs = append(errs, deletePhotoObjects(cleanupCtx, uploader, removed, "deleting removed")...)
+	}
+	if refresher, ok := uploader.(postTickerRefresher); ok && len(photos) > 0 {
+		removedFirst := len(removed) > 0 && removed[0].Position == 0
+		if len(before) == 0 || before[0].S3Key != photos[0].S3Key || removedFirst {
+			if err := refresher.RefreshPostTicker(cleanupCtx, photos[0]); err != nil {
+				errs = append(errs, fmt.Errorf("refreshing ticker photo: %w", err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return result, fmt.Errorf("photos of post %d were updated, but cleanup failed: %w", post.ID, errors.Join(errs...))
+	}
+	return result, nil
+}
+
+func checkPostPhotoPosition(postID int64, photos []domain.PostCreateSavedPhoto, position int) error {
+	if position < 0 || position >= len(photos) {
+		if len(photos) == 0 {
+			return fmt.Errorf("post %d has no photos", postID)
+		}
+		return fmt.Errorf("post %d has no photo at position %d (positions 0..%d)", postID, position, len(photos)-1)
+	}
+	return nil
+}
+
+func renumberPostPhotos(postID int64, photos []domain.PostCreateSavedPhoto) []domain.PostCreateSavedPhoto {
+	out := make([]domain.PostCreateSavedPhoto, len(photos))
+	for idx, photo := range photos {
+		photo.PostID = postID
+		photo.Position = idx
+		out[idx] = photo
+	}
+	return out
+}
+
+// discardUploadedPhotos deletes objects uploaded for a change that could not
+// be saved and joins any cleanup errors onto cause.
+func discardUploadedPhotos(ctx context.Context, uploader PostCreatePhotoUploader, uploaded []domain.PostCreateSavedPhoto, cause error) error {
+	return errors.Join(append([]error{cause}, deletePhotoObjects(context.WithoutCancel(ctx), uploader, uploaded, "rolling back")...)...)
+}
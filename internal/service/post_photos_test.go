@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+type mockPostPhotoRepo struct {
+	post       domain.Post
+	photos     []domain.PostCreateSavedPhoto
+	replaceErr error
+	replaced   int
+}
+
+func (m *mockPostPhotoRepo) GetPostByAccessToken(_ context.Context, accessToken string) (domain.Post, error) {
+	if accessToken != m.post.AccessToken {
+		return domain.Post{}, domain.ErrNotFound
+	}
+	return m.post, nil
+}
+
+func (m *mockPostPhotoRepo) ListPostPhotos(_ context.Context, _ int64) ([]domain.PostCreateSavedPhoto, error) {
+	return append([]domain.PostCreateSavedPhoto(nil), m.photos...), nil
+}
+
+func (m *mockPostPhotoRepo) ReplacePostPhotos(_ context.Context, _ int64, photos []domain.PostCreateSavedPhoto) error {
+	if m.replaceErr != nil {
+		return m.replaceErr
+	}
+	m.replaced++
+	m.photos = append([]domain.PostCreateSavedPhoto(nil), photos...)
+	m.post.HasImage = len(photos) > 0
+	return nil
+}
+
+func (m *mockPostPhotoRepo) ListRecentPhotoFingerprints(_ context.Context, _ string, _ time.Time) ([]domain.PhotoFingerprint, error) {
+	return nil, nil
+}
+
+// tickerPhotoUploader records RefreshPostTicker calls like local storage.
+type tickerPhotoUploader struct {
+	faultyPhotoUploader
+	tickerMu  sync.Mutex
+	refreshed []string
+}
+
+func (u *tickerPhotoUploader) RefreshPostTicker(_ context.Context, photo domain.PostCreateSavedPhoto) error {
+	u.tickerMu.Lock()
+	defer u.tickerMu.Unlock()
+	u.refreshed = append(u.refreshed, photo.S3Key)
+	return nil
+}
+
+func postPhotoRepoWith(keys ...string) *mockPostPhotoRepo {
+	repo := &mockPostPhotoRepo{post: domain.Post{ID: 7, Name: "Desk", Email: "seller@stanford.edu", AccessToken: "tok", HasImage: len(keys) > 0}}
+	for idx, key := range keys {
+		repo.photos = append(repo.photos, domain.PostCreateSavedPhoto{PostID: 7, S3Key: key, TickerS3Key: "ticker_" + key, Position: idx})
+	}
+	return repo
+}
+
+func photoKeys(photos []domain.PostCreateSavedPhoto) string {
+	keys := make([]string, 0, len(photos))
+	for idx, photo := range photos {
+		if photo.Position != idx {
+			return fmt.Sprintf("non-contiguous positions: %+v", photos)
+		}
+		keys = append(keys, photo.S3Key)
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestPostPhotoService_ListSortsAndRenumbersPositions(t *testing.T) {
+	repo := postPhotoRepoWith()
+	repo.photos = []domain.PostCreateSavedPhoto{
+		{PostID: 7, S3Key: "c", Position: 3},
+		{PostID: 7, S3Key: "a", Position: 0},
+		{PostID: 7, S3Key: "b", Position: 2},
+	}
+
+	result, err := NewPostPhotoService(repo).List(context.Background(), " tok ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := photoKeys(result.Photos); got != "a,b,c" {
+		t.Fatalf("expected a,b,c at 0..2, got %s", got)
+	}
+
+	if _, err := NewPostPhotoService(repo).List(context.Background(), "wrong"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected not found for an unknown token, got %v", err)
+	}
+}
+
+func TestPostPhotoService_AddAppendsAfterExistingPhotos(t *testing.T) {
+	repo := postPhotoRepoWith("old-0")
+	uploader := &faultyPhotoUploader{failPosition: -1}
+
+	result, err := NewPostPhotoService(repo).Add(context.Background(), "tok", fourTestPhotos(t)[:2], uploader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "old-0,v2/posts/7/photo-1.jpg,v2/posts/7/photo-2.jpg"
+	if got := photoKeys(result.Photos); got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if !result.HasImage || result.Action != "add" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	for _, photo := range result.Photos[1:] {
+		if photo.PerceptualHash == 0 {
+			t.Fatalf("expected added photos to carry a perceptual hash")
+		}
+	}
+}
+
+func TestPostPhotoService_AddRejectsMoreThanFourPhotos(t *testing.T) {
+	repo := postPhotoRepoWith("a", "b", "c")
+	uploader := &faultyPhotoUploader{failPosition: -1}
+
+	_, err := NewPostPhotoService(repo).Add(context.Background(), "tok", fourTestPhotos(t)[:2], uploader)
+	if err == nil || !strings.Contains(err.Error(), "At most 4 photos are allowed; this post has 3 and 2 would make 5.") {
+		t.Fatalf("expected photo count error, got %v", err)
+	}
+	if repo.replaced != 0 || len(uploader.uploaded) != 0 {
+		t.Fatalf("expected nothing uploaded or saved")
+	}
+}
+
+func TestPostPhotoService_AddRejectsDuplicateOfExistingPhoto(t *testing.T) {
+	upload := fourTestPhotos(t)[0]
+	repo := postPhotoRepoWith("old-0")
+	repo.photos[0].PerceptualHash = perceptualHash(decodeTestPhoto(t, upload.Content))
+
+	_, err := NewPostPhotoService(repo).Add(context.Background(), "tok", []domain.PostCreatePhotoUpload{upload}, &faultyPhotoUploader{failPosition: -1})
+	if err == nil || !strings.Contains(err.Error(), "Photo at position 1 looks like a duplicate of the photo at position 0.") {
+		t.Fatalf("expected duplicate error, got %v", err)
+	}
+}
+
+func TestPostPhotoService_AddDeletesUploadsWhenSaveFails(t *testing.T) {
+	repo := postPhotoRepoWith()
+	repo.replaceErr = errors.New("unique violation")
+	uploader := &faultyPhotoUploader{failPosition: -1}
+
+	_, err := NewPostPhotoService(repo).Add(context.Background(), "tok", fourTestPhotos(t)[:2], uploader)
+	if err == nil || !strings.Contains(err.Error(), "saving post photos: unique violation") {
+		t.Fatalf("expected save error, got %v", err)
+	}
+	if len(uploader.deleted) != 2 {
+		t.Fatalf("expected both uploads deleted, got %v", uploader.deleted)
+	}
+}
+
+func TestPostPhotoService_RemoveShiftsLaterPhotosDown(t *testing.T) {
+	repo := postPhotoRepoWith("a", "b", "c")
+	uploader := &tickerPhotoUploader{faultyPhotoUploader: faultyPhotoUploader{failPosition: -1}}
+
+	result, err := NewPostPhotoService(repo).Remove(context.Background(), "tok", 0, uploader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := photoKeys(result.Photos); got != "b,c" {
+		t.Fatalf("expected b,c at 0..1, got %s", got)
+	}
+	if len(uploader.deleted) != 1 || uploader.deleted[0] != "a" {
+		t.Fatalf("expected removed photo objects deleted, got %v", uploader.deleted)
+	}
+	if len(uploader.refreshed) != 1 || uploader.refreshed[0] != "b" {
+		t.Fatalf("expected ticker refreshed to the new first photo, got %v", uploader.refreshed)
+	}
+
+	if _, err := NewPostPhotoService(repo).Remove(context.Background(), "tok", 2, uploader); err == nil || !strings.Contains(err.Error(), "no photo at position 2 (positions 0..1)") {
+		t.Fatalf("expected position error, got %v", err)
+	}
+}
+
+func TestPostPhotoService_RemovingLastPhotoClearsHasImage(t *testing.T) {
+	repo := postPhotoRepoWith("a")
+
+	result, err := NewPostPhotoService(repo).Remove(context.Background(), "tok", 0, &faultyPhotoUploader{failPosition: -1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.HasImage || len(result.Photos) != 0 || len(result.Removed) != 1 {
+		t.Fatalf("expected no photos and has_image false, got %+v", result)
+	}
+}
+
+func TestPostPhotoService_ReorderAppliesPermutation(t *testing.T) {
+	repo := postPhotoRepoWith("a", "b", "c", "d")
+	uploader := &tickerPhotoUploader{faultyPhotoUploader: faultyPhotoUploader{failPosition: -1}}
+
+	result, err := NewPostPhotoService(repo).Reorder(context.Background(), "tok", []int{2, 0, 1, 3}, uploader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := photoKeys(result.Photos); got != "c,a,b,d" {
+		t.Fatalf("expected c,a,b,d, got %s", got)
+	}
+	if len(uploader.deleted) != 0 || len(uploader.refreshed) != 1 || uploader.refreshed[0] != "c" {
+		t.Fatalf("expected only a ticker refresh, got deletes %v refreshes %v", uploader.deleted, uploader.refreshed)
+	}
+
+	for _, order := range [][]int{{0, 1, 2}, {0, 0, 1, 2}, {0, 1, 2, 4}} {
+		if _, err := NewPostPhotoService(repo).Reorder(context.Background(), "tok", order, nil); err == nil {
+			t.Fatalf("expected order %v to be rejected", order)
+		}
+	}
+}
+
+func TestPostPhotoService_ReplaceSwapsPhotoAndDeletesOldObjects(t *testing.T) {
+	repo := postPhotoRepoWith("a", "b")
+	uploader := &faultyPhotoUploader{failPosition: -1}
+
+	result, err := NewPostPhotoService(repo).Replace(context.Background(), "tok", 1, fourTestPhotos(t)[0], uploader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := photoKeys(result.Photos); got != "a,v2/posts/7/photo-1.jpg" {
+		t.Fatalf("unexpected photos %s", got)
+	}
+	if len(uploader.deleted) != 1 || uploader.deleted[0] != "b" {
+		t.Fatalf("expected old photo deleted, got %v", uploader.deleted)
+	}
+}
@@ -11,24 +11,36 @@ import (
 )
 
 const (
-	responseSafetyLine  = "Safety: If someone sends you a check, do not send them any money back. https://supost.com/safety"
-	responseContactLine = "Report responses to contact@supost.com"
+	responseSafetyURL    = "https://supost.com/safety"
+	responseContactEmail = "contact@supost.com"
 )
 
 // PostRespondRepository defines post lookup + message persistence operations.
 type PostRespondRepository interface {
 	GetPostByID(ctx context.Context, postID int64) (domain.Post, error)
 	CreateResponseMessage(ctx context.Context, postID int64, replyToEmail, message, ip, userAgent string) (domain.Message, error)
+	SetMessageMailgunID(ctx context.Context, messageID int64, mailgunMessageID string) error
 }
 
-// PostRespondEmailSender defines response-email side effects.
+// PostResponseRecorder is implemented by repositories that cannot read a
+// poster's email directly, such as the Supabase REST backend. It stores the
+// response and returns the destination address in one step, so the address
+// is only handed out together with a saved message.
+type PostResponseRecorder interface {
+	RecordPostResponse(ctx context.Context, postID int64, replyToEmail, message, ip, userAgent string) (domain.Message, string, error)
+}
+
+// PostRespondEmailSender defines response-email side effects. The returned
+// provider message id correlates later delivery events with the message row.
 type PostRespondEmailSender interface {
-	SendResponseEmail(ctx context.Context, msg domain.ResponseEmailMessage) error
+	SendResponseEmail(ctx context.Context, msg domain.ResponseEmailMessage) (string, error)
 }
 
 // PostRespondService orchestrates post response sends.
 type PostRespondService struct {
-	repo PostRespondRepository
+	repo      PostRespondRepository
+	templates *EmailTemplates
+	relay     *MailRelay
 }
 
 // NewPostRespondService constructs PostRespondService.
@@ -36,6 +48,19 @@ func NewPostRespondService(repo PostRespondRepository) *PostRespondService {
 	return &PostRespondService{repo: repo}
 }
 
+// WithEmailTemplates overrides the embedded response-email templates.
+func (s *PostRespondService) WithEmailTemplates(templates *EmailTemplates) *PostRespondService {
+	s.templates = templates
+	return s
+}
+
+// WithReplyRelay hides the responder's address behind a relay address. The
+// message row is persisted before sending so its id can key the relay.
+func (s *PostRespondService) WithReplyRelay(relay *MailRelay) *PostRespondService {
+	s.relay = relay
+	return s
+}
+
 // Respond validates, optionally sends, and optionally persists a response message.
 func (s *PostRespondService) Respond(
 	ctx context.Context,
@@ -54,14 +79,29 @@ func (s *PostRespondService) Respond(
 	if err != nil {
 		return domain.PostRespondResult{}, err
 	}
-	if strings.TrimSpace(post.Email) == "" {
-		return domain.PostRespondResult{}, fmt.Errorf("post %d has no destination email", normalized.PostID)
+	// A repository that can read the poster's email sends before saving; one
+	// that cannot records the message first and gets the address back.
+	recorder, _ := s.repo.(PostResponseRecorder)
+	if strings.TrimSpace(post.Email) != "" {
+		recorder = nil
 	}
-	if strings.TrimSpace(post.AccessToken) == "" {
-		return domain.PostRespondResult{}, fmt.Errorf("post %d has no access token", normalized.PostID)
+	if recorder == nil {
+		if strings.TrimSpace(post.Email) == "" {
+			return domain.PostRespondResult{}, fmt.Errorf("post %d has no destination email", normalized.PostID)
+		}
+		if strings.TrimSpace(post.AccessToken) == "" {
+			return domain.PostRespondResult{}, fmt.Errorf("post %d has no access token", normalized.PostID)
+		}
 	}
 
-	subject, body := buildResponseEmailContent(post, normalized, baseURL)
+	templates, err := resolveEmailTemplates(s.templates)
+	if err != nil {
+		return domain.PostRespondResult{}, err
+	}
+	subject, body, htmlBody, err := buildResponseEmailContent(templates, post, normalized, baseURL)
+	if err != nil {
+		return domain.PostRespondResult{}, err
+	}
 	result := domain.PostRespondResult{
 		DryRun:       dryRun,
 		PostID:       post.ID,
@@ -71,6 +111,7 @@ func (s *PostRespondService) Respond(
 		EmailSent:    false,
 		Subject:      subject,
 		Body:         body,
+		HTMLBody:     htmlBody,
 		SentAt:       time.Now(),
 	}
 
@@ -81,17 +122,27 @@ func (s *PostRespondService) Respond(
 		return domain.PostRespondResult{}, fmt.Errorf("response email sender is required")
 	}
 
+	if recorder != nil {
+		return s.respondRecordedFirst(ctx, recorder, templates, post, normalized, baseURL, fromEmail, sender, result)
+	}
+	if s.relay != nil {
+		return s.respondViaRelay(ctx, templates, post, normalized, baseURL, fromEmail, sender, result)
+	}
+
 	msg := domain.ResponseEmailMessage{
 		From:    strings.TrimSpace(fromEmail),
 		To:      result.PostEmail,
 		ReplyTo: result.ReplyTo,
 		Subject: subject,
 		Text:    body,
+		HTML:    htmlBody,
 	}
-	if err := sender.SendResponseEmail(ctx, msg); err != nil {
+	mailgunMessageID, err := sender.SendResponseEmail(ctx, msg)
+	if err != nil {
 		return domain.PostRespondResult{}, err
 	}
 	result.EmailSent = true
+	result.MailgunMessageID = mailgunMessageID
 
 	saved, err := s.repo.CreateResponseMessage(ctx, post.ID, result.ReplyTo, normalized.Message, normalized.IP, normalized.UserAgent)
 	if err != nil {
@@ -101,6 +152,132 @@ func (s *PostRespondService) Respond(
 		result.MessageID = saved.ID
 	}
 	result.MessageSaved = true
+	if err := s.recordMailgunID(ctx, result.MessageID, mailgunMessageID); err != nil {
+		return domain.PostRespondResult{}, err
+	}
+	return result, nil
+}
+
+func (s *PostRespondService) recordMailgunID(ctx context.Context, messageID int64, mailgunMessageID string) error {
+	if messageID <= 0 || strings.TrimSpace(mailgunMessageID) == "" {
+		return nil
+	}
+	if err := s.repo.SetMessageMailgunID(ctx, messageID, mailgunMessageID); err != nil {
+		return fmt.Errorf("recording mailgun message id: %w", err)
+	}
+	return nil
+}
+
+// respondRecordedFirst saves the message through recorder, which also
+// returns the poster's email, and then sends. The post's access token is not
+// available on this path, so the email leaves out the delete link.
+func (s *PostRespondService) respondRecordedFirst(
+	ctx context.Context,
+	recorder PostResponseRecorder,
+	templates *EmailTemplates,
+	post domain.Post,
+	normalized domain.PostRespondSubmission,
+	baseURL string,
+	fromEmail string,
+	sender PostRespondEmailSender,
+	result domain.PostRespondResult,
+) (domain.PostRespondResult, error) {
+	saved, postEmail, err := recorder.RecordPostResponse(ctx, post.ID, normalized.ReplyTo, normalized.Message, normalized.IP, normalized.UserAgent)
+	if err != nil {
+		return domain.PostRespondResult{}, err
+	}
+	if strings.TrimSpace(postEmail) == "" {
+		return domain.PostRespondResult{}, fmt.Errorf("post %d has no destination email", post.ID)
+	}
+	result.PostEmail = strings.TrimSpace(postEmail)
+	result.MessageID = saved.ID
+	result.MessageSaved = true
+	if s.relay != nil {
+		if saved.ID <= 0 {
+			return domain.PostRespondResult{}, fmt.Errorf("invalid persisted message id for reply relay")
+		}
+		return s.sendViaRelay(ctx, templates, post, normalized, baseURL, fromEmail, sender, result)
+	}
+
+	mailgunMessageID, err := sender.SendResponseEmail(ctx, domain.ResponseEmailMessage{
+		From:    strings.TrimSpace(fromEmail),
+		To:      result.PostEmail,
+		ReplyTo: result.ReplyTo,
+		Subject: result.Subject,
+		Text:    result.Body,
+		HTML:    result.HTMLBody,
+	})
+	if err != nil {
+		return domain.PostRespondResult{}, err
+	}
+	result.EmailSent = true
+	result.MailgunMessageID = mailgunMessageID
+	if err := s.recordMailgunID(ctx, result.MessageID, mailgunMessageID); err != nil {
+		return domain.PostRespondResult{}, err
+	}
+	return result, nil
+}
+
+func (s *PostRespondService) respondViaRelay(
+	ctx context.Context,
+	templates *EmailTemplates,
+	post domain.Post,
+	normalized domain.PostRespondSubmission,
+	baseURL string,
+	fromEmail string,
+	sender PostRespondEmailSender,
+	result domain.PostRespondResult,
+) (domain.PostRespondResult, error) {
+	saved, err := s.repo.CreateResponseMessage(ctx, post.ID, normalized.ReplyTo, normalized.Message, normalized.IP, normalized.UserAgent)
+	if err != nil {
+		return domain.PostRespondResult{}, err
+	}
+	if saved.ID <= 0 {
+		return domain.PostRespondResult{}, fmt.Errorf("invalid persisted message id for reply relay")
+	}
+	result.MessageID = saved.ID
+	result.MessageSaved = true
+	return s.sendViaRelay(ctx, templates, post, normalized, baseURL, fromEmail, sender, result)
+}
+
+// sendViaRelay sends the response to the poster with a relay Reply-To keyed
+// by the already saved result.MessageID.
+func (s *PostRespondService) sendViaRelay(
+	ctx context.Context,
+	templates *EmailTemplates,
+	post domain.Post,
+	normalized domain.PostRespondSubmission,
+	baseURL string,
+	fromEmail string,
+	sender PostRespondEmailSender,
+	result domain.PostRespondResult,
+) (domain.PostRespondResult, error) {
+	var err error
+	relayed := normalized
+	relayed.ReplyTo = s.relay.Address(result.MessageID, domain.RelayToResponder, result.PostEmail)
+	result.ReplyRelay = relayed.ReplyTo
+	result.Subject, result.Body, result.HTMLBody, err = buildResponseEmailContent(templates, post, relayed, baseURL)
+	if err != nil {
+		return domain.PostRespondResult{}, err
+	}
+
+	msg := domain.ResponseEmailMessage{
+		From:    strings.TrimSpace(fromEmail),
+		To:      result.PostEmail,
+		ReplyTo: result.ReplyRelay,
+		Subject: result.Subject,
+		Text:    result.Body,
+		HTML:    result.HTMLBody,
+	}
+	mailgunMessageID, err := sender.SendResponseEmail(ctx, msg)
+	if err != nil {
+		return domain.PostRespondResult{}, err
+	}
+	result.EmailSent = true
+	result.MailgunMessageID = mailgunMessageID
+	if err := s.recordMailgunID(ctx, result.MessageID, mailgunMessageID); err != nil {
+		return domain.PostRespondResult{}, err
+	}
 	return result, nil
 }
 
@@ -144,7 +321,7 @@ func isValidEmail(value string) bool {
 	return strings.Contains(domainPart, ".")
 }
 
-func buildResponseEmailContent(post domain.Post, input domain.PostRespondSubmission, baseURL string) (string, string) {
+func buildResponseEmailContent(templates *EmailTemplates, post domain.Post, input domain.PostRespondSubmission, baseURL string) (string, string, string, error) {
 	root := strings.TrimRight(strings.TrimSpace(baseURL), "/")
 	if root == "" {
 		root = defaultSupostBaseURL
@@ -158,28 +335,27 @@ func buildResponseEmailContent(post domain.Post, input domain.PostRespondSubmiss
 	if postedAt.IsZero() {
 		postedAt = time.Now()
 	}
-	postedLine := postedAt.Format("Mon, Jan 2, 2006 03:04 PM")
-	publishURL := root + "/post/publish/" + strings.TrimSpace(post.AccessToken)
-	postURL := fmt.Sprintf("%s/post/index/%d", root, post.ID)
+
+	publishURL := ""
+	if token := strings.TrimSpace(post.AccessToken); token != "" {
+		publishURL = root + "/post/publish/" + token
+	}
 
 	subject := fmt.Sprintf("SUpost - %s response: %s", input.ReplyTo, title)
-	body := strings.Join([]string{
-		"Reply to: " + input.ReplyTo,
-		"",
-		input.Message,
-		"",
-		responseSafetyLine,
-		"",
-		fmt.Sprintf("%s - Posted: %s", title, postedLine),
-		"",
-		"To delete your post, use this link and click 'Delete your post.'",
-		publishURL,
-		"",
-		postURL,
-		"",
-		responseContactLine,
-	}, "\n")
-	return subject, body
+	text, html, err := templates.RenderResponse(domain.ResponseEmailData{
+		ReplyTo:      input.ReplyTo,
+		Message:      input.Message,
+		PostName:     title,
+		PostedAt:     postedAt.Format("Mon, Jan 2, 2006 03:04 PM"),
+		PublishURL:   publishURL,
+		PostURL:      fmt.Sprintf("%s/post/index/%d", root, post.ID),
+		SafetyURL:    responseSafetyURL,
+		ContactEmail: responseContactEmail,
+	})
+	if err != nil {
+		return "", "", "", err
+	}
+	return subject, text, html, nil
 }
 
 func postTimestamp(post domain.Post) time.Time {
@@ -15,6 +15,11 @@ type mockPostRespondRepo struct {
 	saveCalled   bool
 }
 
+func (m *mockPostRespondRepo) SetMessageMailgunID(_ context.Context, _ int64, mailgunMessageID string) error {
+	m.savedMessage.MailgunMessageID = mailgunMessageID
+	return nil
+}
+
 func (m *mockPostRespondRepo) GetPostByID(_ context.Context, _ int64) (domain.Post, error) {
 	return m.post, nil
 }
@@ -36,10 +41,10 @@ type mockPostRespondSender struct {
 	sent bool
 }
 
-func (m *mockPostRespondSender) SendResponseEmail(_ context.Context, msg domain.ResponseEmailMessage) error {
+func (m *mockPostRespondSender) SendResponseEmail(_ context.Context, msg domain.ResponseEmailMessage) (string, error) {
 	m.last = msg
 	m.sent = true
-	return nil
+	return "20260226.77@mg.supost.com", nil
 }
 
 func TestPostRespondService_DryRun(t *testing.T) {
@@ -111,6 +116,9 @@ func TestPostRespondService_SendAndPersist(t *testing.T) {
 	if repo.savedMessage.IP != "198.51.100.7" {
 		t.Fatalf("expected ip to be persisted, got %q", repo.savedMessage.IP)
 	}
+	if result.MailgunMessageID != "20260226.77@mg.supost.com" || repo.savedMessage.MailgunMessageID != result.MailgunMessageID {
+		t.Fatalf("expected mailgun message id to be recorded, got result=%q saved=%q", result.MailgunMessageID, repo.savedMessage.MailgunMessageID)
+	}
 }
 
 func TestPostRespondService_Validation(t *testing.T) {
@@ -173,3 +181,53 @@ func TestPostRespondService_ValidationIPFormat(t *testing.T) {
 		t.Fatalf("unexpected error %v", err)
 	}
 }
+
+// recordingPostRespondRepo cannot read the poster's email, like the Supabase
+// REST backend; RecordPostResponse hands it out with the saved message.
+type recordingPostRespondRepo struct {
+	mockPostRespondRepo
+	postEmail string
+	recorded  bool
+}
+
+func (m *recordingPostRespondRepo) RecordPostResponse(ctx context.Context, postID int64, replyToEmail, message, ip, userAgent string) (domain.Message, string, error) {
+	m.recorded = true
+	saved, err := m.CreateResponseMessage(ctx, postID, replyToEmail, message, ip, userAgent)
+	return saved, m.postEmail, err
+}
+
+func TestPostRespondService_RecordsFirstWhenEmailIsPrivate(t *testing.T) {
+	repo := &recordingPostRespondRepo{
+		mockPostRespondRepo: mockPostRespondRepo{post: domain.Post{
+			ID:           130031908,
+			Name:         "Looking for a buddy to go to the movies",
+			TimePostedAt: time.Date(2026, time.February, 26, 17, 32, 0, 0, time.UTC),
+		}},
+		postEmail: "owner@stanford.edu",
+	}
+	sender := &mockPostRespondSender{}
+	svc := NewPostRespondService(repo)
+	input := domain.PostRespondSubmission{PostID: 130031908, Message: "Is it still available?", ReplyTo: "gwientjes@gmail.com"}
+
+	preview, err := svc.Respond(context.Background(), input, true, "https://supost.com", "response@mg.supost.com", sender)
+	if err != nil || repo.recorded || preview.PostEmail != "" {
+		t.Fatalf("expected a dry run without recording, got %+v (%v)", preview, err)
+	}
+
+	result, err := svc.Respond(context.Background(), input, false, "https://supost.com", "response@mg.supost.com", sender)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !repo.recorded || !result.MessageSaved || !result.EmailSent || result.MessageID != 77 {
+		t.Fatalf("expected the message recorded and sent, got %+v", result)
+	}
+	if sender.last.To != "owner@stanford.edu" || result.PostEmail != "owner@stanford.edu" {
+		t.Fatalf("expected the recorded address as destination, got %q", sender.last.To)
+	}
+	if strings.Contains(sender.last.Text, "/post/publish/") || strings.Contains(sender.last.Text, "Delete your post") {
+		t.Fatalf("expected no delete link without the post's access token:\n%s", sender.last.Text)
+	}
+	if repo.savedMessage.MailgunMessageID != "20260226.77@mg.supost.com" {
+		t.Fatalf("expected mailgun id recorded, got %q", repo.savedMessage.MailgunMessageID)
+	}
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+)
+
+// ProfileRepository reads and writes public.profiles rows keyed by Auth
+// user id. GetProfile and UpdateProfile return domain.ErrNotFound when the
+// row does not exist.
+type ProfileRepository interface {
+	GetProfile(ctx context.Context, userID string) (domain.User, error)
+	CreateProfileIfMissing(ctx context.Context, profile domain.User) (domain.User, bool, error)
+	UpdateProfile(ctx context.Context, profile domain.User, at time.Time) (domain.User, error)
+}
+
+// ProfileService shows and edits the logged-in user's profile. The row is
+// created from the user's metadata the first time it is needed, so
+// display_name and phone given at signup carry over. Show and Update act on
+// the user Supabase reports for the session.
+type ProfileService struct {
+	repo     ProfileRepository
+	sessions AuthSessionSource
+	now      func() time.Time
+}
+
+// NewProfileService constructs ProfileService.
+func NewProfileService(repo ProfileRepository, sessions AuthSessionSource) *ProfileService {
+	return &ProfileService{repo: repo, sessions: sessions, now: time.Now}
+}
+
+// EnsureProfile creates the profile for a just-issued session unless it
+// already exists. The bool reports whether it was created.
+func (s *ProfileService) EnsureProfile(ctx context.Context, session domain.AuthSession) (domain.User, bool, error) {
+	identity, err := decodeAccessTokenIdentity(session.AccessToken)
+	if err != nil {
+		return domain.User{}, false, fmt.Errorf("%w: %v", domain.ErrUnauthorized, err)
+	}
+	return s.ensure(ctx, identity)
+}
+
+// Show returns the logged-in user's profile, creating it if the user logged
+// in before profiles existed.
+func (s *ProfileService) Show(ctx context.Context) (domain.User, error) {
+	identity, err := s.sessions.VerifiedIdentity(ctx)
+	if err != nil {
+		return domain.User{}, err
+	}
+	profile, _, err := s.ensure(ctx, identity)
+	return profile, err
+}
+
+// Update changes the display name or phone. A provided empty phone clears
+// it; the display name cannot be cleared, matching signup.
+func (s *ProfileService) Update(ctx context.Context, update domain.ProfileUpdate) (domain.User, error) {
+	if !update.DisplayNameProvided && !update.PhoneProvided {
+		return domain.User{}, fmt.Errorf("nothing to update: set a display name or phone")
+	}
+	update.DisplayName = strings.TrimSpace(update.DisplayName)
+	update.Phone = strings.TrimSpace(update.Phone)
+	if update.DisplayNameProvided && update.DisplayName == "" {
+		return domain.User{}, fmt.Errorf("display_name cannot be empty")
+	}
+	if update.PhoneProvided && update.Phone != "" && !looksLikePhone(update.Phone) {
+		return domain.User{}, fmt.Errorf("phone must be a valid international number (example: +16505551234)")
+	}
+
+	identity, err := s.sessions.VerifiedIdentity(ctx)
+	if err != nil {
+		return domain.User{}, err
+	}
+	profile, _, err := s.ensure(ctx, identity)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	// The repository writes every field, so carry over what is not changing.
+	if update.DisplayNameProvided {
+		profile.DisplayName = update.DisplayName
+	}
+	if update.PhoneProvided {
+		profile.Phone = update.Phone
+	}
+	updated, err := s.repo.UpdateProfile(ctx, profile, s.now())
+	if err != nil {
+		return domain.User{}, fmt.Errorf("updating profile: %w", err)
+	}
+	return updated, nil
+}
+
+func (s *ProfileService) ensure(ctx context.Context, identity domain.AuthIdentity) (domain.User, bool, error) {
+	if identity.UserID == "" {
+		return domain.User{}, false, fmt.Errorf("%w: session has no user id", domain.ErrUnauthorized)
+	}
+	profile, err := s.repo.GetProfile(ctx, identity.UserID)
+	if err == nil {
+		return profile, false, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return domain.User{}, false, fmt.Errorf("loading profile: %w", err)
+	}
+	profile, created, err := s.repo.CreateProfileIfMissing(ctx, domain.User{
+		ID:          identity.UserID,
+		Email:       identity.Email,
+		DisplayName: identity.DisplayName,
+		Phone:       identity.Phone,
+	})
+	if err != nil {
+		return domain.User{}, false, fmt.Errorf("creating profile: %w", err)
+	}
+	return profile, created, nil
+}
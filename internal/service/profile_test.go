@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Capmus-Team/supost-cli/internal/domain"
+	"github.com/Capmus-Team/supost-cli/internal/repository"
+)
+
+func TestProfileService_EnsureCopiesSignupMetadataOnce(t *testing.T) {
+	repo := repository.NewInMemory()
+	svc := NewProfileService(repo, nil)
+	session := domain.AuthSession{AccessToken: testAccessToken(t, map[string]any{
+		"sub":           "user-1",
+		"email":         "Pat@Stanford.edu",
+		"user_metadata": map[string]any{"display_name": " Pat ", "phone": "+16505551234"},
+	})}
+
+	profile, created, err := svc.EnsureProfile(context.Background(), session)
+	if err != nil || !created {
+		t.Fatalf("expected the profile created, got %v, %v", created, err)
+	}
+	if profile.ID != "user-1" || profile.Email != "pat@stanford.edu" || profile.DisplayName != "Pat" || profile.Phone != "+16505551234" {
+		t.Fatalf("expected signup metadata copied, got %+v", profile)
+	}
+
+	if _, created, err := svc.EnsureProfile(context.Background(), session); err != nil || created {
+		t.Fatalf("expected the second login to reuse the profile, got %v, %v", created, err)
+	}
+}
+
+func TestProfileService_ShowAndUpdate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := repository.NewInMemory()
+	svc := NewProfileService(repo, loggedInAs(t, map[string]any{"sub": "user-1", "email": "pat@stanford.edu", "exp": now.Add(time.Hour).Unix()}))
+	svc.now = func() time.Time { return now }
+
+	shown, err := svc.Show(context.Background())
+	if err != nil || shown.ID != "user-1" || shown.DisplayName != "" {
+		t.Fatalf("expected a profile created on first show, got %+v, %v", shown, err)
+	}
+
+	updated, err := svc.Update(context.Background(), domain.ProfileUpdate{DisplayName: " Pat Lee ", DisplayNameProvided: true, Phone: "+16505551234", PhoneProvided: true})
+	if err != nil || updated.DisplayName != "Pat Lee" || updated.Phone != "+16505551234" || !updated.UpdatedAt.Equal(now) {
+		t.Fatalf("unexpected update result %+v, %v", updated, err)
+	}
+
+	cleared, err := svc.Update(context.Background(), domain.ProfileUpdate{PhoneProvided: true})
+	if err != nil || cleared.Phone != "" || cleared.DisplayName != "Pat Lee" {
+		t.Fatalf("expected only the phone cleared, got %+v, %v", cleared, err)
+	}
+
+	for _, tc := range []struct {
+		update domain.ProfileUpdate
+		want   string
+	}{
+		{domain.ProfileUpdate{}, "nothing to update"},
+		{domain.ProfileUpdate{DisplayName: "  ", DisplayNameProvided: true}, "display_name cannot be empty"},
+		{domain.ProfileUpdate{Phone: "call me", PhoneProvided: true}, "phone must be a valid"},
+	} {
+		if _, err := svc.Update(context.Background(), tc.update); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("expected %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestProfileService_RequiresSession(t *testing.T) {
+	svc := NewProfileService(repository.NewInMemory(), staticAuthSession{err: domain.ErrUnauthorized})
+	if _, err := svc.Show(context.Background()); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected unauthorized, got %v", err)
+	}
+}
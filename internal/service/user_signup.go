@@ -16,7 +16,9 @@ type UserSignupProvider interface {
 
 // UserSignupService validates signup inputs and delegates to Supabase Auth.
 type UserSignupService struct {
-	provider UserSignupProvider
+	provider    UserSignupProvider
+	emailPolicy *EmailDomainChecker
+	passwords   *PasswordChecker
 }
 
 // NewUserSignupService constructs UserSignupService.
@@ -24,6 +26,13 @@ func NewUserSignupService(provider UserSignupProvider) *UserSignupService {
 	return &UserSignupService{provider: provider}
 }
 
+// WithPasswordChecker replaces the default password policy, which has no
+// common-password-variant source.
+func (s *UserSignupService) WithPasswordChecker(checker *PasswordChecker) *UserSignupService {
+	s.passwords = checker
+	return s
+}
+
 // SignUp validates a signup payload and creates a new Supabase Auth user.
 func (s *UserSignupService) SignUp(ctx context.Context, submission domain.UserSignupSubmission) (domain.UserSignupResult, error) {
 	if s.provider == nil {
@@ -43,12 +52,22 @@ func (s *UserSignupService) SignUp(ctx context.Context, submission domain.UserSi
 	}
 	if normalized.Email == "" || !strings.Contains(normalized.Email, "@") {
 		problems = append(problems, "email must be valid")
+	} else if checker := s.emailDomainChecker(); !checker.Allowed(normalized.Email) {
+		problems = append(problems, "email must be "+checker.Requirement())
 	}
 	if !looksLikePhone(normalized.Phone) {
 		problems = append(problems, "phone must be a valid international number (example: +16505551234)")
 	}
-	if len(normalized.Password) < 8 {
-		problems = append(problems, "password must be at least 8 characters")
+	passwords := s.passwords
+	if passwords == nil {
+		passwords = NewPasswordChecker(domain.PasswordPolicy{})
+	}
+	passwordCheck, err := passwords.Check(ctx, normalized.Password, normalized.Email, normalized.DisplayName)
+	if err != nil {
+		return domain.UserSignupResult{}, err
+	}
+	for _, problem := range passwordCheck.Problems {
+		problems = append(problems, problem.Message)
 	}
 	if len(problems) > 0 {
 		return domain.UserSignupResult{}, errors.New(strings.Join(problems, "; "))
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -65,3 +66,21 @@ func TestUserSignupService_SignUp_ValidationErrors(t *testing.T) {
 		t.Fatalf("expected validation error")
 	}
 }
+
+func TestUserSignupService_SignUp_ReportsPasswordPolicyProblems(t *testing.T) {
+	provider := &mockUserSignupProvider{}
+	_, err := NewUserSignupService(provider).SignUp(context.Background(), domain.UserSignupSubmission{
+		DisplayName: "Greg Wientjes",
+		Email:       "wientjes@stanford.edu",
+		Phone:       "+16505551234",
+		Password:    "wientjes2024",
+	})
+	if err == nil || provider.called {
+		t.Fatalf("expected a weak password refused, got %v", err)
+	}
+	for _, want := range []string{"password must mix at least 3", "must not contain your email name", "must not contain your display name"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+-- Provider message id returned when the response email is accepted; event
+-- webhooks (delivered/failed/complained/unsubscribed) look rows up by it.
+alter table app_private.message
+  add column if not exists mailgun_message_id text;
+
+create index if not exists message_mailgun_message_id_idx
+  on app_private.message (mailgun_message_id)
+  where mailgun_message_id is not null;
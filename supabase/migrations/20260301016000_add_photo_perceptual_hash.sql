@@ -0,0 +1,9 @@
+-- 64-bit difference hash of each uploaded photo (stored as signed bigint).
+-- post create compares new uploads against the poster's recent photos to
+-- reject re-uploads of the same picture.
+alter table public.photo
+  add column if not exists perceptual_hash bigint;
+
+-- Recent-post lookup by poster for the duplicate check.
+create index if not exists post_lower_email_time_posted_idx
+  on public.post (lower(email), time_posted desc);
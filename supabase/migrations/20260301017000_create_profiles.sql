@@ -0,0 +1,50 @@
+-- One profile per Supabase Auth user. Signup keeps display_name and phone
+-- in user metadata only; the CLI copies them here on first login, and
+-- `supost me profile update` edits them.
+create table if not exists public.profiles (
+  id uuid not null,
+  email extensions.citext null,
+  display_name text null,
+  phone text null,
+  created_at timestamp with time zone not null default now(),
+  updated_at timestamp with time zone null,
+  constraint profiles_pkey primary key (id),
+  constraint profiles_id_fkey
+    foreign key (id)
+    references auth.users (id)
+    on delete cascade,
+  constraint profiles_display_name_not_blank check (display_name is null or length(trim(display_name)) > 0)
+);
+
+create index if not exists profiles_email_idx
+  on public.profiles using btree (email);
+
+create trigger trg_profiles_set_updated_at
+before update on public.profiles
+for each row
+execute function set_updated_at();
+
+-- Each user reads and edits only their own profile.
+alter table public.profiles enable row level security;
+
+drop policy if exists profiles_owner_read on public.profiles;
+create policy profiles_owner_read
+on public.profiles
+for select
+to authenticated
+using (id = auth.uid());
+
+drop policy if exists profiles_owner_insert on public.profiles;
+create policy profiles_owner_insert
+on public.profiles
+for insert
+to authenticated
+with check (id = auth.uid());
+
+drop policy if exists profiles_owner_update on public.profiles;
+create policy profiles_owner_update
+on public.profiles
+for update
+to authenticated
+using (id = auth.uid())
+with check (id = auth.uid());
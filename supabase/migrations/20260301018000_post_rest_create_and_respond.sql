@@ -0,0 +1,169 @@
+-- Lets `post create` and `post respond` run over PostgREST with only the
+-- publishable key. Pending posts and their photos are written under RLS;
+-- reading a poster's email and deleting a pending post go through
+-- SECURITY DEFINER functions, so anon never needs those columns or a
+-- delete policy.
+
+-- New posts start pending (status 0) and carry the token that publishes them.
+drop policy if exists post_insert_pending on public.post;
+create policy post_insert_pending
+on public.post
+for insert
+to anon, authenticated
+with check (
+  status = 0
+  and coalesce(length(access_token), 0) >= 16
+);
+
+-- Photo rows may only be written for posts that are still pending. The
+-- update policy lets the upsert on (post_id, position) merge.
+drop policy if exists photo_insert_pending on public.photo;
+create policy photo_insert_pending
+on public.photo
+for insert
+to anon, authenticated
+with check (
+  exists (
+    select 1
+    from public.post p
+    where p.id = photo.post_id
+      and p.status = 0
+  )
+);
+
+drop policy if exists photo_update_pending on public.photo;
+create policy photo_update_pending
+on public.photo
+for update
+to anon, authenticated
+using (
+  exists (
+    select 1
+    from public.post p
+    where p.id = photo.post_id
+      and p.status = 0
+  )
+)
+with check (
+  exists (
+    select 1
+    from public.post p
+    where p.id = photo.post_id
+      and p.status = 0
+  )
+);
+
+-- Rolls back a failed submit. The access token proves the caller created
+-- the post; photo rows go with it through the cascade.
+create or replace function public.delete_pending_post(p_post_id bigint, p_access_token text)
+returns boolean
+language plpgsql
+security definer
+set search_path = ''
+as $$
+declare
+  deleted bigint;
+begin
+  delete from public.post
+  where id = p_post_id
+    and status = 0
+    and access_token = p_access_token
+  returning id into deleted;
+  return deleted is not null;
+end;
+$$;
+
+-- Stores a response to an active post and returns the poster's address so
+-- the caller can send the email. Every address handed out leaves a message
+-- row, and one reply-to address gets at most 20 responses an hour.
+create or replace function public.respond_to_post(
+  p_post_id bigint,
+  p_reply_to text,
+  p_message text,
+  p_ip text default null,
+  p_user_agent text default null
+)
+returns table (
+  id bigint,
+  post_id bigint,
+  message text,
+  ip text,
+  email text,
+  raw_email text,
+  source text,
+  status text,
+  mailgun_message_id text,
+  user_agent text,
+  scammed boolean,
+  created_at timestamp with time zone,
+  updated_at timestamp with time zone,
+  post_email text
+)
+language plpgsql
+security definer
+set search_path = ''
+as $$
+declare
+  recipient text;
+begin
+  if coalesce(trim(p_message), '') = '' or coalesce(trim(p_reply_to), '') = '' then
+    raise exception 'message and reply_to are required' using errcode = '22023';
+  end if;
+
+  select p.email::text into recipient
+  from public.post p
+  where p.id = p_post_id
+    and p.status = 1;
+  if recipient is null or trim(recipient) = '' then
+    raise exception 'post % is not active or has no destination email', p_post_id using errcode = 'P0002';
+  end if;
+
+  if (
+    select count(*)
+    from app_private.message m
+    where m.email = p_reply_to::extensions.citext
+      and m.created_at > now() - interval '1 hour'
+  ) >= 20 then
+    raise exception 'too many responses from %; try again later', p_reply_to using errcode = '53400';
+  end if;
+
+  return query
+  insert into app_private.message as m (
+    message, post_id, ip, email, raw_email, source, status, user_agent, scammed, created_at, updated_at
+  ) values (
+    p_message, p_post_id, nullif(p_ip, '')::inet, p_reply_to, p_reply_to, 'cli', 'queued',
+    nullif(p_user_agent, ''), false, now(), now()
+  )
+  returning
+    m.id, m.post_id, m.message, host(m.ip), m.email::text, m.raw_email::text, m.source, m.status,
+    m.mailgun_message_id, m.user_agent, m.scammed, m.created_at, m.updated_at, recipient;
+end;
+$$;
+
+-- Records the provider id of a response email once; event webhooks look the
+-- message up by it.
+create or replace function public.set_response_mailgun_id(p_message_id bigint, p_mailgun_message_id text)
+returns boolean
+language plpgsql
+security definer
+set search_path = ''
+as $$
+declare
+  updated bigint;
+begin
+  update app_private.message
+  set mailgun_message_id = p_mailgun_message_id,
+      updated_at = now()
+  where id = p_message_id
+    and mailgun_message_id is null
+  returning id into updated;
+  return updated is not null;
+end;
+$$;
+
+revoke all on function public.delete_pending_post(bigint, text) from public;
+revoke all on function public.respond_to_post(bigint, text, text, text, text) from public;
+revoke all on function public.set_response_mailgun_id(bigint, text) from public;
+grant execute on function public.delete_pending_post(bigint, text) to anon, authenticated;
+grant execute on function public.respond_to_post(bigint, text, text, text, text) to anon, authenticated;
+grant execute on function public.set_response_mailgun_id(bigint, text) to anon, authenticated;